@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
@@ -55,6 +56,9 @@ func init() {
 
 	flags.BoolVar(&autoUpdateOptions.DryRun, "dry-run", false, "Check for pending updates")
 	flags.BoolVar(&autoUpdateOptions.Rollback, "rollback", true, "Rollback to previous image if update fails")
+	healthTimeoutFlagName := "health-timeout"
+	flags.DurationVar(&autoUpdateOptions.HealthTimeout, healthTimeoutFlagName, 2*time.Minute, "Wait up to this long for updated containers to pass their health checks")
+	_ = autoUpdateCommand.RegisterFlagCompletionFunc(healthTimeoutFlagName, completion.AutocompleteNone)
 
 	flags.StringVar(&autoUpdateOptions.format, "format", "", "Change the output format to JSON or a Go template")
 	_ = autoUpdateCommand.RegisterFlagCompletionFunc("format", common.AutocompleteFormat(&autoUpdateOutput{}))
@@ -82,7 +86,7 @@ func autoUpdate(cmd *cobra.Command, args []string) error {
 		return errorhandling.JoinErrors(failures)
 	}
 
-	if err := writeTemplate(allReports, autoUpdateOptions.format); err != nil {
+	if err := writeTemplate(allReports, autoUpdateOptions.format, autoUpdateOptions.DryRun); err != nil {
 		failures = append(failures, err)
 	}
 
@@ -97,6 +101,12 @@ type autoUpdateOutput struct {
 	Image         string
 	Policy        string
 	Updated       string
+	Reason        string
+	Digest        string
+	NewDigest     string
+	NewImage      string
+	Version       string
+	NewVersion    string
 }
 
 func reportsToOutput(allReports []*entities.AutoUpdateReport) []autoUpdateOutput {
@@ -110,12 +120,18 @@ func reportsToOutput(allReports []*entities.AutoUpdateReport) []autoUpdateOutput
 			Image:         r.ImageName,
 			Policy:        r.Policy,
 			Updated:       r.Updated,
+			Reason:        r.Reason,
+			Digest:        r.ImageDigest,
+			NewDigest:     r.NewImageDigest,
+			NewImage:      r.NewImageName,
+			Version:       r.ImageVersion,
+			NewVersion:    r.NewImageVersion,
 		}
 	}
 	return output
 }
 
-func writeTemplate(allReports []*entities.AutoUpdateReport, inputFormat string) error {
+func writeTemplate(allReports []*entities.AutoUpdateReport, inputFormat string, dryRun bool) error {
 	rpt := report.New(os.Stdout, "auto-update")
 	defer rpt.Flush()
 
@@ -124,6 +140,10 @@ func writeTemplate(allReports []*entities.AutoUpdateReport, inputFormat string)
 	switch inputFormat {
 	case "":
 		format := "{{range . }}\t{{.Unit}}\t{{.Container}}\t{{.Image}}\t{{.Policy}}\t{{.Updated}}\n{{end -}}"
+		if dryRun {
+			// Show what a pending update would change.
+			format = "{{range . }}\t{{.Unit}}\t{{.Container}}\t{{.Image}}\t{{.Policy}}\t{{.Updated}}\t{{.Version}}\t{{.NewVersion}}\t{{.Digest}}\t{{.NewDigest}}\n{{end -}}"
+		}
 		rpt, err = rpt.Parse(report.OriginPodman, format)
 	case "json":
 		prettyJSON, err := json.MarshalIndent(output, "", "    ")
@@ -1417,17 +1417,17 @@ func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete strin
 		return []string{events.Attach.String(), events.AutoUpdate.String(), events.Checkpoint.String(), events.Cleanup.String(),
 			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(),
 			events.Exited.String(), events.Export.String(), events.Import.String(), events.Init.String(), events.Kill.String(),
-			events.LoadFromArchive.String(), events.Mount.String(), events.NetworkConnect.String(),
+			events.LoadFromArchive.String(), events.LogThrottled.String(), events.Mount.String(), events.NetworkConnect.String(),
 			events.NetworkDisconnect.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
 			events.Push.String(), events.Refresh.String(), events.Remove.String(), events.Rename.String(),
 			events.Renumber.String(), events.Restart.String(), events.Restore.String(), events.Save.String(),
 			events.Start.String(), events.Stop.String(), events.Sync.String(), events.Tag.String(), events.Unmount.String(),
-			events.Unpause.String(), events.Untag.String(),
+			events.Unpause.String(), events.Untag.String(), events.Update.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	eventTypes := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{events.Container.String(), events.Image.String(), events.Network.String(),
-			events.Pod.String(), events.System.String(), events.Volume.String(),
+			events.Pod.String(), events.Secret.String(), events.System.String(), events.Volume.String(),
 		}, cobra.ShellCompDirectiveNoFileComp
 	}
 	kv := keyValueCompletion{
@@ -1435,9 +1435,13 @@ func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete strin
 		"image=":     func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
 		"pod=":       func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
 		"volume=":    func(s string) ([]string, cobra.ShellCompDirective) { return getVolumes(cmd, s) },
+		"secret=":    func(s string) ([]string, cobra.ShellCompDirective) { return getSecrets(cmd, s, completeDefault) },
 		"event=":     event,
 		"label=":     nil,
 		"type=":      eventTypes,
+		"caller=":    nil,
+		"digest=":    nil,
+		"registry=":  func(_ string) ([]string, cobra.ShellCompDirective) { return getRegistries() },
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -1575,9 +1579,9 @@ func AutocompleteContainersConfModules(cmd *cobra.Command, args []string, toComp
 }
 
 // AutocompleteEventBackend - Autocomplete event backend options.
-// -> "file", "journald", "none"
+// -> "file", "journald", "none", "webhook"
 func AutocompleteEventBackend(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	types := []string{events.LogFile.String(), events.Journald.String(), events.Null.String()}
+	types := []string{events.LogFile.String(), events.Journald.String(), events.Null.String(), events.Webhook.String()}
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -1588,6 +1592,13 @@ func AutocompleteNetworkBackend(cmd *cobra.Command, args []string, toComplete st
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteDatabaseBackend - Autocomplete database backend options.
+// -> "boltdb", "sqlite"
+func AutocompleteDatabaseBackend(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	types := []string{config.DBBackendBoltDB.String(), config.DBBackendSQLite.String()}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteLogLevel - Autocomplete log level options.
 // -> "trace", "debug", "info", "warn", "error", "fatal", "panic"
 func AutocompleteLogLevel(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1769,3 +1780,8 @@ func AutocompleteSSH(cmd *cobra.Command, args []string, toComplete string) ([]st
 func AutocompleteHealthOnFailure(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return define.SupportedHealthCheckOnFailureActions, cobra.ShellCompDirectiveNoFileComp
 }
+
+// AutocompleteHealthCheckStatus - Autocomplete health statuses.
+func AutocompleteHealthCheckStatus(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{define.HealthCheckHealthy, define.HealthCheckUnhealthy, define.HealthCheckStarting}, cobra.ShellCompDirectiveNoFileComp
+}
@@ -218,6 +218,20 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(healthOnFailureFlagName, AutocompleteHealthOnFailure)
 
+		healthOnFailureCmdFlagName := "health-on-failure-cmd"
+		createFlags.StringVar(
+			&cf.HealthOnFailureCmd,
+			healthOnFailureCmdFlagName, "",
+			"command to run once the container turns unhealthy, before the on-failure action is taken",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(healthOnFailureCmdFlagName, completion.AutocompleteNone)
+
+		createFlags.BoolVar(
+			&cf.HealthOnFailureCtr,
+			"health-on-failure-cmd-in-container", false,
+			"run the on-failure command inside the container instead of on the host",
+		)
+
 		createFlags.BoolVar(
 			&cf.HTTPProxy,
 			"http-proxy", podmanConfig.ContainersConfDefaultsRO.Containers.HTTPProxy,
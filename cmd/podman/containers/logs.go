@@ -118,6 +118,14 @@ func logsFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&logsOptions.Colors, "color", "", false, "Output the containers with different colors in the log.")
 	flags.BoolVarP(&logsOptions.Names, "names", "n", false, "Output the container name in the log")
 
+	if !registry.IsRemote() {
+		flags.BoolVar(&logsOptions.Merge, "merge", false, "Order the lines of all containers by their timestamps")
+
+		prefixFlagName := "prefix"
+		flags.StringVar(&logsOptions.Prefix, prefixFlagName, "", "Go template written before every line instead of the container ID or name")
+		_ = cmd.RegisterFlagCompletionFunc(prefixFlagName, completion.AutocompleteNone)
+	}
+
 	flags.SetInterspersed(false)
 	_ = flags.MarkHidden("details")
 }
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/specgenutil"
@@ -15,7 +17,7 @@ import (
 )
 
 var (
-	updateDescription = `Updates the cgroup configuration of a given container`
+	updateDescription = `Updates the configuration of an existing container, allowing changes to resource limits, restart policy, healthcheck, environment and labels`
 
 	updateCommand = &cobra.Command{
 		Use:               "update [options] CONTAINER",
@@ -39,11 +41,69 @@ var (
 )
 var (
 	updateOpts entities.ContainerCreateOptions
+
+	updateRestart           string
+	updateHealthCmd         string
+	updateHealthInterval    string
+	updateHealthRetries     uint
+	updateHealthStartPeriod string
+	updateHealthTimeout     string
+	updateHealthOnFailure   string
+	updateEnv               []string
+	updateUnsetEnv          []string
+	updateLabels            []string
+	updateUnsetLabels       []string
 )
 
 func updateFlags(cmd *cobra.Command) {
 	common.DefineCreateDefaults(&updateOpts)
 	common.DefineCreateFlags(cmd, &updateOpts, entities.UpdateMode)
+
+	flags := cmd.Flags()
+
+	restartFlagName := "restart"
+	flags.StringVar(&updateRestart, restartFlagName, "", `Restart policy to apply when a container exits ("always"|"no"|"never"|"on-failure"|"unless-stopped")`)
+	_ = cmd.RegisterFlagCompletionFunc(restartFlagName, common.AutocompleteRestartOption)
+
+	healthCmdFlagName := "health-cmd"
+	flags.StringVar(&updateHealthCmd, healthCmdFlagName, "", "set a new healthcheck command for the container ('none' disables the existing healthcheck)")
+	_ = cmd.RegisterFlagCompletionFunc(healthCmdFlagName, completion.AutocompleteNone)
+
+	healthIntervalFlagName := "health-interval"
+	flags.StringVar(&updateHealthInterval, healthIntervalFlagName, define.DefaultHealthCheckInterval, "set an interval for the healthcheck (a value of disable results in no automatic timer setup)")
+	_ = cmd.RegisterFlagCompletionFunc(healthIntervalFlagName, completion.AutocompleteNone)
+
+	healthRetriesFlagName := "health-retries"
+	flags.UintVar(&updateHealthRetries, healthRetriesFlagName, define.DefaultHealthCheckRetries, "the number of retries allowed before a healthcheck is considered to be unhealthy")
+	_ = cmd.RegisterFlagCompletionFunc(healthRetriesFlagName, completion.AutocompleteNone)
+
+	healthStartPeriodFlagName := "health-start-period"
+	flags.StringVar(&updateHealthStartPeriod, healthStartPeriodFlagName, define.DefaultHealthCheckStartPeriod, "the initialization time needed for a container to bootstrap")
+	_ = cmd.RegisterFlagCompletionFunc(healthStartPeriodFlagName, completion.AutocompleteNone)
+
+	healthTimeoutFlagName := "health-timeout"
+	flags.StringVar(&updateHealthTimeout, healthTimeoutFlagName, define.DefaultHealthCheckTimeout, "the maximum time allowed to complete the healthcheck before an interval is considered failed")
+	_ = cmd.RegisterFlagCompletionFunc(healthTimeoutFlagName, completion.AutocompleteNone)
+
+	healthOnFailureFlagName := "health-on-failure"
+	flags.StringVar(&updateHealthOnFailure, healthOnFailureFlagName, "none", "action to take once the container turns unhealthy")
+	_ = cmd.RegisterFlagCompletionFunc(healthOnFailureFlagName, common.AutocompleteHealthOnFailure)
+
+	envFlagName := "env"
+	flags.StringArrayVarP(&updateEnv, envFlagName, "e", []string{}, "Add or change environment variables in the container")
+	_ = cmd.RegisterFlagCompletionFunc(envFlagName, completion.AutocompleteNone)
+
+	unsetenvFlagName := "unsetenv"
+	flags.StringArrayVar(&updateUnsetEnv, unsetenvFlagName, []string{}, "Remove environment variables from the container")
+	_ = cmd.RegisterFlagCompletionFunc(unsetenvFlagName, completion.AutocompleteNone)
+
+	labelFlagName := "label"
+	flags.StringArrayVarP(&updateLabels, labelFlagName, "l", []string{}, "Add or change labels on the container")
+	_ = cmd.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
+
+	unsetLabelFlagName := "unset-label"
+	flags.StringArrayVar(&updateUnsetLabels, unsetLabelFlagName, []string{}, "Remove labels from the container")
+	_ = cmd.RegisterFlagCompletionFunc(unsetLabelFlagName, completion.AutocompleteNone)
 }
 
 func init() {
@@ -77,8 +137,34 @@ func update(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := &entities.ContainerUpdateOptions{
-		NameOrID: strings.TrimPrefix(args[0], "/"),
-		Specgen:  s,
+		NameOrID:    strings.TrimPrefix(args[0], "/"),
+		Specgen:     s,
+		Env:         updateEnv,
+		UnsetEnv:    updateUnsetEnv,
+		Labels:      updateLabels,
+		UnsetLabels: updateUnsetLabels,
+	}
+	flags := cmd.Flags()
+	if flags.Changed("restart") {
+		opts.Restart = &updateRestart
+	}
+	if flags.Changed("health-cmd") {
+		opts.HealthCmd = &updateHealthCmd
+	}
+	if flags.Changed("health-interval") {
+		opts.HealthInterval = &updateHealthInterval
+	}
+	if flags.Changed("health-retries") {
+		opts.HealthRetries = &updateHealthRetries
+	}
+	if flags.Changed("health-start-period") {
+		opts.HealthStartPeriod = &updateHealthStartPeriod
+	}
+	if flags.Changed("health-timeout") {
+		opts.HealthTimeout = &updateHealthTimeout
+	}
+	if flags.Changed("health-on-failure") {
+		opts.HealthOnFailure = &updateHealthOnFailure
 	}
 	rep, err := registry.ContainerEngine().ContainerUpdate(context.Background(), opts)
 	if err != nil {
@@ -20,6 +20,8 @@ type buildOptions struct {
 	local        bool
 	platforms    []string
 	farm         string
+	push         bool
+	localCopy    bool
 }
 
 var (
@@ -49,9 +51,13 @@ func init() {
 	// Default for local is true and hide this flag for the remote use case
 	if !registry.IsRemote() {
 		flags.BoolVarP(&buildOpts.local, localFlagName, "l", true, "Build image on local machine as well as on farm nodes")
+		localCopyFlagName := "local-copy"
+		flags.BoolVar(&buildOpts.localCopy, localCopyFlagName, true, "Keep a copy of the pushed manifest list in local storage")
 	}
 	cleanupFlag := "cleanup"
 	flags.BoolVar(&buildOpts.buildOptions.Cleanup, cleanupFlag, false, "Remove built images from farm nodes on success")
+	pushFlagName := "push"
+	flags.BoolVar(&buildOpts.push, pushFlagName, false, "Push the images from the farm nodes and the manifest list directly to the registry")
 	platformsFlag := "platforms"
 	buildCommand.PersistentFlags().StringSliceVar(&buildOpts.platforms, platformsFlag, nil, "Build only on farm nodes that match the given platforms")
 
@@ -76,6 +82,12 @@ func build(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if cmd.Flags().Changed("local-copy") && !buildOpts.push {
+		return errors.New("--local-copy can only be used with --push")
+	}
+	if cmd.Flags().Changed("tls-verify") && !buildOpts.push {
+		return errors.New("--tls-verify can only be used with --push")
+	}
 	if !cmd.Flags().Changed("tag") {
 		return errors.New("cannot create manifest list without a name, value for --tag is required")
 	}
@@ -97,6 +109,8 @@ func build(cmd *cobra.Command, args []string) error {
 		}()
 	}
 	opts.Cleanup = buildOpts.buildOptions.Cleanup
+	opts.Push = buildOpts.push
+	opts.LocalCopy = buildOpts.localCopy
 	iidFile, err := cmd.Flags().GetString("iidfile")
 	if err != nil {
 		return err
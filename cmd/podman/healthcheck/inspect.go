@@ -0,0 +1,61 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectCmd = &cobra.Command{
+		Use:               "inspect [options] CONTAINER",
+		Short:             "Inspect the health check of a container",
+		Long:              "Display the health check configuration, the current health status and the probe history of a container",
+		Example:           `podman healthcheck inspect mywebapp`,
+		RunE:              inspect,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+	}
+)
+
+var inspectFormat string
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: inspectCmd,
+		Parent:  healthCmd,
+	})
+	flags := inspectCmd.Flags()
+	formatFlagName := "format"
+	flags.StringVarP(&inspectFormat, formatFlagName, "f", "", "Format inspect output using Go template")
+	_ = inspectCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.HealthCheckInspectReport{}))
+}
+
+func inspect(cmd *cobra.Command, args []string) error {
+	inspected, err := registry.ContainerEngine().HealthCheckInspect(registry.Context(), args[0], entities.HealthCheckInspectOptions{})
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("format") && !report.IsJSON(inspectFormat) {
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUnknown, inspectFormat)
+		if err != nil {
+			return err
+		}
+		defer rpt.Flush()
+		return rpt.Execute(inspected)
+	}
+
+	buf, err := json.MarshalIndent(inspected, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(buf))
+	return nil
+}
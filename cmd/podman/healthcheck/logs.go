@@ -0,0 +1,150 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsDescription = `Display the probes recorded in the health check history of a container, oldest first.
+
+  The history is kept across restarts of the container.`
+	logsCmd = &cobra.Command{
+		Use:   "logs [options] CONTAINER",
+		Short: "Display the health check history of a container",
+		Long:  logsDescription,
+		Example: `podman healthcheck logs mywebapp
+  podman healthcheck logs --since 1h --status unhealthy mywebapp
+  podman healthcheck logs --follow --format json mywebapp`,
+		RunE:              logs,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+	}
+)
+
+var (
+	logsOptions entities.HealthCheckLogsOptions
+	logsFormat  string
+	logsSince   string
+	logsUntil   string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: logsCmd,
+		Parent:  healthCmd,
+	})
+	flags := logsCmd.Flags()
+	flags.BoolVarP(&logsOptions.Follow, "follow", "f", false, "Follow new probes until the container stops")
+
+	formatFlagName := "format"
+	flags.StringVar(&logsFormat, formatFlagName, "", "Format the output using JSON or a Go template")
+	_ = logsCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&define.HealthCheckHistoryEntry{}))
+
+	sinceFlagName := "since"
+	flags.StringVar(&logsSince, sinceFlagName, "", "Show probes started since TIMESTAMP")
+	_ = logsCmd.RegisterFlagCompletionFunc(sinceFlagName, completion.AutocompleteNone)
+
+	statusFlagName := "status"
+	flags.StringVar(&logsOptions.Status, statusFlagName, "", "Show probes which left the container in this health status (healthy, unhealthy, starting)")
+	_ = logsCmd.RegisterFlagCompletionFunc(statusFlagName, common.AutocompleteHealthCheckStatus)
+
+	untilFlagName := "until"
+	flags.StringVar(&logsUntil, untilFlagName, "", "Show probes started until TIMESTAMP")
+	_ = logsCmd.RegisterFlagCompletionFunc(untilFlagName, completion.AutocompleteNone)
+}
+
+func logs(cmd *cobra.Command, args []string) error {
+	var err error
+	if logsSince != "" {
+		logsOptions.Since, err = util.ParseInputTime(logsSince, true)
+		if err != nil {
+			return fmt.Errorf("parsing --since %q: %w", logsSince, err)
+		}
+	}
+	if logsUntil != "" {
+		logsOptions.Until, err = util.ParseInputTime(logsUntil, false)
+		if err != nil {
+			return fmt.Errorf("parsing --until %q: %w", logsUntil, err)
+		}
+	}
+	if err := define.ValidateHealthCheckHistoryStatus(logsOptions.Status); err != nil {
+		return err
+	}
+
+	var (
+		rpt    *report.Formatter
+		doJSON bool
+	)
+	if cmd.Flags().Changed("format") {
+		doJSON = report.IsJSON(logsFormat)
+		if !doJSON {
+			// Use OriginUnknown so it does not add an extra range since it
+			// will only be called for each single entry and not a slice.
+			rpt, err = report.New(os.Stdout, cmd.Name()).Parse(report.OriginUnknown, logsFormat)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	entryChan := make(chan *define.HealthCheckHistoryEntry, 1)
+	logsOptions.EntryChan = entryChan
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- registry.ContainerEngine().HealthCheckLogs(registry.Context(), args[0], logsOptions)
+	}()
+
+	for entry := range entryChan {
+		switch {
+		case doJSON:
+			b, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
+		case rpt != nil:
+			if err := rpt.Execute(entry); err != nil {
+				return err
+			}
+			// Flush every entry when following the history.
+			if err := rpt.Flush(); err != nil {
+				return err
+			}
+		default:
+			fmt.Println(humanReadableEntry(entry))
+		}
+	}
+	return <-errChan
+}
+
+// humanReadableEntry formats a probe as a single line.
+func humanReadableEntry(entry *define.HealthCheckHistoryEntry) string {
+	line := entry.Start
+	if start, err := time.Parse(time.RFC3339Nano, entry.Start); err == nil {
+		line = start.Local().Format(time.RFC3339)
+		if end, err := time.Parse(time.RFC3339Nano, entry.End); err == nil {
+			line += fmt.Sprintf(" (%s)", end.Sub(start).Round(time.Millisecond))
+		}
+	}
+	kind := "probe"
+	if entry.Startup {
+		kind = "startup probe"
+	}
+	line += fmt.Sprintf(" %s exited with %d, status %s", kind, entry.ExitCode, entry.Status)
+	if entry.Output != "" {
+		line += ": " + entry.Output
+	}
+	return line
+}
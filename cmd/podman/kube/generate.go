@@ -87,6 +87,9 @@ func generateFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 	podmanOnlyFlagName := "podman-only"
 	flags.BoolVar(&generateOptions.PodmanOnly, podmanOnlyFlagName, false, "Add podman-only reserved annotations to the generated YAML file (Cannot be used by Kubernetes)")
 
+	flags.BoolVar(&generateOptions.ConfigMaps, "configmaps", false, "Move the environment variables of containers into ConfigMaps")
+	flags.BoolVar(&generateOptions.SecretData, "secret-data", false, "Generate Secrets including the data of the podman secrets used by the containers")
+
 	flags.SetNormalizeFunc(utils.AliasFlags)
 }
 
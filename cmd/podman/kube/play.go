@@ -15,6 +15,7 @@ import (
 	buildahParse "github.com/containers/buildah/pkg/parse"
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
 	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/parse"
@@ -165,6 +166,12 @@ func playFlags(cmd *cobra.Command) {
 	waitFlagName := "wait"
 	flags.BoolVarP(&playOptions.Wait, waitFlagName, "w", false, "Clean up all objects created when a SIGTERM is received or pods exit")
 
+	flags.BoolVar(&playOptions.WaitReady, "wait-ready", false, "Wait until all containers are running and healthy and report their state")
+
+	waitReadyTimeoutFlagName := "wait-ready-timeout"
+	flags.UintVar(&playOptions.WaitReadyTimeout, waitReadyTimeoutFlagName, entities.DefaultPlayKubeWaitReadyTimeout, "Maximum time in `seconds` to wait for the containers to become ready, 0 waits forever")
+	_ = cmd.RegisterFlagCompletionFunc(waitReadyTimeoutFlagName, completion.AutocompleteNone)
+
 	configmapFlagName := "configmap"
 	flags.StringArrayVar(&playOptions.ConfigMaps, configmapFlagName, []string{}, "`Pathname` of a YAML file containing a kubernetes configmap")
 	_ = cmd.RegisterFlagCompletionFunc(configmapFlagName, completion.AutocompleteDefault)
@@ -271,6 +278,12 @@ func play(cmd *cobra.Command, args []string) error {
 	if playOptions.Force && !playOptions.Down {
 		return errors.New("--force may be specified only with --down")
 	}
+	if playOptions.WaitReady && playOptions.Start == types.OptionalBoolFalse {
+		return errors.New("--wait-ready cannot be used with --start=false")
+	}
+	if cmd.Flags().Changed("wait-ready-timeout") && !playOptions.WaitReady {
+		return errors.New("--wait-ready-timeout may be specified only with --wait-ready")
+	}
 
 	reader, err := readerFromArg(args[0])
 	if err != nil {
@@ -393,12 +406,30 @@ func teardown(body io.Reader, options entities.PlayKubeDownOptions) error {
 		podRmErrors   utils.OutputErrors
 		volRmErrors   utils.OutputErrors
 		secRmErrors   utils.OutputErrors
+		timerErrors   utils.OutputErrors
 	)
 	reports, err := registry.ContainerEngine().PlayKubeDown(registry.GetContext(), body, options)
 	if err != nil {
 		return err
 	}
 
+	// Output removed timers
+	if len(reports.TimerRmReport) > 0 {
+		fmt.Println("Timers removed:")
+	}
+	for _, removed := range reports.TimerRmReport {
+		switch {
+		case removed.Err != nil:
+			timerErrors = append(timerErrors, removed.Err)
+		default:
+			fmt.Println(removed.Name)
+		}
+	}
+	lastTimerError := timerErrors.PrintErrors()
+	if lastTimerError != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", lastTimerError)
+	}
+
 	// Output stopped pods
 	fmt.Println("Pods stopped:")
 	for _, stopped := range reports.StopReport {
@@ -471,6 +502,11 @@ func kubeplay(body io.Reader) error {
 	if err := printPlayReport(report); err != nil {
 		return err
 	}
+	if playOptions.WaitReady {
+		if err := printReadyReport(report); err != nil {
+			return err
+		}
+	}
 
 	// If --wait=true, we need wait for the service container to exit so that we know that the pod has exited and we can clean up
 	if playOptions.Wait {
@@ -501,6 +537,14 @@ func printPlayReport(report *entities.PlayKubeReport) error {
 		fmt.Println(secret.CreateReport.ID)
 	}
 
+	// Print timers report
+	for i, timer := range report.Timers {
+		if i == 0 {
+			fmt.Println("Timers:")
+		}
+		fmt.Println(timer.Name)
+	}
+
 	// Print pods report
 	for _, pod := range report.Pods {
 		for _, l := range pod.Logs {
@@ -542,3 +586,49 @@ func printPlayReport(report *entities.PlayKubeReport) error {
 	}
 	return nil
 }
+
+// printReadyReport prints the final state of the containers after waiting
+// for the pods to become ready and returns an error if not all containers are
+// ready.
+func printReadyReport(playReport *entities.PlayKubeReport) error {
+	type readyStatus struct {
+		Pod string
+		entities.PlayKubeContainerStatus
+	}
+	var statuses []readyStatus
+	for _, pod := range playReport.Pods {
+		for _, status := range pod.ContainerStatuses {
+			statuses = append(statuses, readyStatus{Pod: pod.ID[:12], PlayKubeContainerStatus: status})
+		}
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	fmt.Println("Readiness:")
+	rpt := report.New(os.Stdout, "ready")
+	rpt, err := rpt.Parse(report.OriginPodman, "{{range .}}{{.Pod}}\t{{.Name}}\t{{.State}}\t{{.ExitCode}}\t{{.Health}}\t{{.Ready}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+	headers := []map[string]string{{"Pod": "POD", "Name": "CONTAINER", "State": "STATE", "ExitCode": "EXIT CODE", "Health": "HEALTH", "Ready": "READY"}}
+	if err := rpt.Execute(headers); err != nil {
+		return err
+	}
+	if err := rpt.Execute(statuses); err != nil {
+		return err
+	}
+	if err := rpt.Flush(); err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if !status.Ready && status.ProbeOutput != "" {
+			fmt.Fprintf(os.Stderr, "Probe of container %s failed: %s\n", status.Name, status.ProbeOutput)
+		}
+	}
+	if playReport.NotReady {
+		return errors.New("not all containers became ready")
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/containers/podman/v4/pkg/specgenutil"
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/docker/docker/pkg/parsers"
+	"github.com/docker/go-units"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -48,6 +49,7 @@ var (
 	infraOptions      = entities.NewInfraContainerCreateOptions()
 	infraImage        string
 	labels, labelFile []string
+	logQuota          string
 	podIDFile         string
 	replace           bool
 	share             string
@@ -75,6 +77,10 @@ func init() {
 	flags.StringVarP(&createOptions.Name, nameFlagName, "n", "", "Assign a name to the pod")
 	_ = createCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
 
+	logQuotaFlagName := "log-quota"
+	flags.StringVar(&logQuota, logQuotaFlagName, "", "Maximum size of the logs of all containers in the pod (e.g. 10mb)")
+	_ = createCommand.RegisterFlagCompletionFunc(logQuotaFlagName, completion.AutocompleteNone)
+
 	policyFlag := "exit-policy"
 	flags.StringVarP(&createOptions.ExitPolicy, policyFlag, "", string(containerConfig.Engine.PodExitPolicy), "Behaviour when the last container exits")
 	_ = createCommand.RegisterFlagCompletionFunc(policyFlag, common.AutocompletePodExitPolicy)
@@ -123,6 +129,13 @@ func create(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to process labels: %w", err)
 	}
 
+	if logQuota != "" {
+		createOptions.LogQuota, err = units.FromHumanSize(logQuota)
+		if err != nil {
+			return fmt.Errorf("invalid --log-quota %q: %w", logQuota, err)
+		}
+	}
+
 	if cmd.Flag("infra-image").Changed {
 		imageName = infraImage
 	}
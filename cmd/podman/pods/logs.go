@@ -92,6 +92,14 @@ func logsFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&logsPodOptions.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
 	flags.BoolVarP(&logsPodOptions.Colors, "color", "", false, "Output the containers within a pod with different colors in the log")
 
+	if !registry.IsRemote() {
+		flags.BoolVar(&logsPodOptions.Merge, "merge", false, "Order the lines of all containers by their timestamps")
+
+		prefixFlagName := "prefix"
+		flags.StringVar(&logsPodOptions.Prefix, prefixFlagName, "", "Go template written before every line instead of the container ID or name")
+		_ = cmd.RegisterFlagCompletionFunc(prefixFlagName, completion.AutocompleteNone)
+	}
+
 	flags.SetInterspersed(false)
 	_ = flags.MarkHidden("details")
 }
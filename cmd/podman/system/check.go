@@ -0,0 +1,129 @@
+package system
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v4/cmd/podman/common"
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/cmd/podman/validate"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkDescription = `
+	podman system check
+
+	Check the libpod database, container storage and locks for inconsistencies and optionally repair them.
+`
+	checkCommand = &cobra.Command{
+		Use:               "check [options]",
+		Args:              validate.NoArgs,
+		Short:             "Check the consistency of the libpod state",
+		Long:              checkDescription,
+		RunE:              check,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system check
+  podman system check --quick
+  podman system check --repair`,
+	}
+)
+
+var (
+	checkOptions entities.SystemCheckOptions
+	checkFormat  string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: checkCommand,
+		Parent:  systemCmd,
+	})
+	flags := checkCommand.Flags()
+	flags.BoolVar(&checkOptions.Quick, "quick", false, "Skip the checks that have to walk container storage")
+	flags.BoolVar(&checkOptions.Repair, "repair", false, "Repair the inconsistencies that are safe to fix")
+
+	formatFlagName := "format"
+	flags.StringVar(&checkFormat, formatFlagName, "", "Format the report using JSON or a Go template")
+	_ = checkCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&checkIssue{}))
+}
+
+func check(cmd *cobra.Command, args []string) error {
+	checkReport, err := registry.ContainerEngine().SystemCheck(registry.Context(), checkOptions)
+	if err != nil {
+		return err
+	}
+	if err := printCheckReport(cmd, checkReport); err != nil {
+		return err
+	}
+
+	if unrepaired := checkReport.Unrepaired(); unrepaired > 0 {
+		if checkOptions.Repair {
+			return fmt.Errorf("%d of %d inconsistencies could not be repaired", unrepaired, len(checkReport.Issues))
+		}
+		return fmt.Errorf("%d inconsistencies found", unrepaired)
+	}
+	return nil
+}
+
+func printCheckReport(cmd *cobra.Command, checkReport *define.SystemCheckReport) error {
+	if report.IsJSON(checkFormat) {
+		if checkReport.Issues == nil {
+			checkReport.Issues = []define.SystemCheckIssue{}
+		}
+		b, err := json.MarshalIndent(checkReport.Issues, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	var err error
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, checkFormat)
+	} else {
+		if len(checkReport.Issues) == 0 {
+			fmt.Println("No inconsistencies found")
+			return nil
+		}
+		row := "{{range . }}{{.Kind}}\t{{.ID}}\t{{.Message}}\t{{.Status}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, row)
+	}
+	if err != nil {
+		return err
+	}
+
+	issues := make([]checkIssue, 0, len(checkReport.Issues))
+	for _, issue := range checkReport.Issues {
+		issues = append(issues, checkIssue{issue})
+	}
+	hdrs := report.Headers(checkIssue{}, map[string]string{
+		"Message": "DESCRIPTION",
+		"Status":  "STATUS",
+	})
+	return writeTemplate(rpt, hdrs, issues)
+}
+
+type checkIssue struct {
+	define.SystemCheckIssue
+}
+
+// Status describes whether the issue has been repaired
+func (c checkIssue) Status() string {
+	switch {
+	case c.Repaired:
+		return "repaired"
+	case c.RepairError != "":
+		return "repair failed: " + c.RepairError
+	default:
+		return "not repaired"
+	}
+}
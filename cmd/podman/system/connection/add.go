@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -28,6 +29,7 @@ var (
     [user@]hostname (will default to ssh)
     ssh://[user@]hostname[:port][/path] (will obtain socket path from service, if not given.)
     tcp://hostname:port (not secured)
+    tcp+tls://hostname:port (secured with TLS, see --tls-ca, --tls-cert and --tls-key)
     unix://path (absolute path required)
 `,
 		RunE:              add,
@@ -36,6 +38,7 @@ var (
   podman system connection add --identity ~/.ssh/dev_rsa testing ssh://root@server.fubar.com:2222
   podman system connection add --identity ~/.ssh/dev_rsa --port 22 production root@server.fubar.com
   podman system connection add debug tcp://localhost:8080
+  podman system connection add --tls-ca ca.pem --tls-cert client.pem --tls-key client-key.pem secure tcp+tls://server.fubar.com:8443
   `,
 	}
 
@@ -56,6 +59,9 @@ var (
 		UDSPath  string
 		Default  bool
 		Farm     string
+		TLSCert  string
+		TLSKey   string
+		TLSCA    string
 	}{}
 )
 
@@ -85,6 +91,18 @@ func init() {
 
 	flags.BoolVarP(&cOpts.Default, "default", "d", false, "Set connection to be default")
 
+	tlsCertFlagName := "tls-cert"
+	flags.StringVar(&cOpts.TLSCert, tlsCertFlagName, "", "path to TLS client certificate PEM file for tcp+tls destinations")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsCertFlagName, completion.AutocompleteDefault)
+
+	tlsKeyFlagName := "tls-key"
+	flags.StringVar(&cOpts.TLSKey, tlsKeyFlagName, "", "path to TLS client certificate private key PEM file for tcp+tls destinations")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsKeyFlagName, completion.AutocompleteDefault)
+
+	tlsCAFlagName := "tls-ca"
+	flags.StringVar(&cOpts.TLSCA, tlsCAFlagName, "", "path to TLS certificate authority PEM file used to verify tcp+tls destinations")
+	_ = addCmd.RegisterFlagCompletionFunc(tlsCAFlagName, completion.AutocompleteDefault)
+
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: createCmd,
 		Parent:  system.ContextCmd,
@@ -139,6 +157,11 @@ func add(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid ssh mode")
 	}
 
+	tlsFlagsChanged := cmd.Flags().Changed("tls-cert") || cmd.Flags().Changed("tls-key") || cmd.Flags().Changed("tls-ca")
+	if tlsFlagsChanged && uri.Scheme != "tcp+tls" {
+		return fmt.Errorf("--tls-cert, --tls-key and --tls-ca options are not supported for %s scheme", uri.Scheme)
+	}
+
 	switch uri.Scheme {
 	case "ssh":
 		return ssh.Create(entities, sshMode)
@@ -172,6 +195,19 @@ func add(cmd *cobra.Command, args []string) error {
 		if uri.Port() == "" {
 			return errors.New("tcp scheme requires a port either via --port or in destination URL")
 		}
+	case "tcp+tls":
+		if cmd.Flags().Changed("socket-path") {
+			return errors.New("--socket-path option not supported for tcp+tls scheme")
+		}
+		if cmd.Flags().Changed("identity") {
+			return errors.New("--identity option not supported for tcp+tls scheme")
+		}
+		if uri.Port() == "" {
+			return errors.New("tcp+tls scheme requires a port in destination URL")
+		}
+		if (cOpts.TLSCert == "") != (cOpts.TLSKey == "") {
+			return errors.New("--tls-cert and --tls-key must be specified together")
+		}
 	default:
 		logrus.Warnf("%q unknown scheme, no validation provided", uri.Scheme)
 	}
@@ -187,6 +223,23 @@ func add(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// The TLS files are stored as query parameters of the URI, which is
+	// where the bindings read them from.
+	if tlsFlagsChanged {
+		query := uri.Query()
+		for param, path := range map[string]string{"tls_cert": cOpts.TLSCert, "tls_key": cOpts.TLSKey, "tls_ca": cOpts.TLSCA} {
+			if path == "" {
+				continue
+			}
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			query.Set(param, abs)
+		}
+		uri.RawQuery = query.Encode()
+	}
+
 	dst := config.Destination{
 		URI: uri.String(),
 	}
@@ -29,7 +29,8 @@ var (
 		Example: `podman events
   podman events --filter event=create
   podman events --format {{.Image}}
-  podman events --since 1h30s`,
+  podman events --since 1h30s
+  podman events --after-cursor 42`,
 	}
 
 	systemEventsCommand = &cobra.Command{
@@ -83,6 +84,10 @@ func eventsFlags(cmd *cobra.Command) {
 	untilFlagName := "until"
 	flags.StringVar(&eventOptions.Until, untilFlagName, "", "show all events until timestamp")
 	_ = cmd.RegisterFlagCompletionFunc(untilFlagName, completion.AutocompleteNone)
+
+	afterCursorFlagName := "after-cursor"
+	flags.StringVar(&eventOptions.AfterCursor, afterCursorFlagName, "", "show all events after the event with the given cursor")
+	_ = cmd.RegisterFlagCompletionFunc(afterCursorFlagName, completion.AutocompleteNone)
 }
 
 func eventsCmd(cmd *cobra.Command, _ []string) error {
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v4/cmd/podman/common"
 	"github.com/containers/podman/v4/cmd/podman/registry"
 	"github.com/containers/podman/v4/cmd/podman/validate"
 	"github.com/containers/podman/v4/libpod/define"
@@ -51,9 +52,18 @@ func init() {
 	newRuntimeFlagName := "new-runtime"
 	flags.StringVar(&migrateOptions.NewRuntime, newRuntimeFlagName, "", "Specify a new runtime for all containers")
 	_ = migrateCommand.RegisterFlagCompletionFunc(newRuntimeFlagName, completion.AutocompleteNone)
+
+	databaseFlagName := "database"
+	flags.StringVar(&migrateOptions.Database, databaseFlagName, "", "Migrate the state of all containers, pods and volumes to a new database backend (boltdb, sqlite)")
+	_ = migrateCommand.RegisterFlagCompletionFunc(databaseFlagName, common.AutocompleteDatabaseBackend)
 }
 
 func migrate(cmd *cobra.Command, args []string) {
+	if migrateOptions.Database != "" && migrateOptions.NewRuntime != "" {
+		fmt.Println("--database and --new-runtime cannot be used together")
+		os.Exit(define.ExecErrorCodeGeneric)
+	}
+
 	// Shutdown all running engines, `renumber` will hijack repository
 	registry.ContainerEngine().Shutdown(registry.Context())
 	registry.ImageEngine().Shutdown(registry.Context())
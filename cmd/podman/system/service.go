@@ -40,9 +40,15 @@ Enable a listening service for API access to Podman commands.
 	}
 
 	srvArgs = struct {
-		CorsHeaders string
-		PProfAddr   string
-		Timeout     uint
+		CorsHeaders     string
+		PProfAddr       string
+		Timeout         uint
+		TLSCert         string
+		TLSKey          string
+		TLSClientCA     string
+		TLSNoClientAuth bool
+		Metrics         bool
+		DrainTimeout    uint
 	}{}
 )
 
@@ -67,6 +73,31 @@ func init() {
 	flags.StringVarP(&srvArgs.PProfAddr, "pprof-address", "", "",
 		"Binding network address for pprof profile endpoints, default: do not expose endpoints")
 	_ = flags.MarkHidden("pprof-address")
+
+	flags.BoolVar(&srvArgs.Metrics, "enable-metrics", false, "Expose Prometheus metrics at the /metrics endpoint")
+
+	drainTimeoutFlagName := "drain-timeout"
+	flags.UintVar(&srvArgs.DrainTimeout, drainTimeoutFlagName, 0,
+		"Seconds to wait for requests in flight when stopped by SIGTERM or SIGINT.  Use 0 to stop right away")
+	_ = srvCmd.RegisterFlagCompletionFunc(drainTimeoutFlagName, completion.AutocompleteNone)
+
+	tlsCertFlagName := "tls-cert"
+	flags.StringVar(&srvArgs.TLSCert, tlsCertFlagName, "",
+		"PEM encoded certificate to serve the API over TLS on tcp listeners")
+	_ = srvCmd.RegisterFlagCompletionFunc(tlsCertFlagName, completion.AutocompleteDefault)
+
+	tlsKeyFlagName := "tls-key"
+	flags.StringVar(&srvArgs.TLSKey, tlsKeyFlagName, "",
+		"PEM encoded private key matching the --tls-cert certificate")
+	_ = srvCmd.RegisterFlagCompletionFunc(tlsKeyFlagName, completion.AutocompleteDefault)
+
+	tlsClientCAFlagName := "tls-client-ca"
+	flags.StringVar(&srvArgs.TLSClientCA, tlsClientCAFlagName, "",
+		"PEM encoded CA bundle, clients must present a certificate signed by one of these CAs")
+	_ = srvCmd.RegisterFlagCompletionFunc(tlsClientCAFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&srvArgs.TLSNoClientAuth, "tls-no-client-auth", false,
+		"Serve the API over TLS without --tls-client-ca, allowing any client to access it")
 }
 
 func aliasTimeoutFlag(_ *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -100,10 +131,16 @@ func service(cmd *cobra.Command, args []string) error {
 	}
 
 	return restService(cmd.Flags(), registry.PodmanConfig(), entities.ServiceOptions{
-		CorsHeaders: srvArgs.CorsHeaders,
-		PProfAddr:   srvArgs.PProfAddr,
-		Timeout:     time.Duration(srvArgs.Timeout) * time.Second,
-		URI:         apiURI,
+		CorsHeaders:     srvArgs.CorsHeaders,
+		PProfAddr:       srvArgs.PProfAddr,
+		Timeout:         time.Duration(srvArgs.Timeout) * time.Second,
+		URI:             apiURI,
+		TLSCert:         srvArgs.TLSCert,
+		TLSKey:          srvArgs.TLSKey,
+		TLSClientCA:     srvArgs.TLSClientCA,
+		TLSNoClientAuth: srvArgs.TLSNoClientAuth,
+		EnableMetrics:   srvArgs.Metrics,
+		DrainTimeout:    time.Duration(srvArgs.DrainTimeout) * time.Second,
 	})
 }
 
@@ -77,10 +77,12 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 			}
 		case "tcp":
 			// We want to check if the user is requesting a TCP address.
-			// If so, warn that this is insecure.
+			// If so, warn that this is insecure unless clients must authenticate.
 			// Ignore errors here, the actual backend code will handle them
 			// better than we can here.
-			logrus.Warnf("Using the Podman API service with TCP sockets is not recommended, please see `podman system service` manpage for details")
+			if opts.TLSClientCA == "" {
+				logrus.Warnf("Using the Podman API service with TCP sockets is not recommended, please see `podman system service` manpage for details")
+			}
 
 			host := uri.Host
 			if host == "" {
@@ -97,6 +99,24 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 		libpodRuntime.SetRemoteURI(uri.String())
 	}
 
+	if opts.TLSCert != "" || opts.TLSKey != "" || opts.TLSClientCA != "" || opts.TLSNoClientAuth {
+		if listener.Addr().Network() != "tcp" {
+			return errors.New("the --tls-cert, --tls-key, --tls-client-ca and --tls-no-client-auth options are only supported for tcp listeners")
+		}
+		// TLS without client certificates only encrypts the connection, any
+		// client can still use the API.  Require an explicit opt-in for that.
+		switch {
+		case opts.TLSClientCA == "" && !opts.TLSNoClientAuth:
+			return errors.New("serving the API over TLS requires --tls-client-ca to authenticate clients, use --tls-no-client-auth to allow any client")
+		case opts.TLSClientCA != "" && opts.TLSNoClientAuth:
+			return errors.New("--tls-client-ca and --tls-no-client-auth are mutually exclusive")
+		}
+		listener, err = api.ListenTLS(listener, opts.TLSCert, opts.TLSKey, opts.TLSClientCA)
+		if err != nil {
+			return err
+		}
+	}
+
 	// bugzilla.redhat.com/show_bug.cgi?id=2180483:
 	//
 	// Disable leaking the LISTEN_* into containers which
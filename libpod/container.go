@@ -653,6 +653,16 @@ func (c *Container) LogDriver() string {
 	return c.config.LogDriver
 }
 
+// logsToFile returns whether conmon writes the container logs to a file at
+// the container's log path.
+func (c *Container) logsToFile() bool {
+	switch c.config.LogDriver {
+	case define.NoLogging, define.PassthroughLogging, define.JournaldLogging:
+		return false
+	}
+	return true
+}
+
 // RuntimeName returns the name of the runtime
 func (c *Container) RuntimeName() string {
 	return c.config.OCIRuntime
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/containers/common/pkg/resize"
+	"github.com/containers/image/v5/manifest"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/libpod/events"
 	"github.com/containers/podman/v4/pkg/signal"
@@ -118,13 +119,47 @@ func (c *Container) Start(ctx context.Context, recursive bool) (finalErr error)
 	return c.start(ctx)
 }
 
+// ContainerUpdateOptions describes the changes to apply to a container with
+// Update.  Nil or empty fields are left unchanged.
+type ContainerUpdateOptions struct {
+	// Resources are the new cgroup limits of the container.  They are
+	// applied immediately.
+	Resources *spec.LinuxResources
+	// RestartPolicy is the new restart policy of the container.
+	RestartPolicy *string
+	// RestartRetries is the new number of restart retries of the
+	// container.  Only valid with the on-failure restart policy.
+	RestartRetries *uint
+	// HealthCheckConfig replaces the healthcheck of the container.  A
+	// healthcheck with the "NONE" test removes it.
+	HealthCheckConfig *manifest.Schema2HealthConfig
+	// HealthCheckOnFailureAction is the new action to take once the
+	// container turns unhealthy.
+	HealthCheckOnFailureAction *define.HealthCheckOnFailureAction
+	// Env are environment variables (key=value) to add or replace.
+	Env []string
+	// UnsetEnv are the names of environment variables to remove.
+	UnsetEnv []string
+	// Labels are labels to add or replace.
+	Labels map[string]string
+	// UnsetLabels are the names of labels to remove.
+	UnsetLabels []string
+}
+
 // Update updates the given container.
-// only the cgroup config can be updated and therefore only a linux resource spec is passed.
-func (c *Container) Update(res *spec.LinuxResources) error {
-	if err := c.syncContainer(); err != nil {
-		return err
+// Changes to the cgroup config are applied immediately while all other
+// changes are persisted in the database and take effect on the next start of
+// the container.
+func (c *Container) Update(options *ContainerUpdateOptions) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
 	}
-	return c.update(res)
+	return c.update(options)
 }
 
 // StartAndAttach starts a container and attaches to it.
@@ -376,6 +376,17 @@ type ContainerMiscConfig struct {
 	LogTag string `json:"logTag"`
 	// LogSize is the tag used for logging
 	LogSize int64 `json:"logSize"`
+	// LogRateLimit is the number of bytes per second the container may
+	// log. Log output beyond it is dropped. 0 is unlimited.
+	LogRateLimit int64 `json:"logRateLimit,omitempty"`
+	// LogRateBurst is the number of bytes the container may log at once
+	// when it has not logged for a while. Defaults to LogRateLimit.
+	LogRateBurst int64 `json:"logRateBurst,omitempty"`
+	// LogMaxFiles is the number of rotated log files kept when the log
+	// file reaches its maximum size. If 0, the log file is truncated.
+	LogMaxFiles uint `json:"logMaxFiles,omitempty"`
+	// LogCompress compresses rotated log files with gzip.
+	LogCompress bool `json:"logCompress,omitempty"`
 	// LogDriver driver for logs
 	LogDriver string `json:"logDriver"`
 	// File containing the conmon PID
@@ -413,10 +424,22 @@ type ContainerMiscConfig struct {
 	HealthCheckConfig *manifest.Schema2HealthConfig `json:"healthcheck"`
 	// HealthCheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthCheckOnFailureAction define.HealthCheckOnFailureAction `json:"healthcheck_on_failure_action"`
+	// HealthCheckOnFailureCommand is a command run once the container
+	// turns unhealthy, before the on-failure action is taken.
+	HealthCheckOnFailureCommand []string `json:"healthcheck_on_failure_command,omitempty"`
+	// HealthCheckOnFailureCommandInContainer runs the on-failure command
+	// inside the container instead of on the host.
+	HealthCheckOnFailureCommandInContainer bool `json:"healthcheck_on_failure_command_in_container,omitempty"`
 	// StartupHealthCheckConfig is the configuration of the startup
 	// healthcheck for the container. This will run before the regular HC
 	// runs, and when it passes the regular HC will be activated.
 	StartupHealthCheckConfig *define.StartupHealthCheck `json:"startupHealthCheck,omitempty"`
+	// PostStartCommand is a command executed inside the container right
+	// after it has been started. If it fails, the container is stopped.
+	PostStartCommand []string `json:"postStartCommand,omitempty"`
+	// PreStopCommand is a command executed inside the container right
+	// before it is being stopped.
+	PreStopCommand []string `json:"preStopCommand,omitempty"`
 	// PreserveFDs is a number of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process. The total FDs
 	// passed will be 3 + PreserveFDs.
@@ -406,6 +406,8 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 	ctrConfig.Healthcheck = c.config.HealthCheckConfig
 
 	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction.String()
+	ctrConfig.HealthcheckOnFailureCommand = c.config.HealthCheckOnFailureCommand
+	ctrConfig.HealthcheckOnFailureCommandInContainer = c.config.HealthCheckOnFailureCommandInContainer
 
 	ctrConfig.CreateCommand = c.config.CreateCommand
 
@@ -457,6 +459,19 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	logConfig.Path = c.config.LogPath
 	logConfig.Size = units.HumanSize(float64(c.config.LogSize))
 	logConfig.Tag = c.config.LogTag
+	logConfig.MaxFiles = c.config.LogMaxFiles
+	logConfig.Compress = c.config.LogCompress
+	rate, burst, quota, err := c.logLimits()
+	if err != nil {
+		return nil, err
+	}
+	if rate > 0 {
+		logConfig.RateLimit = units.HumanSize(float64(rate))
+		logConfig.Burst = units.HumanSize(float64(burst))
+	}
+	if quota > 0 {
+		logConfig.Quota = units.HumanSize(float64(quota))
+	}
 
 	hostConfig.LogConfig = logConfig
 
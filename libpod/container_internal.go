@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -1277,6 +1278,17 @@ func (c *Container) start(ctx context.Context) error {
 		return err
 	}
 
+	if len(c.config.PostStartCommand) > 0 {
+		if err := c.runLifecycleHook("postStart", c.config.PostStartCommand, 0); err != nil {
+			if c.ensureState(define.ContainerStateRunning) {
+				if stopErr := c.stop(c.StopTimeout()); stopErr != nil {
+					logrus.Errorf("Stopping container %s after failed postStart hook: %v", c.ID(), stopErr)
+				}
+			}
+			return err
+		}
+	}
+
 	if c.config.SdNotifyMode != define.SdNotifyModeHealthy {
 		return nil
 	}
@@ -1301,10 +1313,162 @@ func (c *Container) start(ctx context.Context) error {
 	return nil
 }
 
+// runLifecycleHook executes the specified lifecycle hook (e.g., postStart or
+// preStop) inside the running container and returns an error if the command
+// could not be executed or exited non-zero.  If timeout is positive and the
+// hook does not finish in time, it is killed.  Since executing a command
+// requires the container lock, it is temporarily released.
+func (c *Container) runLifecycleHook(name string, command []string, timeout time.Duration) error {
+	logrus.Debugf("Running %s hook %v for container %s", name, command, c.ID())
+
+	var output bytes.Buffer
+	streams := &define.AttachStreams{
+		OutputStream: &output,
+		ErrorStream:  &output,
+		AttachOutput: true,
+		AttachError:  true,
+	}
+	config := new(ExecConfig)
+	config.Command = command
+
+	if !c.batched {
+		c.lock.Unlock()
+	}
+	exitCode, execErr := c.execWithTimeout(config, streams, timeout)
+	if !c.batched {
+		c.lock.Lock()
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if execErr != nil {
+		return fmt.Errorf("running %s hook of container %s: %w", name, c.ID(), execErr)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%s hook of container %s exited with code %d: %s", name, c.ID(), exitCode, strings.TrimSpace(output.String()))
+	}
+	logrus.Debugf("%s hook of container %s succeeded: %s", name, c.ID(), strings.TrimSpace(output.String()))
+	return nil
+}
+
+// execWithTimeout executes the command in the container like exec and kills
+// the exec session if it does not finish within timeout.  A timeout of 0
+// means no timeout.  The container must not be locked.
+func (c *Container) execWithTimeout(config *ExecConfig, streams *define.AttachStreams, timeout time.Duration) (int, error) {
+	if timeout <= 0 {
+		return c.exec(config, streams, nil, false)
+	}
+
+	sessionID, err := c.ExecCreate(config)
+	if err != nil {
+		return -1, err
+	}
+	defer func() {
+		if err := c.ExecRemove(sessionID, true); err != nil && !errors.Is(err, define.ErrNoSuchExecSession) {
+			logrus.Errorf("Removing exec session %s of container %s: %v", sessionID, c.ID(), err)
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.execStartAndAttach(sessionID, streams, nil, false)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			return -1, err
+		}
+	case <-timer.C:
+		logrus.Warnf("Exec session %s of container %s did not finish within %s, killing it", sessionID, c.ID(), timeout)
+		c.killExecSession(sessionID, done)
+		return -1, fmt.Errorf("timed out after %s", timeout)
+	}
+
+	session, err := c.execSessionNoCopy(sessionID)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchExecSession) {
+			diedEvent, err := c.runtime.GetExecDiedEvent(context.Background(), c.ID(), sessionID)
+			if err != nil {
+				return -1, fmt.Errorf("retrieving exec session %s exit code: %w", sessionID, err)
+			}
+			return *diedEvent.ContainerExitCode, nil
+		}
+		return -1, err
+	}
+	return session.ExitCode, nil
+}
+
+// killExecSession kills the exec session started by execStartAndAttach and
+// waits for the attach, which reports on done, to finish.
+func (c *Container) killExecSession(sessionID string, done <-chan error) {
+	noTimeout := uint(0)
+	for {
+		// The session may not have been marked as running yet.
+		err := c.ExecStop(sessionID, &noTimeout)
+		if err == nil || !errors.Is(err, define.ErrExecSessionStateInvalid) {
+			if err != nil {
+				logrus.Errorf("Killing exec session %s of container %s: %v", sessionID, c.ID(), err)
+			}
+			<-done
+			return
+		}
+		select {
+		case <-done:
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// runPreStopHook runs the container's preStop hook, if any, before stopping
+// it.  The hook is bounded by the stop timeout and the time it takes is
+// charged against it, so the returned timeout is what is left for stopping the
+// container.
+func (c *Container) runPreStopHook(timeout uint) (uint, error) {
+	if len(c.config.PreStopCommand) == 0 || !c.ensureState(define.ContainerStateRunning) {
+		return timeout, nil
+	}
+	if timeout == 0 {
+		logrus.Debugf("Skipping preStop hook of container %s as the stop timeout is 0", c.ID())
+		return timeout, nil
+	}
+
+	// Make sure that the container is not restarted while the lock is
+	// released to run the hook.
+	c.state.StoppedByUser = true
+	if err := c.save(); err != nil {
+		return timeout, fmt.Errorf("saving container %s state before running preStop hook: %w", c.ID(), err)
+	}
+
+	start := time.Now()
+	if err := c.runLifecycleHook("preStop", c.config.PreStopCommand, time.Duration(timeout)*time.Second); err != nil {
+		// The container may have been removed in the meantime.
+		if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+			return 0, err
+		}
+		logrus.Warnf("PreStop hook failed: %v", err)
+	}
+
+	elapsed := uint(math.Ceil(time.Since(start).Seconds()))
+	if elapsed >= timeout {
+		return 0, nil
+	}
+	return timeout - elapsed, nil
+}
+
 // Internal, non-locking function to stop container
 func (c *Container) stop(timeout uint) error {
 	logrus.Debugf("Stopping ctr %s (timeout %d)", c.ID(), timeout)
 
+	timeout, err := c.runPreStopHook(timeout)
+	if err != nil {
+		return err
+	}
+
 	// If the container is running in a PID Namespace, then killing the
 	// primary pid is enough to kill the container.  If it is not running in
 	// a pid namespace then the OCI Runtime needs to kill ALL processes in
@@ -2518,11 +2682,133 @@ func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
 	return nil
 }
 
-// update calls the ociRuntime update function to modify a cgroup config after container creation
-func (c *Container) update(resources *spec.LinuxResources) error {
-	if err := c.ociRuntime.UpdateContainer(c, resources); err != nil {
-		return err
+// update calls the ociRuntime update function to modify a cgroup config after
+// container creation and persists all other changes in the database.
+func (c *Container) update(options *ContainerUpdateOptions) error {
+	if options.Resources != nil {
+		if err := c.ociRuntime.UpdateContainer(c, options.Resources); err != nil {
+			return err
+		}
+	}
+
+	newConfig := new(ContainerConfig)
+	if err := JSONDeepCopy(c.config, newConfig); err != nil {
+		return fmt.Errorf("copying config of container %s: %w", c.ID(), err)
+	}
+
+	var updated []string
+	if options.Resources != nil {
+		updated = append(updated, "resources")
+	}
+
+	if options.RestartPolicy != nil {
+		switch *options.RestartPolicy {
+		case define.RestartPolicyNone, define.RestartPolicyNo, define.RestartPolicyOnFailure, define.RestartPolicyAlways, define.RestartPolicyUnlessStopped:
+			newConfig.RestartPolicy = *options.RestartPolicy
+		default:
+			return fmt.Errorf("%q is not a valid restart policy: %w", *options.RestartPolicy, define.ErrInvalidArg)
+		}
+		if newConfig.RestartPolicy != define.RestartPolicyOnFailure {
+			newConfig.RestartRetries = 0
+		}
+		updated = append(updated, "restartPolicy")
+	}
+	if options.RestartRetries != nil {
+		if newConfig.RestartPolicy != define.RestartPolicyOnFailure {
+			return fmt.Errorf("restart retries can only be set with the %q restart policy: %w", define.RestartPolicyOnFailure, define.ErrInvalidArg)
+		}
+		newConfig.RestartRetries = *options.RestartRetries
+		updated = append(updated, "restartRetries")
+	}
+
+	healthCheckChanged := false
+	if options.HealthCheckConfig != nil {
+		hc := options.HealthCheckConfig
+		if len(hc.Test) == 0 || hc.Test[0] == define.HealthConfigTestNone {
+			hc = nil
+			newConfig.StartupHealthCheckConfig = nil
+		}
+		newConfig.HealthCheckConfig = hc
+		healthCheckChanged = true
+		updated = append(updated, "healthcheck")
+	}
+	if options.HealthCheckOnFailureAction != nil {
+		if *options.HealthCheckOnFailureAction == define.HealthCheckOnFailureActionCommand && len(newConfig.HealthCheckOnFailureCommand) == 0 {
+			return fmt.Errorf("on-failure action %s requires an on-failure command: %w", options.HealthCheckOnFailureAction.String(), define.ErrInvalidArg)
+		}
+		newConfig.HealthCheckOnFailureAction = *options.HealthCheckOnFailureAction
+		updated = append(updated, "healthcheckOnFailureAction")
+	}
+
+	if len(options.Env) > 0 || len(options.UnsetEnv) > 0 {
+		if newConfig.Spec.Process == nil {
+			return fmt.Errorf("container %s has no process to set environment variables for: %w", c.ID(), define.ErrInvalidArg)
+		}
+		// Keep the order of the existing variables and append new
+		// ones in the specified order.
+		remove := make(map[string]bool)
+		for _, unset := range options.UnsetEnv {
+			remove[unset] = true
+		}
+		for _, e := range options.Env {
+			key, _, _ := strings.Cut(e, "=")
+			remove[key] = true
+		}
+		env := make([]string, 0, len(newConfig.Spec.Process.Env)+len(options.Env))
+		for _, e := range newConfig.Spec.Process.Env {
+			key, _, _ := strings.Cut(e, "=")
+			if !remove[key] {
+				env = append(env, e)
+			}
+		}
+		newConfig.Spec.Process.Env = append(env, options.Env...)
+		updated = append(updated, "env")
+	}
+
+	if len(options.Labels) > 0 || len(options.UnsetLabels) > 0 {
+		if newConfig.Labels == nil {
+			newConfig.Labels = make(map[string]string)
+		}
+		for _, unset := range options.UnsetLabels {
+			delete(newConfig.Labels, unset)
+		}
+		for k, v := range options.Labels {
+			newConfig.Labels[k] = v
+		}
+		updated = append(updated, "labels")
+	}
+
+	if len(updated) == 0 {
+		return nil
+	}
+
+	// The healthcheck timers of a running container are derived from the
+	// old config, so remove them before switching to the new one.
+	running := c.ensureState(define.ContainerStateRunning)
+	if healthCheckChanged && running && c.config.HealthCheckConfig != nil {
+		if err := c.removeTransientFiles(context.Background(), c.config.StartupHealthCheckConfig != nil && !c.state.StartupHCPassed); err != nil {
+			logrus.Errorf("Removing healthcheck timer of container %s: %v", c.ID(), err)
+		}
 	}
-	logrus.Debugf("updated container %s", c.ID())
+
+	// SafeRewriteContainerConfig must be used with care. Make sure to not change config fields by accident.
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", newConfig); err != nil {
+		return fmt.Errorf("rewriting config of container %s: %w", c.ID(), err)
+	}
+	c.config = newConfig
+
+	if healthCheckChanged && running && c.config.HealthCheckConfig != nil {
+		if err := c.updateHealthStatus(define.HealthCheckStarting); err != nil {
+			logrus.Error(err)
+		}
+		if err := c.createTimer(c.config.HealthCheckConfig.Interval.String(), false); err != nil {
+			logrus.Error(err)
+		} else if err := c.startTimer(false); err != nil {
+			logrus.Error(err)
+		}
+	}
+
+	c.newContainerUpdateEvent(updated)
+	logrus.Debugf("updated container %s: %s", c.ID(), strings.Join(updated, ", "))
 	return nil
 }
@@ -35,6 +35,20 @@ func (r *Runtime) Log(ctx context.Context, containers []*Container, options *log
 	return nil
 }
 
+// logPodName returns the name of the pod of the container to show in its
+// logs, if any.
+func (c *Container) logPodName() string {
+	if c.config.Pod == "" {
+		return ""
+	}
+	name, err := c.runtime.state.GetPodName(c.config.Pod)
+	if err != nil {
+		logrus.Debugf("Looking up pod name of container %s: %v", c.ID(), err)
+		return ""
+	}
+	return name
+}
+
 // ReadLog reads a container's log based on the input options and returns log lines over a channel.
 func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
 	switch c.LogDriver() {
@@ -60,6 +74,7 @@ func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logCh
 }
 
 func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
+	podName := c.logPodName()
 	t, tailLog, err := logs.GetLogFile(c.LogPath(), options)
 	if err != nil {
 		// If the log file does not exist, this is not fatal.
@@ -79,15 +94,39 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 	}()
 
 	go func() {
+		defer options.WaitGroup.Done()
+		if options.Tail < 0 {
+			// Stream the rotated segments of the log file before
+			// its current content.
+			err := logs.ReadRotatedLog(c.LogPath(), options, func(nll *logs.LogLine) error {
+				nll.CID = c.ID()
+				nll.CName = c.Name()
+				nll.PodName = podName
+				nll.ColorID = colorID
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case logChannel <- nll:
+					return nil
+				}
+			})
+			if err != nil && ctx.Err() != nil {
+				// the consumer has cancelled
+				t.Kill(errors.New("hangup by client"))
+				return
+			} else if err != nil {
+				logrus.Errorf("Reading rotated log files of container %s: %v", c.ID(), err)
+			}
+		}
 		for _, nll := range tailLog {
 			nll.CID = c.ID()
 			nll.CName = c.Name()
+			nll.PodName = podName
 			nll.ColorID = colorID
 			if nll.Since(options.Since) && nll.Until(options.Until) {
 				logChannel <- nll
 			}
 		}
-		defer options.WaitGroup.Done()
 		var line *tail.Line
 		var ok bool
 		for {
@@ -109,6 +148,7 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 			}
 			nll.CID = c.ID()
 			nll.CName = c.Name()
+			nll.PodName = podName
 			nll.ColorID = colorID
 			if nll.Since(options.Since) && nll.Until(options.Until) {
 				logChannel <- nll
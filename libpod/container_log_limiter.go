@@ -0,0 +1,529 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/libpod/logs"
+	"github.com/containers/storage/pkg/reexec"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// logLimiterCommand is the reexec name of the process enforcing the
+	// log rate limit and quota of a container.
+	logLimiterCommand = "podman-log-limiter"
+	// logThrottledEventInterval is the minimum interval between two
+	// log-throttled events for the same reason.
+	logThrottledEventInterval = 10 * time.Second
+
+	logThrottledReasonRate  = "rate"
+	logThrottledReasonQuota = "quota"
+)
+
+func init() {
+	reexec.Register(logLimiterCommand, logLimiterMain)
+}
+
+// logLimiterConfig is passed to the log limiter process.
+type logLimiterConfig struct {
+	// FIFOPath is the FIFO conmon writes the k8s-file formatted logs to
+	FIFOPath string
+	// LogPath is the log file of the container
+	LogPath string
+	// Rate is the number of bytes per second written to the log file
+	Rate int64
+	// Burst is the number of bytes written at once after a quiet period
+	Burst int64
+	// Quota is the maximum number of bytes the log files of all
+	// containers of the pod may take up, further output is dropped
+	Quota int64
+	// QuotaUsagePath is the file counting the bytes of the log files of
+	// the containers of the pod, shared by their log limiters
+	QuotaUsagePath string
+	// MaxSize is the size at which the log file is rotated
+	MaxSize int64
+	// MaxFiles is the number of rotated log files kept, if 0 the log
+	// file is truncated
+	MaxFiles uint
+	// Compress compresses the rotated log files
+	Compress bool
+
+	ContainerID   string
+	ContainerName string
+	Image         string
+	PodID         string
+	Eventer       events.EventerOptions
+}
+
+// logLimits returns the log rate limit, burst and quota of the container.
+// The quota is the log quota of the container's pod, which is shared by all
+// containers of the pod.
+func (c *Container) logLimits() (rate, burst, quota int64, err error) {
+	if !c.logsToFile() {
+		return 0, 0, 0, nil
+	}
+	rate, burst = c.config.LogRateLimit, c.config.LogRateBurst
+	if rate > 0 && burst == 0 {
+		burst = rate
+	}
+	if c.config.Pod == "" {
+		return rate, burst, 0, nil
+	}
+	pod, err := c.runtime.state.Pod(c.config.Pod)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("looking up pod %s of container %s: %w", c.config.Pod, c.ID(), err)
+	}
+	return rate, burst, pod.config.LogQuota, nil
+}
+
+// logQuotaUsagePath returns the path of the file counting the bytes of the
+// log files of the containers of the pod.
+func (p *Pod) logQuotaUsagePath() string {
+	return filepath.Join(p.runtime.config.Engine.StaticDir, "log-quota", p.ID())
+}
+
+// logLimiterFIFOPath returns the path of the FIFO conmon writes the logs to
+// when they are limited.
+func (c *Container) logLimiterFIFOPath() string {
+	return filepath.Join(c.state.RunDir, "ctr.log.fifo")
+}
+
+// startLogLimiter starts the process enforcing the log rate limit, quota and
+// rotation retention of the container, if any.  It returns the path conmon
+// must write the logs to, which is the log path if the logs are not limited,
+// and the PID of the log limiter, 0 if it is not started.
+func (c *Container) startLogLimiter(maxSize int64) (string, int, error) {
+	rate, burst, quota, err := c.logLimits()
+	if err != nil {
+		return "", 0, err
+	}
+	maxFiles := c.config.LogMaxFiles
+	if maxSize <= 0 || !c.logsToFile() {
+		maxFiles = 0
+	}
+	if rate == 0 && quota == 0 && maxFiles == 0 {
+		return c.LogPath(), 0, nil
+	}
+	quotaUsagePath := ""
+	if quota > 0 {
+		pod, err := c.runtime.state.Pod(c.config.Pod)
+		if err != nil {
+			return "", 0, err
+		}
+		quotaUsagePath = pod.logQuotaUsagePath()
+	}
+
+	fifoPath := c.logLimiterFIFOPath()
+	if err := os.Remove(fifoPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", 0, err
+	}
+	if err := unix.Mkfifo(fifoPath, 0600); err != nil {
+		return "", 0, fmt.Errorf("creating log FIFO %s: %w", fifoPath, err)
+	}
+
+	eventer := events.EventerOptions{
+		EventerType:    c.runtime.config.Engine.EventsLogger,
+		LogFilePath:    c.runtime.config.Engine.EventsLogFilePath,
+		LogFileMaxSize: c.runtime.config.Engine.EventsLogMaxSize(),
+		WebhookURL:     c.runtime.eventsWebhookURL,
+	}
+	config, err := json.Marshal(logLimiterConfig{
+		FIFOPath:       fifoPath,
+		LogPath:        c.LogPath(),
+		Rate:           rate,
+		Burst:          burst,
+		Quota:          quota,
+		QuotaUsagePath: quotaUsagePath,
+		MaxSize:        maxSize,
+		MaxFiles:       maxFiles,
+		Compress:       c.config.LogCompress,
+		ContainerID:    c.ID(),
+		ContainerName:  c.Name(),
+		Image:          c.config.RootfsImageName,
+		PodID:          c.PodID(),
+		Eventer:        eventer,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	cmd := reexec.Command(logLimiterCommand, string(config))
+	// The log limiter must outlive podman, it exits once conmon closes
+	// the FIFO.  It is moved to the cgroup of conmon once conmon runs.
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid: true,
+	}
+	if err := cmd.Start(); err != nil {
+		return "", 0, fmt.Errorf("starting log limiter for container %s: %w", c.ID(), err)
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			logrus.Debugf("Log limiter of container %s: %v", c.ID(), err)
+		}
+	}()
+	logrus.Debugf("Started log limiter for container %s: rate %d, burst %d, quota %d", c.ID(), rate, burst, quota)
+	return fifoPath, cmd.Process.Pid, nil
+}
+
+// stopLogLimiter makes the log limiter exit if conmon never opened the FIFO.
+func (c *Container) stopLogLimiter() {
+	fifo, err := os.OpenFile(c.logLimiterFIFOPath(), os.O_WRONLY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		// ENXIO: the log limiter is not running anymore.
+		return
+	}
+	fifo.Close()
+}
+
+func logLimiterMain() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "%s expects exactly one argument\n", logLimiterCommand)
+		os.Exit(1)
+	}
+	var config logLimiterConfig
+	if err := json.Unmarshal([]byte(os.Args[1]), &config); err != nil {
+		fmt.Fprintf(os.Stderr, "parsing log limiter configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if err := runLogLimiter(config); err != nil {
+		fmt.Fprintf(os.Stderr, "log limiter of container %s: %v\n", config.ContainerID, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// runLogLimiter copies the logs from the FIFO to the log file until conmon
+// closes the FIFO.
+func runLogLimiter(config logLimiterConfig) error {
+	// Blocks until conmon opens the FIFO for writing.
+	fifo, err := os.Open(config.FIFOPath)
+	if err != nil {
+		return err
+	}
+	defer fifo.Close()
+
+	eventer, err := events.NewEventer(config.Eventer)
+	if err != nil {
+		logrus.Errorf("Creating eventer: %v", err)
+		eventer = nil
+	}
+	limiter, err := newLogLimiter(config, eventer)
+	if err != nil {
+		return err
+	}
+	defer limiter.close()
+
+	reader := bufio.NewReader(fifo)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if err := limiter.writeLine(line); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// logLimiter writes k8s-file log lines to the log file, dropping lines which
+// exceed the rate limit or the quota.
+type logLimiter struct {
+	config  logLimiterConfig
+	eventer events.Eventer
+	now     func() time.Time
+
+	file *os.File
+	size int64
+	// quotaUsage counts the bytes of the log files of all containers of
+	// the pod, including the rotated ones.
+	quotaUsage *logQuotaUsage
+
+	// tokens is the number of bytes that can be written right now. It
+	// refills at config.Rate bytes per second up to config.Burst.
+	tokens     float64
+	lastRefill time.Time
+
+	// dropped counts the bytes dropped since the last event per reason.
+	dropped   map[string]int64
+	lastEvent map[string]time.Time
+}
+
+func newLogLimiter(config logLimiterConfig, eventer events.Eventer) (*logLimiter, error) {
+	l := &logLimiter{
+		config:    config,
+		eventer:   eventer,
+		now:       time.Now,
+		tokens:    float64(config.Burst),
+		dropped:   make(map[string]int64),
+		lastEvent: make(map[string]time.Time),
+	}
+	l.lastRefill = l.now()
+	if config.Quota > 0 {
+		usage, err := openLogQuotaUsage(config.QuotaUsagePath)
+		if err != nil {
+			return nil, fmt.Errorf("opening log quota usage %s: %w", config.QuotaUsagePath, err)
+		}
+		l.quotaUsage = usage
+	}
+	if err := l.open(); err != nil {
+		l.close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *logLimiter) open() error {
+	f, err := os.OpenFile(l.config.LogPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file = f
+	l.size = info.Size()
+	return nil
+}
+
+func (l *logLimiter) close() {
+	if l.file != nil {
+		l.file.Close()
+	}
+	if l.quotaUsage != nil {
+		l.quotaUsage.close()
+	}
+}
+
+// rotate keeps the log file as a rotated segment or truncates it the same way
+// conmon does when the log file reaches its maximum size.  The bytes removed
+// from disk are released from the quota.
+func (l *logLimiter) rotate() error {
+	if l.config.MaxFiles > 0 {
+		// The oldest segment is removed by the rotation.
+		removed := logFilesSize(logs.RotatedLogPath(l.config.LogPath, l.config.MaxFiles, false), logs.RotatedLogPath(l.config.LogPath, l.config.MaxFiles, true))
+		if err := logs.RotateLogFile(l.config.LogPath, l.config.MaxFiles, l.config.Compress); err != nil {
+			return err
+		}
+		if l.config.Compress {
+			removed += l.size - logFilesSize(logs.RotatedLogPath(l.config.LogPath, 1, true))
+		}
+		l.releaseQuota(removed)
+		l.file.Close()
+		l.file = nil
+		return l.open()
+	}
+
+	tmpPath := l.config.LogPath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	f.Close()
+	if err := os.Rename(tmpPath, l.config.LogPath); err != nil {
+		return err
+	}
+	l.releaseQuota(l.size)
+	l.file.Close()
+	l.file = nil
+	return l.open()
+}
+
+func (l *logLimiter) releaseQuota(n int64) {
+	if l.quotaUsage != nil {
+		l.quotaUsage.release(n)
+	}
+}
+
+// logFilesSize returns the total size of the files, missing files count as
+// empty.
+func logFilesSize(paths ...string) int64 {
+	var size int64
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+// releaseLogQuota releases the size of the log files of the container from
+// the log quota of its pod, as they are removed with the container.
+func (c *Container) releaseLogQuota(pod *Pod) {
+	if pod == nil || pod.config.LogQuota <= 0 || !c.logsToFile() || filepath.Dir(c.LogPath()) != c.config.StaticDir {
+		return
+	}
+	rotated, err := logs.RotatedLogFiles(c.LogPath())
+	if err != nil {
+		logrus.Debugf("Listing rotated log files of container %s: %v", c.ID(), err)
+	}
+	usage, err := openLogQuotaUsage(pod.logQuotaUsagePath())
+	if err != nil {
+		logrus.Errorf("Opening log quota usage of pod %s: %v", pod.ID(), err)
+		return
+	}
+	defer usage.close()
+	usage.release(logFilesSize(append(rotated, c.LogPath())...))
+}
+
+// writeLine writes a single log line unless it exceeds the rate limit or the
+// quota.
+func (l *logLimiter) writeLine(line []byte) error {
+	n := int64(len(line))
+
+	if l.config.Rate > 0 {
+		now := l.now()
+		l.tokens += now.Sub(l.lastRefill).Seconds() * float64(l.config.Rate)
+		if l.tokens > float64(l.config.Burst) {
+			l.tokens = float64(l.config.Burst)
+		}
+		l.lastRefill = now
+		// Lines longer than the burst pass once the bucket is full.
+		needed := n
+		if needed > l.config.Burst {
+			needed = l.config.Burst
+		}
+		if l.tokens < float64(needed) {
+			l.throttled(logThrottledReasonRate, n)
+			return nil
+		}
+		l.tokens -= float64(n)
+	}
+
+	if l.config.MaxSize > 0 && l.size > 0 && l.size+n > l.config.MaxSize {
+		if err := l.rotate(); err != nil {
+			return fmt.Errorf("rotating log file %s: %w", l.config.LogPath, err)
+		}
+	}
+	if l.quotaUsage != nil && !l.quotaUsage.add(n, l.config.Quota) {
+		l.throttled(logThrottledReasonQuota, n)
+		return nil
+	}
+
+	written, err := l.file.Write(line)
+	l.size += int64(written)
+	return err
+}
+
+// throttled records dropped bytes and writes a log-throttled event at most
+// once per logThrottledEventInterval and reason.
+func (l *logLimiter) throttled(reason string, n int64) {
+	l.dropped[reason] += n
+	now := l.now()
+	if last, ok := l.lastEvent[reason]; ok && now.Sub(last) < logThrottledEventInterval {
+		return
+	}
+	l.lastEvent[reason] = now
+	dropped := l.dropped[reason]
+	l.dropped[reason] = 0
+	if l.eventer == nil {
+		return
+	}
+
+	e := events.NewEvent(events.LogThrottled)
+	e.ID = l.config.ContainerID
+	e.Name = l.config.ContainerName
+	e.Image = l.config.Image
+	e.Type = events.Container
+	e.Details = events.Details{
+		ID:    e.ID,
+		PodID: l.config.PodID,
+		Attributes: map[string]string{
+			"reason":  reason,
+			"dropped": fmt.Sprintf("%d", dropped),
+		},
+	}
+	if err := l.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write log-throttled event: %v", err)
+	}
+}
+
+// logQuotaUsage is the number of bytes the log files of the containers of a
+// pod take up on disk.  It is stored in a file mapped into the memory of all
+// log limiters of the pod, so that they share the quota of the pod.
+type logQuotaUsage struct {
+	data []byte
+}
+
+func openLogQuotaUsage(path string) (*logQuotaUsage, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// Growing the file keeps the usage written by other log limiters.
+	if info.Size() < 8 {
+		if err := f.Truncate(8); err != nil {
+			return nil, err
+		}
+	}
+	data, err := unix.Mmap(int(f.Fd()), 0, 8, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	return &logQuotaUsage{data: data}, nil
+}
+
+func (u *logQuotaUsage) counter() *int64 {
+	return (*int64)(unsafe.Pointer(&u.data[0]))
+}
+
+// add adds n bytes to the usage unless it would exceed the quota.  It returns
+// whether the bytes were added.
+func (u *logQuotaUsage) add(n, quota int64) bool {
+	for {
+		used := atomic.LoadInt64(u.counter())
+		if used+n > quota {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(u.counter(), used, used+n) {
+			return true
+		}
+	}
+}
+
+// release subtracts n bytes removed from disk from the usage.
+func (u *logQuotaUsage) release(n int64) {
+	for {
+		used := atomic.LoadInt64(u.counter())
+		released := used - n
+		if released < 0 {
+			released = 0
+		}
+		if atomic.CompareAndSwapInt64(u.counter(), used, released) {
+			return
+		}
+	}
+}
+
+func (u *logQuotaUsage) close() {
+	if err := unix.Munmap(u.data); err != nil {
+		logrus.Debugf("Unmapping log quota usage: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/libpod/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEventer struct {
+	events.Eventer
+	written []events.Event
+}
+
+func (f *fakeEventer) Write(e events.Event) error {
+	f.written = append(f.written, e)
+	return nil
+}
+
+func newTestLogLimiter(t *testing.T, config logLimiterConfig) (*logLimiter, *fakeEventer, *time.Time) {
+	dir := t.TempDir()
+	config.LogPath = filepath.Join(dir, "ctr.log")
+	if config.QuotaUsagePath == "" {
+		config.QuotaUsagePath = filepath.Join(dir, "log-quota")
+	}
+	config.ContainerID = "123"
+	eventer := new(fakeEventer)
+	limiter, err := newLogLimiter(config, eventer)
+	require.NoError(t, err)
+	t.Cleanup(limiter.close)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	limiter.lastRefill = now
+	return limiter, eventer, &now
+}
+
+func readLog(t *testing.T, l *logLimiter) string {
+	content, err := os.ReadFile(l.config.LogPath)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestLogLimiterRate(t *testing.T) {
+	limiter, eventer, now := newTestLogLimiter(t, logLimiterConfig{Rate: 10, Burst: 20})
+	line := []byte("123456789\n")
+
+	// The burst allows two lines at once.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.writeLine(line))
+	}
+	assert.Equal(t, strings.Repeat(string(line), 2), readLog(t, limiter))
+	require.Len(t, eventer.written, 1)
+	assert.Equal(t, events.LogThrottled, eventer.written[0].Status)
+	assert.Equal(t, "rate", eventer.written[0].Attributes["reason"])
+	assert.Equal(t, "10", eventer.written[0].Attributes["dropped"])
+
+	// One second later, one more line fits.
+	*now = now.Add(time.Second)
+	require.NoError(t, limiter.writeLine(line))
+	require.NoError(t, limiter.writeLine(line))
+	assert.Equal(t, strings.Repeat(string(line), 3), readLog(t, limiter))
+	// Events are rate limited as well.
+	assert.Len(t, eventer.written, 1)
+
+	*now = now.Add(logThrottledEventInterval)
+	require.NoError(t, limiter.writeLine(line))
+	require.NoError(t, limiter.writeLine(line))
+	require.NoError(t, limiter.writeLine(line))
+	require.Len(t, eventer.written, 2)
+	assert.Equal(t, "20", eventer.written[1].Attributes["dropped"])
+}
+
+func TestLogLimiterQuota(t *testing.T) {
+	limiter, eventer, _ := newTestLogLimiter(t, logLimiterConfig{Quota: 25})
+	line := []byte("123456789\n")
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.writeLine(line))
+	}
+	assert.Equal(t, strings.Repeat(string(line), 2), readLog(t, limiter))
+	require.Len(t, eventer.written, 1)
+	assert.Equal(t, "quota", eventer.written[0].Attributes["reason"])
+	assert.Equal(t, "123", eventer.written[0].ID)
+}
+
+func TestLogLimiterQuotaShared(t *testing.T) {
+	usagePath := filepath.Join(t.TempDir(), "log-quota")
+	first, _, _ := newTestLogLimiter(t, logLimiterConfig{Quota: 30, QuotaUsagePath: usagePath, MaxSize: 15, MaxFiles: 1})
+	second, eventer, _ := newTestLogLimiter(t, logLimiterConfig{Quota: 30, QuotaUsagePath: usagePath})
+	line := []byte("123456789\n")
+
+	// Removing the oldest rotated segment frees its bytes, so only the
+	// 20 bytes on disk count towards the quota of the pod.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, first.writeLine(line))
+	}
+	assert.Equal(t, string(line), readLog(t, first))
+	assert.Equal(t, int64(20), atomic.LoadInt64(first.quotaUsage.counter()))
+	require.NoError(t, second.writeLine(line))
+	require.NoError(t, second.writeLine(line))
+	assert.Equal(t, string(line), readLog(t, second))
+	require.Len(t, eventer.written, 1)
+	assert.Equal(t, "quota", eventer.written[0].Attributes["reason"])
+
+	// The usage outlives the log limiters.
+	third, _, _ := newTestLogLimiter(t, logLimiterConfig{Quota: 40, QuotaUsagePath: usagePath})
+	require.NoError(t, third.writeLine(line))
+	require.NoError(t, third.writeLine(line))
+	assert.Equal(t, string(line), readLog(t, third))
+}
+
+func TestLogLimiterQuotaRotate(t *testing.T) {
+	for _, config := range []logLimiterConfig{
+		{Quota: 100, MaxSize: 15},
+		{Quota: 100, MaxSize: 15, MaxFiles: 2},
+		{Quota: 100, MaxSize: 15, MaxFiles: 2, Compress: true},
+	} {
+		limiter, _, _ := newTestLogLimiter(t, config)
+		for i := 0; i < 5; i++ {
+			require.NoError(t, limiter.writeLine([]byte("123456789\n")))
+		}
+		// The usage is the size of the log files on disk.
+		rotated, err := logs.RotatedLogFiles(limiter.config.LogPath)
+		require.NoError(t, err)
+		assert.Len(t, rotated, int(config.MaxFiles))
+		onDisk := logFilesSize(append(rotated, limiter.config.LogPath)...)
+		assert.Equal(t, onDisk, atomic.LoadInt64(limiter.quotaUsage.counter()), "%+v", config)
+	}
+}
+
+func TestLogLimiterRotate(t *testing.T) {
+	limiter, eventer, _ := newTestLogLimiter(t, logLimiterConfig{MaxSize: 25})
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth line\n"} {
+		require.NoError(t, limiter.writeLine([]byte(line)))
+	}
+	assert.Equal(t, "fourth line\n", readLog(t, limiter))
+	assert.Empty(t, eventer.written)
+}
+
+func TestLogLimiterRotateMaxFiles(t *testing.T) {
+	limiter, _, _ := newTestLogLimiter(t, logLimiterConfig{MaxSize: 10, MaxFiles: 2, Compress: true})
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		require.NoError(t, limiter.writeLine([]byte(line)))
+	}
+	assert.Equal(t, "fourth\n", readLog(t, limiter))
+
+	files, err := logs.RotatedLogFiles(limiter.config.LogPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		logs.RotatedLogPath(limiter.config.LogPath, 2, true),
+		logs.RotatedLogPath(limiter.config.LogPath, 1, true),
+	}, files)
+}
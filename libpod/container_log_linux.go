@@ -38,6 +38,8 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 		return fmt.Errorf("using --follow with the journald --log-driver but without the journald --events-backend (%s) is not supported", c.runtime.config.Engine.EventsLogger)
 	}
 
+	podName := c.logPodName()
+
 	journal, err := sdjournal.NewJournal()
 	if err != nil {
 		return err
@@ -198,9 +200,8 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 			}
 			logLine.CID = id
 			logLine.ColorID = colorID
-			if options.UseName {
-				logLine.CName = c.Name()
-			}
+			logLine.CName = c.Name()
+			logLine.PodName = podName
 			if doTail {
 				tailQueue = append(tailQueue, logLine)
 				continue
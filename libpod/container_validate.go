@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/pkg/shortnames"
@@ -147,11 +148,27 @@ func (c *Container) validate() error {
 		return fmt.Errorf("cannot set on-failure action to %s without a health check", c.config.HealthCheckOnFailureAction.String())
 	}
 
+	if len(c.config.HealthCheckOnFailureCommand) > 0 && c.config.HealthCheckOnFailureAction == define.HealthCheckOnFailureActionNone {
+		return fmt.Errorf("cannot set an on-failure command without an on-failure action: %w", define.ErrInvalidArg)
+	}
+
+	if c.config.HealthCheckOnFailureAction == define.HealthCheckOnFailureActionCommand && len(c.config.HealthCheckOnFailureCommand) == 0 {
+		return fmt.Errorf("on-failure action %s requires an on-failure command: %w", c.config.HealthCheckOnFailureAction.String(), define.ErrInvalidArg)
+	}
+
+	if c.config.LogRateLimit > 0 && !c.logsToFile() {
+		return fmt.Errorf("cannot set a log rate limit with the %s log driver: %w", c.config.LogDriver, define.ErrInvalidArg)
+	}
+
+	if c.config.LogMaxFiles > 0 && !c.logsToFile() {
+		return fmt.Errorf("cannot keep rotated log files with the %s log driver: %w", c.config.LogDriver, define.ErrInvalidArg)
+	}
+
 	if value, exists := c.config.Labels[define.AutoUpdateLabel]; exists {
 		// TODO: we cannot reference pkg/autoupdate here due to
 		// circular dependencies.  It's worth considering moving the
 		// auto-update logic into the libpod package.
-		if value == "registry" || value == "image" {
+		if value == "registry" || value == "image" || strings.HasPrefix(value, "semver:") {
 			if err := validateAutoUpdateImageReference(c.config.RawImageName); err != nil {
 				return err
 			}
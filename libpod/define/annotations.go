@@ -161,6 +161,11 @@ const (
 	// the k8s behavior of waiting for the intialDelaySeconds to be over before updating the status
 	KubeHealthCheckAnnotation = "io.podman.annotations.kube.health.check"
 
+	// KubeRestartRetriesAnnotation is used by kube play to specify the number of
+	// restart retries of a pod with the OnFailure restart policy, e.g., to map
+	// the backoffLimit of a Job
+	KubeRestartRetriesAnnotation = "io.podman.annotations.kube.restart.retries"
+
 	// MaxKubeAnnotation is the max length of annotations allowed by Kubernetes.
 	MaxKubeAnnotation = 63
 )
@@ -57,6 +57,11 @@ type InspectContainerConfig struct {
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 	// HealthcheckOnFailureAction defines an action to take once the container turns unhealthy.
 	HealthcheckOnFailureAction string `json:"HealthcheckOnFailureAction,omitempty"`
+	// HealthcheckOnFailureCommand is run once the container turns unhealthy.
+	HealthcheckOnFailureCommand []string `json:"HealthcheckOnFailureCommand,omitempty"`
+	// HealthcheckOnFailureCommandInContainer is set if the on-failure
+	// command is run inside the container instead of on the host.
+	HealthcheckOnFailureCommandInContainer bool `json:"HealthcheckOnFailureCommandInContainer,omitempty"`
 	// CreateCommand is the full command plus arguments of the process the
 	// container has been created with.
 	CreateCommand []string `json:"CreateCommand,omitempty"`
@@ -113,6 +118,18 @@ type InspectLogConfig struct {
 	Tag string `json:"Tag"`
 	// Size specifies a maximum size of the container log
 	Size string `json:"Size"`
+	// RateLimit specifies the number of bytes per second the container
+	// may log
+	RateLimit string `json:"RateLimit,omitempty"`
+	// Burst specifies the number of bytes the container may log at once
+	Burst string `json:"Burst,omitempty"`
+	// Quota specifies the log quota of the pod, shared by all containers
+	// of the pod
+	Quota string `json:"Quota,omitempty"`
+	// MaxFiles is the number of rotated log files kept
+	MaxFiles uint `json:"MaxFiles,omitempty"`
+	// Compress specifies whether rotated log files are compressed
+	Compress bool `json:"Compress,omitempty"`
 }
 
 // InspectBlkioWeightDevice holds information about the relative weight
@@ -245,6 +262,8 @@ type HealthCheckResults struct {
 	FailingStreak int `json:"FailingStreak"`
 	// Log describes healthcheck attempts and results
 	Log []HealthCheckLog `json:"Log"`
+	// OnFailureLog describes the runs of the on-failure command
+	OnFailureLog []HealthCheckLog `json:"OnFailureLog,omitempty"`
 }
 
 // HealthCheckLog describes the results of a single healthcheck
@@ -3,6 +3,7 @@ package define
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/containers/image/v5/manifest"
 )
@@ -84,6 +85,8 @@ const (
 	HealthCheckOnFailureActionRestart = iota
 	// HealthCheckOnFailureActionNonce instructs Podman to stop the container on an unhealthy status.
 	HealthCheckOnFailureActionStop = iota
+	// HealthCheckOnFailureActionCommand instructs Podman to only run the on-failure command on an unhealthy status.
+	HealthCheckOnFailureActionCommand = iota
 )
 
 // String representations for on-failure actions.
@@ -93,6 +96,7 @@ const (
 	strHealthCheckOnFailureActionKill    = "kill"
 	strHealthCheckOnFailureActionRestart = "restart"
 	strHealthCheckOnFailureActionStop    = "stop"
+	strHealthCheckOnFailureActionCommand = "command"
 )
 
 // SupportedHealthCheckOnFailureActions lists all supported healthcheck restart policies.
@@ -101,6 +105,7 @@ var SupportedHealthCheckOnFailureActions = []string{
 	strHealthCheckOnFailureActionKill,
 	strHealthCheckOnFailureActionRestart,
 	strHealthCheckOnFailureActionStop,
+	strHealthCheckOnFailureActionCommand,
 }
 
 // String returns the string representation of the HealthCheckOnFailureAction.
@@ -114,6 +119,8 @@ func (h HealthCheckOnFailureAction) String() string {
 		return strHealthCheckOnFailureActionRestart
 	case HealthCheckOnFailureActionStop:
 		return strHealthCheckOnFailureActionStop
+	case HealthCheckOnFailureActionCommand:
+		return strHealthCheckOnFailureActionCommand
 	default:
 		return strHealthCheckOnFailureActionInvalid
 	}
@@ -131,6 +138,8 @@ func ParseHealthCheckOnFailureAction(s string) (HealthCheckOnFailureAction, erro
 		return HealthCheckOnFailureActionRestart, nil
 	case strHealthCheckOnFailureActionStop:
 		return HealthCheckOnFailureActionStop, nil
+	case strHealthCheckOnFailureActionCommand:
+		return HealthCheckOnFailureActionCommand, nil
 	default:
 		err := fmt.Errorf("invalid on-failure action %q for health check: supported actions are %s", s, strings.Join(SupportedHealthCheckOnFailureActions, ","))
 		return HealthCheckOnFailureActionInvalid, err
@@ -145,3 +154,38 @@ type StartupHealthCheck struct {
 	// If set to 0, a single success will mark the HC as passed.
 	Successes int `json:",omitempty"`
 }
+
+// HealthCheckHistoryEntry is a single healthcheck probe recorded in the
+// healthcheck history of a container.
+type HealthCheckHistoryEntry struct {
+	HealthCheckLog
+	// Status is the health status of the container after the probe
+	Status string `json:"Status"`
+	// Startup is set if the probe was run by the startup healthcheck
+	Startup bool `json:"Startup,omitempty"`
+}
+
+// HealthCheckHistoryOptions selects the entries of the healthcheck history.
+type HealthCheckHistoryOptions struct {
+	// Since only selects probes started at or after this time
+	Since time.Time
+	// Until only selects probes started at or before this time
+	Until time.Time
+	// Status only selects probes which left the container in this health
+	// status
+	Status string
+	// Follow keeps sending new probes until the container stops
+	Follow bool
+}
+
+// ValidateHealthCheckHistoryStatus returns an error if status cannot be used
+// to filter the healthcheck history.
+func ValidateHealthCheckHistoryStatus(status string) error {
+	switch status {
+	case "", HealthCheckHealthy, HealthCheckUnhealthy, HealthCheckStarting:
+		return nil
+	default:
+		return fmt.Errorf("invalid healthcheck status %q: supported statuses are %s,%s,%s: %w",
+			status, HealthCheckHealthy, HealthCheckUnhealthy, HealthCheckStarting, ErrInvalidArg)
+	}
+}
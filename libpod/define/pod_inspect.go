@@ -85,6 +85,9 @@ type InspectPodData struct {
 	BlkioWeightDevice []InspectBlkioWeightDevice `json:"blkio_weight_device,omitempty"`
 	// RestartPolicy of the pod.
 	RestartPolicy string `json:"RestartPolicy,omitempty"`
+	// LogQuota is the number of bytes the log files of all containers of
+	// the pod may take up on disk together.
+	LogQuota int64 `json:"log_quota,omitempty"`
 	// Number of the pod's Libpod lock.
 	LockNumber uint32
 }
@@ -0,0 +1,66 @@
+package define
+
+// Kinds of inconsistencies found by a system check.
+const (
+	// CheckOrphanedExecSession is an exec session registered in the
+	// database that its container does not know about.
+	CheckOrphanedExecSession = "orphaned-exec-session"
+	// CheckStaleExecSession is an exec session recorded as running in a
+	// container that is not running.
+	CheckStaleExecSession = "stale-exec-session"
+	// CheckMissingPod is a container that belongs to a pod that does not
+	// exist.
+	CheckMissingPod = "missing-pod"
+	// CheckMissingVolume is a container that mounts a named volume that
+	// does not exist.
+	CheckMissingVolume = "missing-volume"
+	// CheckMissingImage is a container created from an image that is no
+	// longer in storage.
+	CheckMissingImage = "missing-image"
+	// CheckDanglingStorageContainer is a container in c/storage that is
+	// not known to libpod, buildah or an image volume.
+	CheckDanglingStorageContainer = "dangling-storage-container"
+	// CheckDuplicateLock is a lock ID used by more than one container, pod
+	// or volume.
+	CheckDuplicateLock = "duplicate-lock"
+)
+
+// SystemCheckOptions describes the checks run by Runtime.SystemCheck.
+type SystemCheckOptions struct {
+	// Quick skips the checks that have to walk c/storage.
+	Quick bool
+	// Repair fixes the inconsistencies that are safe to fix.
+	Repair bool
+}
+
+// SystemCheckIssue describes a single inconsistency found by a system
+// check.
+type SystemCheckIssue struct {
+	// Kind is one of the Check* constants.
+	Kind string
+	// ID is the ID or name of the affected container, volume or lock.
+	ID string
+	// Message describes the inconsistency.
+	Message string
+	// Repaired is set when the inconsistency has been fixed.
+	Repaired bool
+	// RepairError is set when fixing the inconsistency failed.
+	RepairError string `json:",omitempty"`
+}
+
+// SystemCheckReport is the result of a system check.
+type SystemCheckReport struct {
+	// Issues lists every inconsistency found, in the order of the checks.
+	Issues []SystemCheckIssue
+}
+
+// Unrepaired returns the number of issues that were not repaired.
+func (r *SystemCheckReport) Unrepaired() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			count++
+		}
+	}
+	return count
+}
@@ -5,10 +5,19 @@ package libpod
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/containers/common/libimage"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/transports"
 	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,6 +31,7 @@ func (r *Runtime) newEventer() (events.Eventer, error) {
 		EventerType:    r.config.Engine.EventsLogger,
 		LogFilePath:    r.config.Engine.EventsLogFilePath,
 		LogFileMaxSize: r.config.Engine.EventsLogMaxSize(),
+		WebhookURL:     r.eventsWebhookURL,
 	}
 	return events.NewEventer(options)
 }
@@ -108,6 +118,31 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newContainerUpdateEvent creates a new update event listing the updated
+// fields of the container's configuration.
+func (c *Container) newContainerUpdateEvent(updated []string) {
+	e := events.NewEvent(events.Update)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+
+	attributes := make(map[string]string, len(c.config.Labels)+1)
+	for k, v := range c.Labels() {
+		attributes[k] = v
+	}
+	attributes["updated"] = strings.Join(updated, ",")
+	e.Details = events.Details{
+		ID:         e.ID,
+		PodID:      c.PodID(),
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write container update event: %q", err)
+	}
+}
+
 // newExecDiedEvent creates a new event for an exec session's death
 func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	e := events.NewEvent(events.ExecDied)
@@ -131,23 +166,28 @@ func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 }
 
 // netNetworkEvent creates a new event based on a network connect/disconnect
-func (c *Container) newNetworkEvent(status events.Status, netName string) {
+func (c *Container) newNetworkEvent(ctx context.Context, status events.Status, netName string) {
 	e := events.NewEvent(status)
 	e.ID = c.ID()
 	e.Name = c.Name()
 	e.Type = events.Network
 	e.Network = netName
+	if network, err := c.runtime.network.NetworkInspect(netName); err == nil {
+		e.SetAttribute(events.DriverAttribute, network.Driver)
+	}
+	e.SetCaller(c.runtime.eventCaller(ctx))
 	if err := c.runtime.eventer.Write(e); err != nil {
 		logrus.Errorf("Unable to write pod event: %q", err)
 	}
 }
 
 // newPodEvent creates a new event for a libpod pod
-func (p *Pod) newPodEvent(status events.Status) {
+func (p *Pod) newPodEvent(ctx context.Context, status events.Status) {
 	e := events.NewEvent(status)
 	e.ID = p.ID()
 	e.Name = p.Name()
 	e.Type = events.Pod
+	e.SetCaller(p.runtime.eventCaller(ctx))
 	if err := p.runtime.eventer.Write(e); err != nil {
 		logrus.Errorf("Unable to write pod event: %q", err)
 	}
@@ -163,16 +203,204 @@ func (r *Runtime) NewSystemEvent(status events.Status) {
 	}
 }
 
+// NewSecretEvent creates a new event for a secret.  Secrets are managed
+// outside of libpod, so the callers have to write the events.
+func (r *Runtime) NewSecretEvent(ctx context.Context, status events.Status, id, name, driver string) {
+	e := events.NewEvent(status)
+	e.ID = id
+	e.Name = name
+	e.Type = events.Secret
+	e.SetAttribute(events.DriverAttribute, driver)
+	e.SetCaller(r.eventCaller(ctx))
+	if err := r.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write secret event: %q", err)
+	}
+}
+
 // newVolumeEvent creates a new event for a libpod volume
-func (v *Volume) newVolumeEvent(status events.Status) {
+func (v *Volume) newVolumeEvent(ctx context.Context, status events.Status) {
 	e := events.NewEvent(status)
 	e.Name = v.Name()
 	e.Type = events.Volume
+	e.SetAttribute(events.DriverAttribute, v.Driver())
+	e.SetAttribute(events.MountpointAttribute, v.mountPoint())
+	e.SetCaller(v.runtime.eventCaller(ctx))
 	if err := v.runtime.eventer.Write(e); err != nil {
 		logrus.Errorf("Unable to write volume event: %q", err)
 	}
 }
 
+// setImageEventAttributes adds the caller along with the digest of the image
+// to an image event, and the size and registry of the image to pull and push
+// events.
+func (r *Runtime) setImageEventAttributes(e *events.Event) {
+	e.SetCaller(r.imageEventCallers.callerAt(e.Time))
+
+	var img *libimage.Image
+	// The image is gone when it has been removed.
+	if e.Status != events.Remove {
+		var err error
+		img, _, err = r.libimageRuntime.LookupImage(e.ID, nil)
+		if err != nil {
+			logrus.Debugf("Looking up image %s of %s event: %v", e.ID, e.Status, err)
+		}
+	}
+	if img != nil {
+		e.SetAttribute(events.DigestAttribute, img.Digest().String())
+	}
+	if e.Status != events.Pull && e.Status != events.Push {
+		return
+	}
+	// Computing the size of an image is expensive, only do it for the
+	// events transferring the image.
+	if img != nil {
+		if size, err := img.Size(); err == nil {
+			e.SetAttribute(events.SizeAttribute, strconv.FormatInt(size, 10))
+		}
+	}
+	e.SetAttribute(events.RegistryAttribute, imageEventRegistry(e.Name, img))
+}
+
+// imageEventRegistry returns the registry of the image reference name of a
+// pull or push event, which may include a transport.  Short names are looked
+// up in the names of img.
+func imageEventRegistry(name string, img *libimage.Image) string {
+	if i := strings.Index(name, ":"); i > 0 && transports.Get(name[:i]) != nil {
+		if name[:i] != docker.Transport.Name() {
+			return ""
+		}
+		name = strings.TrimPrefix(name[i+1:], "//")
+	}
+	if named, err := reference.ParseNamed(name); err == nil {
+		return reference.Domain(named)
+	}
+	if img == nil {
+		return ""
+	}
+
+	// Strip the tag or digest of the short name.
+	repo := name
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo = repo[:i]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	for _, imgName := range img.Names() {
+		named, err := reference.ParseNamed(imgName)
+		if err != nil {
+			continue
+		}
+		if path := reference.Path(named); path == repo || strings.HasSuffix(path, "/"+repo) {
+			return reference.Domain(named)
+		}
+	}
+	return ""
+}
+
+// eventCaller returns the caller stored in ctx, the user and process of
+// podman itself if there is none.
+func (r *Runtime) eventCaller(ctx context.Context) events.Caller {
+	if caller, ok := events.CallerFromContext(ctx); ok {
+		return caller
+	}
+	return localEventCaller()
+}
+
+func localEventCaller() events.Caller {
+	return events.Caller{UID: rootless.GetRootlessUID(), PID: os.Getpid()}
+}
+
+// eventCallerRetention is how long the callers of finished API requests are
+// remembered to attribute image events to them, which are written
+// asynchronously.
+const eventCallerRetention = 10 * time.Second
+
+type eventCallerSpan struct {
+	caller     events.Caller
+	start, end time.Time
+}
+
+// eventCallers tracks the callers of API requests to attribute image events
+// to them.  libimage writes image events without a context, so an event is
+// attributed to the callers of the requests in flight when it occurred.
+type eventCallers struct {
+	lock  sync.Mutex
+	spans []*eventCallerSpan
+}
+
+// TrackImageEventCaller records the caller stored in ctx as causing the image
+// events which occur until the returned function is called.
+func (r *Runtime) TrackImageEventCaller(ctx context.Context) func() {
+	caller, ok := events.CallerFromContext(ctx)
+	if !ok {
+		return func() {}
+	}
+	done := r.imageEventCallers.track(caller, time.Now())
+	return func() {
+		done(time.Now())
+	}
+}
+
+// track records caller from start until the returned function is called with
+// the end of the request.
+func (t *eventCallers) track(caller events.Caller, start time.Time) func(end time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	span := &eventCallerSpan{caller: caller, start: start}
+	t.spans = append(t.spans, span)
+	return func(end time.Time) {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		span.end = end
+		// Forget the callers of requests which finished a while ago.
+		spans := t.spans[:0]
+		for _, s := range t.spans {
+			if s.end.IsZero() || span.end.Sub(s.end) < eventCallerRetention {
+				spans = append(spans, s)
+			}
+		}
+		t.spans = spans
+	}
+}
+
+// callerAt returns the caller of an event which occurred at when, inferred
+// from the requests in flight at that time.  If several requests were in
+// flight, only the attributes their callers have in common are set.  If none
+// were, the event was caused by podman itself.
+func (t *eventCallers) callerAt(when time.Time) events.Caller {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var (
+		caller events.Caller
+		found  bool
+	)
+	for _, s := range t.spans {
+		if when.Before(s.start) || (!s.end.IsZero() && when.After(s.end)) {
+			continue
+		}
+		if !found {
+			caller = s.caller
+			found = true
+			continue
+		}
+		if caller.UID != s.caller.UID {
+			caller.UID = -1
+		}
+		if caller.PID != s.caller.PID {
+			caller.PID = 0
+		}
+		if caller.Address != s.caller.Address {
+			caller.Address = ""
+		}
+	}
+	if !found {
+		return localEventCaller()
+	}
+	caller.Inferred = true
+	return caller
+}
+
 // Events is a wrapper function for everyone to begin tailing the events log
 // with options
 func (r *Runtime) Events(ctx context.Context, options events.ReadOptions) error {
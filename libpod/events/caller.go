@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"strconv"
+)
+
+// Attributes added to image, volume, network, secret and pod events.
+const (
+	// CallerUIDAttribute is the UID of the user who caused the event.
+	CallerUIDAttribute = "callerUID"
+	// CallerPIDAttribute is the PID of the process which caused the event.
+	CallerPIDAttribute = "callerPID"
+	// CallerAddressAttribute is the remote address of the API client
+	// which caused the event if it connected over TCP.
+	CallerAddressAttribute = "callerAddress"
+	// CallerInferredAttribute is set to true if the caller attributes
+	// were inferred from the API requests in flight when the event
+	// occurred rather than passed along with the operation.
+	CallerInferredAttribute = "callerInferred"
+	// DigestAttribute is the digest of the image of an image event.
+	DigestAttribute = "digest"
+	// SizeAttribute is the size of the image of an image event in bytes.
+	SizeAttribute = "size"
+	// RegistryAttribute is the registry an image was pulled from or pushed
+	// to.
+	RegistryAttribute = "registry"
+	// DriverAttribute is the driver of a volume, network or secret.
+	DriverAttribute = "driver"
+	// MountpointAttribute is the mount point of a volume.
+	MountpointAttribute = "mountpoint"
+)
+
+// Caller identifies the user or the API client on whose behalf an event was
+// created.
+type Caller struct {
+	// UID of the calling user, -1 if unknown.
+	UID int
+	// PID of the calling process, 0 if unknown.
+	PID int
+	// Address is the remote address of an API client connected over TCP.
+	Address string
+	// Inferred is set if the caller was inferred from the API requests
+	// in flight when the event occurred.
+	Inferred bool
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx carrying the caller of the operations done
+// with it.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller stored in ctx by WithCaller.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	if ctx == nil {
+		return Caller{}, false
+	}
+	caller, ok := ctx.Value(callerKey{}).(Caller)
+	return caller, ok
+}
+
+// SetCaller records the caller in the attributes of the event.
+func (e *Event) SetCaller(caller Caller) {
+	if caller.UID >= 0 {
+		e.SetAttribute(CallerUIDAttribute, strconv.Itoa(caller.UID))
+	}
+	if caller.PID > 0 {
+		e.SetAttribute(CallerPIDAttribute, strconv.Itoa(caller.PID))
+	}
+	if caller.Address != "" {
+		e.SetAttribute(CallerAddressAttribute, caller.Address)
+	}
+	if caller.Inferred {
+		e.SetAttribute(CallerInferredAttribute, "true")
+	}
+}
+
+// SetAttribute sets an attribute of the event.  Empty values are ignored.
+func (e *Event) SetAttribute(key, value string) {
+	if value == "" {
+		return
+	}
+	if e.Attributes == nil {
+		e.Attributes = make(map[string]string)
+	}
+	e.Attributes[key] = value
+}
@@ -18,6 +18,9 @@ const (
 	Null EventerType = iota
 	// Memory indicates the event logger will hold events in memory
 	Memory EventerType = iota
+	// Webhook indicates events are logged to a logfile and forwarded to an
+	// HTTP endpoint or Unix socket
+	Webhook EventerType = iota
 )
 
 // Event describes the attributes of a libpod event
@@ -41,6 +44,10 @@ type Event struct {
 	Type Type
 	// Health status of the current container
 	HealthStatus string `json:"health_status,omitempty"`
+	// Cursor identifies the position of the event in the events backend.
+	// Reading events with ReadOptions.AfterCursor set to it resumes right
+	// after the event.
+	Cursor string `json:"cursor,omitempty"`
 
 	Details
 }
@@ -71,6 +78,9 @@ type EventerOptions struct {
 	LogFilePath string
 	// LogFileMaxSize is the default limit used for rotating the log file
 	LogFileMaxSize uint64
+	// WebhookURL is the endpoint events are forwarded to if using the
+	// webhook logger
+	WebhookURL string
 }
 
 // Eventer is the interface for journald or file event logging
@@ -97,6 +107,9 @@ type ReadOptions struct {
 	Stream bool
 	// Until reads "until" the given time
 	Until string
+	// AfterCursor reads the events after the event with the given cursor,
+	// it takes precedence over Since
+	AfterCursor string
 }
 
 // Type of event that occurred (container, volume, image, pod, etc)
@@ -116,6 +129,8 @@ const (
 	Network Type = "network"
 	// Pod - event is related to pods
 	Pod Type = "pod"
+	// Secret - event is related to secrets
+	Secret Type = "secret"
 	// System - event is related to Podman whole and not to any specific
 	// container/pod/image/volume
 	System Type = "system"
@@ -160,6 +175,9 @@ const (
 	Kill Status = "kill"
 	// LoadFromArchive ...
 	LoadFromArchive Status = "loadfromarchive"
+	// LogThrottled indicates that log output of a container was dropped
+	// because it exceeded the log rate limit or quota
+	LogThrottled Status = "log-throttled"
 	// Mount ...
 	Mount Status = "mount"
 	// NetworkConnect
@@ -206,6 +224,8 @@ const (
 	Unpause Status = "unpause"
 	// Untag ...
 	Untag Status = "untag"
+	// Update indicates that the configuration of a container was updated
+	Update Status = "update"
 )
 
 // EventFilter for filtering events
@@ -24,6 +24,8 @@ func (et EventerType) String() string {
 		return "memory"
 	case Null:
 		return "none"
+	case Webhook:
+		return "webhook"
 	default:
 		return "invalid"
 	}
@@ -40,6 +42,8 @@ func IsValidEventer(eventer string) bool {
 		return true
 	case Null.String():
 		return true
+	case Webhook.String():
+		return true
 	default:
 		return false
 	}
@@ -103,6 +107,8 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 		} else {
 			humanFormat = fmt.Sprintf("%s %s %s", e.Time, e.Type, e.Status)
 		}
+	case Secret:
+		humanFormat = fmt.Sprintf("%s %s %s %s %s", e.Time, e.Type, e.Status, id, e.Name)
 	case Volume, Machine:
 		humanFormat = fmt.Sprintf("%s %s %s %s", e.Time, e.Type, e.Status, e.Name)
 	}
@@ -142,6 +148,8 @@ func StringToType(name string) (Type, error) {
 		return Network, nil
 	case Pod.String():
 		return Pod, nil
+	case Secret.String():
+		return Secret, nil
 	case System.String():
 		return System, nil
 	case Volume.String():
@@ -189,6 +197,8 @@ func StringToStatus(name string) (Status, error) {
 		return Kill, nil
 	case LoadFromArchive.String():
 		return LoadFromArchive, nil
+	case LogThrottled.String():
+		return LogThrottled, nil
 	case Mount.String():
 		return Mount, nil
 	case NetworkConnect.String():
@@ -233,6 +243,8 @@ func StringToStatus(name string) (Status, error) {
 		return Unpause, nil
 	case Untag.String():
 		return Untag, nil
+	case Update.String():
+		return Update, nil
 	}
 	return "", fmt.Errorf("unknown event status %q", name)
 }
@@ -13,6 +13,8 @@ func NewEventer(options EventerOptions) (Eventer, error) {
 	switch strings.ToUpper(options.EventerType) {
 	case strings.ToUpper(LogFile.String()):
 		return EventLogFile{options}, nil
+	case strings.ToUpper(Webhook.String()):
+		return newWebhookEventer(options)
 	case strings.ToUpper(Null.String()):
 		return newNullEventer(), nil
 	case strings.ToUpper(Memory.String()):
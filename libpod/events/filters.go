@@ -2,6 +2,9 @@ package events
 
 import (
 	"fmt"
+	"net"
+	"os/user"
+	"strconv"
 	"strings"
 	"time"
 
@@ -47,6 +50,27 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 			}
 			return strings.HasPrefix(e.ID, filterValue)
 		}, nil
+	case "SECRET":
+		return func(e *Event) bool {
+			if e.Type != Secret {
+				return false
+			}
+			if e.Name == filterValue {
+				return true
+			}
+			return strings.HasPrefix(e.ID, filterValue)
+		}, nil
+	case "DIGEST":
+		return func(e *Event) bool {
+			digest := e.Attributes[DigestAttribute]
+			return digest != "" && (digest == filterValue || strings.HasPrefix(strings.TrimPrefix(digest, "sha256:"), filterValue))
+		}, nil
+	case "REGISTRY":
+		return func(e *Event) bool {
+			return e.Attributes[RegistryAttribute] == filterValue
+		}, nil
+	case "CALLER":
+		return generateCallerFilter(filterValue), nil
 	case "VOLUME":
 		return func(e *Event) bool {
 			if e.Type != Volume {
@@ -81,6 +105,31 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 	return nil, fmt.Errorf("%s is an invalid filter", filter)
 }
 
+// generateCallerFilter matches events caused by the user with the given name
+// or UID or by the API client with the given address.
+func generateCallerFilter(filterValue string) func(e *Event) bool {
+	uid := filterValue
+	if _, err := strconv.Atoi(filterValue); err != nil {
+		if u, err := user.Lookup(filterValue); err == nil {
+			uid = u.Uid
+		}
+	}
+	return func(e *Event) bool {
+		if caller, ok := e.Attributes[CallerUIDAttribute]; ok && caller == uid {
+			return true
+		}
+		address := e.Attributes[CallerAddressAttribute]
+		if address == "" {
+			return false
+		}
+		if address == filterValue {
+			return true
+		}
+		host, _, err := net.SplitHostPort(address)
+		return err == nil && host == filterValue
+	}
+}
+
 func generateEventSinceOption(timeSince time.Time) func(e *Event) bool {
 	return func(e *Event) bool {
 		return e.Time.After(timeSince)
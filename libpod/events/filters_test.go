@@ -0,0 +1,58 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventAttributeFilters(t *testing.T) {
+	pull := &Event{Type: Image, Status: Pull, ID: "abc", Name: "quay.io/libpod/alpine:latest"}
+	pull.SetAttribute(DigestAttribute, "sha256:634a8f35b5f16dcf")
+	pull.SetAttribute(RegistryAttribute, "quay.io")
+	pull.SetCaller(Caller{UID: 1000, PID: 42})
+
+	remote := &Event{Type: Secret, Status: Create, ID: "0123", Name: "token"}
+	remote.SetCaller(Caller{UID: -1, Address: "192.168.1.2:43210"})
+
+	tests := []struct {
+		filter string
+		want   []*Event
+	}{
+		{filter: "digest=sha256:634a8f35b5f16dcf", want: []*Event{pull}},
+		{filter: "digest=634a8f", want: []*Event{pull}},
+		{filter: "digest=sha256:634a", want: nil},
+		{filter: "registry=quay.io", want: []*Event{pull}},
+		{filter: "registry=docker.io", want: nil},
+		{filter: "caller=1000", want: []*Event{pull}},
+		{filter: "caller=192.168.1.2", want: []*Event{remote}},
+		{filter: "caller=192.168.1.2:43210", want: []*Event{remote}},
+		{filter: "secret=token", want: []*Event{remote}},
+		{filter: "secret=01", want: []*Event{remote}},
+		{filter: "type=secret", want: []*Event{remote}},
+		{filter: "label=callerPID=42", want: []*Event{pull}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filterMap, err := generateEventFilters([]string{tt.filter}, "", "")
+			require.NoError(t, err)
+			var got []*Event
+			for _, e := range []*Event{pull, remote} {
+				if applyFilters(e, filterMap) {
+					got = append(got, e)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetCaller(t *testing.T) {
+	e := &Event{}
+	e.SetCaller(Caller{UID: -1})
+	assert.Empty(t, e.Attributes)
+
+	e.SetCaller(Caller{UID: 0, PID: 1})
+	assert.Equal(t, map[string]string{CallerUIDAttribute: "0", CallerPIDAttribute: "1"}, e.Attributes)
+}
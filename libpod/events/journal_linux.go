@@ -53,15 +53,6 @@ func (e EventJournalD) Write(ee Event) error {
 		if ee.PodID != "" {
 			m["PODMAN_POD_ID"] = ee.PodID
 		}
-		// If we have container labels, we need to convert them to a string so they
-		// can be recorded with the event
-		if len(ee.Details.Attributes) > 0 {
-			b, err := json.Marshal(ee.Details.Attributes)
-			if err != nil {
-				return err
-			}
-			m["PODMAN_LABELS"] = string(b)
-		}
 		m["PODMAN_HEALTH_STATUS"] = ee.HealthStatus
 
 		if len(ee.Details.ContainerInspectData) > 0 {
@@ -70,10 +61,23 @@ func (e EventJournalD) Write(ee Event) error {
 	case Network:
 		m["PODMAN_ID"] = ee.ID
 		m["PODMAN_NETWORK_NAME"] = ee.Network
+	case Secret:
+		m["PODMAN_NAME"] = ee.Name
+		m["PODMAN_ID"] = ee.ID
 	case Volume:
 		m["PODMAN_NAME"] = ee.Name
 	}
 
+	// If we have container labels or other attributes, we need to convert
+	// them to a string so they can be recorded with the event
+	if len(ee.Details.Attributes) > 0 {
+		b, err := json.Marshal(ee.Details.Attributes)
+		if err != nil {
+			return err
+		}
+		m["PODMAN_LABELS"] = string(b)
+	}
+
 	// starting with commit 7e6e267329 we set LogLevel=notice for the systemd healthcheck unit
 	// This so it doesn't log the started/stopped unit messages al the time which spam the
 	// journal if a small interval is used. That however broke the healthcheck event as it no
@@ -129,7 +133,11 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) error {
 		return fmt.Errorf("failed to add _UID journal filter for event log: %w", err)
 	}
 
-	if len(options.Since) == 0 && len(options.Until) == 0 && options.Stream {
+	if options.AfterCursor != "" {
+		if err := j.SeekCursor(options.AfterCursor); err != nil {
+			return fmt.Errorf("failed to seek journal cursor %q: %w", options.AfterCursor, err)
+		}
+	} else if len(options.Since) == 0 && len(options.Until) == 0 && options.Stream {
 		if err := j.SeekTail(); err != nil {
 			return fmt.Errorf("failed to seek end of journal: %w", err)
 		}
@@ -159,6 +167,11 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) error {
 		if entry == nil {
 			return nil
 		}
+		// The first entry after seeking a cursor is the entry of the
+		// cursor itself.
+		if options.AfterCursor != "" && entry.Cursor == options.AfterCursor {
+			continue
+		}
 
 		newEvent, err := newEventFromJournalEntry(entry)
 		if err != nil {
@@ -170,6 +183,7 @@ func (e EventJournalD) Read(ctx context.Context, options ReadOptions) error {
 			}
 			continue
 		}
+		newEvent.Cursor = entry.Cursor
 		if applyFilters(newEvent, filterMap) {
 			options.EventChannel <- newEvent
 		}
@@ -208,27 +222,28 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) {
 				newEvent.ContainerExitCode = &intCode
 			}
 		}
-
-		// we need to check for the presence of labels recorded to a container event
-		if stringLabels, ok := entry.Fields["PODMAN_LABELS"]; ok && len(stringLabels) > 0 {
-			labels := make(map[string]string, 0)
-			if err := json.Unmarshal([]byte(stringLabels), &labels); err != nil {
-				return nil, err
-			}
-
-			// if we have labels, add them to the event
-			if len(labels) > 0 {
-				newEvent.Attributes = labels
-			}
-		}
 		newEvent.HealthStatus = entry.Fields["PODMAN_HEALTH_STATUS"]
 		newEvent.Details.ContainerInspectData = entry.Fields["PODMAN_CONTAINER_INSPECT_DATA"]
 	case Network:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 		newEvent.Network = entry.Fields["PODMAN_NETWORK_NAME"]
-	case Image:
+	case Image, Secret:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 	}
+
+	// we need to check for the presence of labels or other attributes
+	// recorded to an event
+	if stringLabels, ok := entry.Fields["PODMAN_LABELS"]; ok && len(stringLabels) > 0 {
+		labels := make(map[string]string, 0)
+		if err := json.Unmarshal([]byte(stringLabels), &labels); err != nil {
+			return nil, err
+		}
+
+		// if we have labels, add them to the event
+		if len(labels) > 0 {
+			newEvent.Attributes = labels
+		}
+	}
 	return &newEvent, nil
 }
 
@@ -5,15 +5,19 @@ package events
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/containers/podman/v4/pkg/util"
+	"github.com/containers/storage/pkg/ioutils"
 	"github.com/containers/storage/pkg/lockfile"
 	"github.com/nxadm/tail"
 	"github.com/sirupsen/logrus"
@@ -43,6 +47,11 @@ func newLogFileEventer(options EventerOptions) (*EventLogFile, error) {
 
 // Writes to the log file
 func (e EventLogFile) Write(ee Event) error {
+	return e.writeEvent(&ee)
+}
+
+// writeEvent writes the event to the log file and sets its cursor.
+func (e EventLogFile) writeEvent(ee *Event) error {
 	// We need to lock events file
 	lock, err := lockfile.GetLockFile(e.options.LogFilePath + ".lock")
 	if err != nil {
@@ -51,6 +60,12 @@ func (e EventLogFile) Write(ee Event) error {
 	lock.Lock()
 	defer lock.Unlock()
 
+	cursor, err := e.nextCursor()
+	if err != nil {
+		return err
+	}
+	ee.Cursor = strconv.FormatUint(cursor, 10)
+
 	eventJSONString, err := ee.ToJSONString()
 	if err != nil {
 		return err
@@ -63,6 +78,79 @@ func (e EventLogFile) Write(ee Event) error {
 	return e.writeString(eventJSONString)
 }
 
+// cursorPath returns the path of the file storing the cursor of the last event
+// written to the log file.
+func (e EventLogFile) cursorPath() string {
+	return e.options.LogFilePath + ".cursor"
+}
+
+// nextCursor returns the cursor of the next event written to the log file.
+// Cursors are sequence numbers which keep increasing across log file
+// rotations.  The caller must hold the lock of the log file.
+func (e EventLogFile) nextCursor() (uint64, error) {
+	var last uint64
+	content, err := os.ReadFile(e.cursorPath())
+	if err == nil {
+		last, err = strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	}
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logrus.Warnf("Reading events cursor file %s, continuing after the events in the log file: %v", e.cursorPath(), err)
+		}
+		// Continue after the events already in the log file.
+		last, err = e.lastCursor()
+		if err != nil {
+			return 0, err
+		}
+	}
+	next := last + 1
+	if err := ioutils.AtomicWriteFile(e.cursorPath(), []byte(strconv.FormatUint(next, 10)), 0600); err != nil {
+		return 0, fmt.Errorf("writing events cursor file: %w", err)
+	}
+	return next, nil
+}
+
+// lastCursor returns the highest cursor of the events in the log file.
+func (e EventLogFile) lastCursor() (uint64, error) {
+	f, err := os.Open(e.options.LogFilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	var last uint64
+	reader := bufio.NewReader(f)
+	for {
+		line, readErr := reader.ReadString('\n')
+		var event struct {
+			Cursor string `json:"cursor"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err == nil && event.Cursor != "" {
+			if cursor, err := strconv.ParseUint(event.Cursor, 10, 64); err == nil && cursor > last {
+				last = cursor
+			}
+		}
+		if readErr != nil {
+			if errors.Is(readErr, io.EOF) {
+				return last, nil
+			}
+			return 0, readErr
+		}
+	}
+}
+
+// parseCursor parses the cursor of an event in the log file.
+func parseCursor(cursor string) (uint64, error) {
+	c, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid events cursor %q, the file events backend uses numbers", cursor)
+	}
+	return c, nil
+}
+
 func (e EventLogFile) writeString(s string) error {
 	f, err := os.OpenFile(e.options.LogFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0700)
 	if err != nil {
@@ -78,7 +166,7 @@ func writeToFile(s string, f *os.File) error {
 
 func (e EventLogFile) getTail(options ReadOptions) (*tail.Tail, error) {
 	seek := tail.SeekInfo{Offset: 0, Whence: io.SeekEnd}
-	if options.FromStart || !options.Stream {
+	if options.FromStart || !options.Stream || options.AfterCursor != "" {
 		seek.Whence = 0
 	}
 	stream := options.Stream
@@ -113,6 +201,13 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse event filters: %w", err)
 	}
+	var afterCursor uint64
+	if options.AfterCursor != "" {
+		afterCursor, err = parseCursor(options.AfterCursor)
+		if err != nil {
+			return err
+		}
+	}
 	t, err := e.getTail(options)
 	if err != nil {
 		return err
@@ -194,6 +289,14 @@ func (e EventLogFile) Read(ctx context.Context, options ReadOptions) error {
 		if skipRotate {
 			continue
 		}
+		if options.AfterCursor != "" {
+			// Events written before cursors were introduced
+			// have none and are skipped as well.
+			cursor, err := parseCursor(event.Cursor)
+			if err != nil || cursor <= afterCursor {
+				continue
+			}
+		}
 		if applyFilters(event, filterMap) {
 			options.EventChannel <- event
 		}
@@ -1,10 +1,14 @@
 package events
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -135,3 +139,54 @@ func TestRenameLog(t *testing.T) {
 	require.NoError(t, os.Remove(target.Name()))
 	require.Equal(t, beforeRename, afterRename)
 }
+
+func TestLogFileCursor(t *testing.T) {
+	dir := t.TempDir()
+	eventer, err := newLogFileEventer(EventerOptions{LogFilePath: filepath.Join(dir, "events.log"), LogFileMaxSize: 2000})
+	require.NoError(t, err)
+
+	write := func(n int) {
+		for i := 0; i < n; i++ {
+			e := NewEvent(Start)
+			e.Type = Container
+			e.Name = fmt.Sprintf("ctr%d", i)
+			require.NoError(t, eventer.Write(e))
+		}
+	}
+	read := func(afterCursor string) []*Event {
+		eventChannel := make(chan *Event)
+		errChannel := make(chan error, 1)
+		go func() {
+			errChannel <- eventer.Read(context.Background(), ReadOptions{EventChannel: eventChannel, FromStart: true, AfterCursor: afterCursor})
+		}()
+		var read []*Event
+		for e := range eventChannel {
+			read = append(read, e)
+		}
+		require.NoError(t, <-errChannel)
+		return read
+	}
+
+	write(3)
+	all := read("")
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"1", "2", "3"}, []string{all[0].Cursor, all[1].Cursor, all[2].Cursor})
+
+	after := read("2")
+	require.Len(t, after, 1)
+	assert.Equal(t, "ctr2", after[0].Name)
+
+	// Cursors keep increasing when the log file is rotated and when the
+	// cursor file is lost.
+	write(30)
+	require.NoError(t, os.Remove(eventer.cursorPath()))
+	write(1)
+	all = read("")
+	last := all[len(all)-1]
+	assert.Equal(t, "34", last.Cursor)
+	assert.Empty(t, read("34"))
+
+	eventChannel := make(chan *Event)
+	err = eventer.Read(context.Background(), ReadOptions{EventChannel: eventChannel, AfterCursor: "s=abc"})
+	assert.Error(t, err)
+}
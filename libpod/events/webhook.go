@@ -0,0 +1,226 @@
+//go:build linux || freebsd
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookQueueSize is the number of events buffered for delivery, the
+	// oldest event is dropped when the queue is full
+	webhookQueueSize = 1024
+	// webhookAttempts is the number of times delivery of an event is tried
+	webhookAttempts = 3
+	// webhookRetryDelay is the delay before the first retry, it doubles
+	// with every further attempt
+	webhookRetryDelay = 100 * time.Millisecond
+	// webhookTimeout bounds a single delivery attempt
+	webhookTimeout = 5 * time.Second
+	// webhookFlushTimeout bounds the time Close waits for queued events, it
+	// is kept short as every podman process closes its eventer on exit
+	webhookFlushTimeout = time.Second
+)
+
+// EventWebhook is the structure for event forwarding. Events are written to
+// the log file, so they can still be read, and are forwarded as JSON to an
+// HTTP endpoint or a Unix socket from a bounded queue in the background.
+type EventWebhook struct {
+	EventLogFile
+	url    *url.URL
+	client *http.Client
+	conn   net.Conn // socket connection, only used by the delivery goroutine
+
+	mux    sync.Mutex // protect closed and sending on queue
+	closed bool
+	queue  chan string
+	done   chan struct{}
+	// failing is set while the endpoint is unreachable, Close does not
+	// wait for queued events then
+	failing atomic.Bool
+}
+
+// newWebhookEventer creates a new EventWebhook eventer and starts delivering events
+func newWebhookEventer(options EventerOptions) (*EventWebhook, error) {
+	if options.WebhookURL == "" {
+		return nil, errors.New("the webhook events backend requires --events-webhook-url or PODMAN_EVENTS_WEBHOOK_URL to be set")
+	}
+	u, err := url.Parse(options.WebhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing events webhook URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "unix", "unixpacket":
+		if u.Path == "" {
+			return nil, fmt.Errorf("events webhook URL %q does not specify a socket path", u.Redacted())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported events webhook URL scheme %q, must be one of http, https, unix or unixpacket", u.Scheme)
+	}
+
+	logFile, err := newLogFileEventer(options)
+	if err != nil {
+		return nil, err
+	}
+	w := &EventWebhook{
+		EventLogFile: *logFile,
+		url:          u,
+		client:       &http.Client{Timeout: webhookTimeout},
+		queue:        make(chan string, webhookQueueSize),
+		done:         make(chan struct{}),
+	}
+	go w.deliver()
+	return w, nil
+}
+
+// Write stores the event in the log file and queues it for delivery
+func (w *EventWebhook) Write(ee Event) error {
+	if err := w.EventLogFile.writeEvent(&ee); err != nil {
+		return err
+	}
+	data, err := ee.ToJSONString()
+	if err != nil {
+		return err
+	}
+
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.closed {
+		logrus.Debugf("Events webhook is closed, not forwarding %s event", ee.Status)
+		return nil
+	}
+	for {
+		select {
+		case w.queue <- data:
+			return nil
+		default:
+		}
+		// The queue is full, drop the oldest event to make room.
+		select {
+		case <-w.queue:
+			logrus.Warnf("Events webhook queue is full, dropping the oldest event")
+		default:
+		}
+	}
+}
+
+// Close stops accepting events and makes a best-effort attempt to deliver the
+// queued events.  It waits at most webhookFlushTimeout and returns right away
+// when the endpoint is failing; undelivered events remain in the log file.
+func (w *EventWebhook) Close() error {
+	w.mux.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mux.Unlock()
+
+	if w.failing.Load() {
+		select {
+		case <-w.done:
+			return nil
+		default:
+			return fmt.Errorf("%s is unreachable, dropping %d queued events", w.url.Redacted(), len(w.queue))
+		}
+	}
+	select {
+	case <-w.done:
+		return nil
+	case <-time.After(webhookFlushTimeout):
+		return fmt.Errorf("timed out forwarding %d queued events to %s", len(w.queue), w.url.Redacted())
+	}
+}
+
+// isClosed returns whether Close has been called
+func (w *EventWebhook) isClosed() bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.closed
+}
+
+// String returns a string representation of the eventer type
+func (w *EventWebhook) String() string {
+	return Webhook.String()
+}
+
+// deliver forwards queued events until the queue is closed and drained
+func (w *EventWebhook) deliver() {
+	defer close(w.done)
+	defer func() {
+		if w.conn != nil {
+			w.conn.Close()
+		}
+	}()
+
+	for data := range w.queue {
+		delay := webhookRetryDelay
+		for attempt := 1; ; attempt++ {
+			err := w.send(data)
+			if err == nil {
+				w.failing.Store(false)
+				break
+			}
+			// Do not delay the exit of the process with retries.
+			if attempt == webhookAttempts || w.isClosed() {
+				w.failing.Store(true)
+				logrus.Errorf("Unable to forward event to %s after %d attempts: %v", w.url.Redacted(), attempt, err)
+				break
+			}
+			logrus.Debugf("Forwarding event to %s failed, retrying in %s: %v", w.url.Redacted(), delay, err)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+}
+
+// send delivers a single JSON encoded event
+func (w *EventWebhook) send(data string) error {
+	if w.url.Scheme == "http" || w.url.Scheme == "https" {
+		resp, err := w.client.Post(w.url.String(), "application/json", strings.NewReader(data))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected response status %q", resp.Status)
+		}
+		return nil
+	}
+
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.url.Scheme, w.url.Path, webhookTimeout)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+	// Stream sockets get newline delimited JSON, SEQPACKET sockets preserve
+	// message boundaries so every event is sent as one message.
+	if w.url.Scheme == "unix" {
+		data += "\n"
+	}
+	err := w.conn.SetWriteDeadline(time.Now().Add(webhookTimeout))
+	if err == nil {
+		_, err = w.conn.Write([]byte(data))
+	}
+	if err != nil {
+		// Reconnect on the next attempt.
+		w.conn.Close()
+		w.conn = nil
+		return err
+	}
+	return nil
+}
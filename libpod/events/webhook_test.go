@@ -0,0 +1,179 @@
+//go:build linux || freebsd
+
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookEventer(t *testing.T, webhookURL string) *EventWebhook {
+	eventer, err := newWebhookEventer(EventerOptions{
+		EventerType: Webhook.String(),
+		LogFilePath: filepath.Join(t.TempDir(), "events.log"),
+		WebhookURL:  webhookURL,
+	})
+	require.NoError(t, err)
+	return eventer
+}
+
+func testEvent(status Status) Event {
+	e := NewEvent(status)
+	e.Type = Container
+	e.ID = "abc"
+	e.HealthStatus = "unhealthy"
+	e.Details = Details{ID: "abc", Attributes: map[string]string{"app": "web"}}
+	return e
+}
+
+func TestWebhookEventerHTTP(t *testing.T) {
+	var (
+		mux      sync.Mutex
+		received []Event
+		failures = 1
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		// fail the first request to exercise the retry
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var e Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+		received = append(received, e)
+	}))
+	defer server.Close()
+
+	eventer := newTestWebhookEventer(t, server.URL)
+	require.NoError(t, eventer.Write(testEvent(HealthStatus)))
+	require.NoError(t, eventer.Write(testEvent(Exited)))
+	// events are not retried once the eventer is closed, so wait for the
+	// retry before closing it
+	require.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(received) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, eventer.Close())
+	// writing after close still logs the event but does not forward it
+	require.NoError(t, eventer.Write(testEvent(Remove)))
+
+	mux.Lock()
+	defer mux.Unlock()
+	require.Len(t, received, 2)
+	require.Equal(t, HealthStatus, received[0].Status)
+	require.Equal(t, "unhealthy", received[0].HealthStatus)
+	require.Equal(t, "web", received[0].Attributes["app"])
+	require.Equal(t, Exited, received[1].Status)
+}
+
+func TestWebhookEventerUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	eventer := newTestWebhookEventer(t, "unix://"+path)
+	require.NoError(t, eventer.Write(testEvent(Start)))
+	require.NoError(t, eventer.Write(testEvent(Stop)))
+	require.NoError(t, eventer.Close())
+
+	var statuses []Status
+	for line := range lines {
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		statuses = append(statuses, e.Status)
+	}
+	require.Equal(t, []Status{Start, Stop}, statuses)
+}
+
+func TestWebhookEventerUnixPacketSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+	listener, err := net.Listen("unixpacket", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	messages := make(chan []byte, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 64*1024)
+		for {
+			n, err := conn.Read(buf)
+			if err == io.EOF || n == 0 {
+				close(messages)
+				return
+			}
+			messages <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+
+	eventer := newTestWebhookEventer(t, "unixpacket://"+path)
+	require.NoError(t, eventer.Write(testEvent(Start)))
+	require.NoError(t, eventer.Write(testEvent(Stop)))
+	require.NoError(t, eventer.Close())
+
+	var statuses []Status
+	for msg := range messages {
+		var e Event
+		require.NoError(t, json.Unmarshal(msg, &e))
+		statuses = append(statuses, e.Status)
+	}
+	require.Equal(t, []Status{Start, Stop}, statuses)
+}
+
+func TestWebhookEventerUnreachable(t *testing.T) {
+	eventer := newTestWebhookEventer(t, "unix://"+filepath.Join(t.TempDir(), "missing.sock"))
+	require.Equal(t, "webhook", eventer.String())
+	for i := 0; i < 10; i++ {
+		require.NoError(t, eventer.Write(testEvent(Start)))
+	}
+
+	// wait until the first event failed, Close must not wait for the rest
+	require.Eventually(t, eventer.failing.Load, 5*time.Second, 10*time.Millisecond)
+	start := time.Now()
+	require.Error(t, eventer.Close())
+	require.Less(t, time.Since(start), webhookFlushTimeout)
+}
+
+func TestWebhookEventerInvalidURL(t *testing.T) {
+	for _, webhookURL := range []string{"", "ftp://example.com", "unix://"} {
+		_, err := newWebhookEventer(EventerOptions{
+			EventerType: Webhook.String(),
+			LogFilePath: filepath.Join(t.TempDir(), "events.log"),
+			WebhookURL:  webhookURL,
+		})
+		require.Error(t, err, webhookURL)
+	}
+}
@@ -0,0 +1,57 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventCallers(t *testing.T) {
+	var callers eventCallers
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(offset int) time.Time {
+		return start.Add(time.Duration(offset) * time.Second)
+	}
+
+	// Without any request in flight, podman itself is the caller.
+	assert.Equal(t, localEventCaller(), callers.callerAt(start))
+
+	doneA := callers.track(events.Caller{UID: 1000, PID: 10}, at(0))
+	doneB := callers.track(events.Caller{UID: 1000, PID: 20}, at(2))
+	doneA(at(3))
+	assert.Equal(t, events.Caller{UID: 1000, PID: 20, Inferred: true}, callers.callerAt(at(4)))
+	doneB(at(5))
+
+	assert.Equal(t, events.Caller{UID: 1000, PID: 10, Inferred: true}, callers.callerAt(at(1)))
+	// Both requests were in flight, they only have the UID in common.
+	assert.Equal(t, events.Caller{UID: 1000, Inferred: true}, callers.callerAt(at(3)))
+	assert.Equal(t, events.Caller{UID: 1000, PID: 20, Inferred: true}, callers.callerAt(at(4)))
+	assert.Equal(t, localEventCaller(), callers.callerAt(at(6)))
+
+	// Requests which finished a while ago are forgotten.
+	done := callers.track(events.Caller{UID: 0}, at(60))
+	done(at(61))
+	assert.Len(t, callers.spans, 1)
+}
+
+func TestImageEventRegistry(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "quay.io/libpod/alpine:latest", want: "quay.io"},
+		{name: "docker://registry.example.com:5000/app@sha256:634a8f35b5f16dcf4aaa0822adc0b1964bb786fca12f6831de8ddc45e5986a00", want: "registry.example.com:5000"},
+		{name: "localhost/app", want: "localhost"},
+		{name: "dir:/tmp/app", want: ""},
+		{name: "docker-archive:/tmp/app.tar", want: ""},
+		// Short names need the image to be resolved.
+		{name: "alpine", want: ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, imageEventRegistry(tt.name, nil), tt.name)
+	}
+}
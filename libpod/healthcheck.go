@@ -4,11 +4,14 @@ package libpod
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -186,8 +189,14 @@ func (c *Container) processHealthCheckStatus(status string) error {
 		return nil
 	}
 
+	if c.config.HealthCheckOnFailureAction != define.HealthCheckOnFailureActionNone && len(c.config.HealthCheckOnFailureCommand) > 0 {
+		if err := c.runHealthCheckOnFailureCommand(); err != nil {
+			logrus.Errorf("Running on-failure command of container %s: %v", c.ID(), err)
+		}
+	}
+
 	switch c.config.HealthCheckOnFailureAction {
-	case define.HealthCheckOnFailureActionNone: // Nothing to do
+	case define.HealthCheckOnFailureActionNone, define.HealthCheckOnFailureActionCommand: // Nothing (more) to do
 
 	case define.HealthCheckOnFailureActionKill:
 		if err := c.Kill(uint(unix.SIGKILL)); err != nil {
@@ -215,6 +224,107 @@ func (c *Container) processHealthCheckStatus(status string) error {
 	return nil
 }
 
+// runHealthCheckOnFailureCommand runs the on-failure command once, when the
+// failing streak reaches the number of retries, and records the result in
+// the healthcheck log.
+func (c *Container) runHealthCheckOnFailureCommand() error {
+	c.lock.Lock()
+	healthCheck, err := c.getHealthCheckLog()
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	threshold := int(c.HealthCheckConfig().Retries)
+	if threshold < 1 {
+		threshold = 1
+	}
+	if healthCheck.FailingStreak != threshold {
+		return nil
+	}
+
+	lastOutput := ""
+	if len(healthCheck.Log) > 0 {
+		lastOutput = healthCheck.Log[len(healthCheck.Log)-1].Output
+	}
+	env := map[string]string{
+		"PODMAN_CONTAINER_ID":          c.ID(),
+		"PODMAN_CONTAINER_NAME":        c.Name(),
+		"PODMAN_HEALTH_FAILING_STREAK": strconv.Itoa(healthCheck.FailingStreak),
+		"PODMAN_HEALTH_OUTPUT":         lastOutput,
+	}
+
+	command := c.config.HealthCheckOnFailureCommand
+	logrus.Debugf("Running on-failure command %v for container %s", command, c.ID())
+	timeStart := time.Now()
+	var (
+		exitCode int
+		output   bytes.Buffer
+	)
+	if c.config.HealthCheckOnFailureCommandInContainer {
+		streams := &define.AttachStreams{
+			OutputStream: &output,
+			ErrorStream:  &output,
+			AttachOutput: true,
+			AttachError:  true,
+		}
+		config := new(ExecConfig)
+		config.Command = command
+		config.Environment = env
+		// Bound the command by the timeout of the health check like
+		// commands run on the host.
+		exitCode, err = c.execWithTimeout(config, streams, c.HealthCheckConfig().Timeout)
+	} else {
+		ctx := context.Background()
+		if timeout := c.HealthCheckConfig().Timeout; timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+		err = cmd.Run()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+			err = nil
+		}
+	}
+	if err != nil {
+		exitCode = 125
+		output.WriteString(err.Error())
+	}
+	timeEnd := time.Now()
+
+	log := strings.TrimSpace(output.String())
+	if len(log) > MaxHealthCheckLogLength {
+		log = log[:MaxHealthCheckLogLength]
+	}
+	if exitCode != 0 {
+		logrus.Warnf("On-failure command of container %s exited with code %d: %s", c.ID(), exitCode, log)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	healthCheck, err = c.getHealthCheckLog()
+	if err != nil {
+		return err
+	}
+	healthCheck.OnFailureLog = append(healthCheck.OnFailureLog, newHealthCheckLog(timeStart, timeEnd, exitCode, log))
+	if len(healthCheck.OnFailureLog) > MaxHealthCheckNumberLogs {
+		healthCheck.OnFailureLog = healthCheck.OnFailureLog[1:]
+	}
+	newResults, err := json.Marshal(healthCheck)
+	if err != nil {
+		return fmt.Errorf("unable to marshall healthchecks for writing on-failure result: %w", err)
+	}
+	return os.WriteFile(c.healthCheckLogPath(), newResults, 0700)
+}
+
 func checkHealthCheckCanBeRun(c *Container) (define.HealthCheckStatus, error) {
 	cstate, err := c.State()
 	if err != nil {
@@ -414,7 +524,13 @@ func (c *Container) updateHealthCheckLog(hcl define.HealthCheckLog, inStartPerio
 	if err != nil {
 		return "", fmt.Errorf("unable to marshall healthchecks for writing: %w", err)
 	}
-	return healthCheck.Status, os.WriteFile(c.healthCheckLogPath(), newResults, 0700)
+	if err := os.WriteFile(c.healthCheckLogPath(), newResults, 0700); err != nil {
+		return healthCheck.Status, err
+	}
+	if err := c.appendHealthCheckHistory(hcl, healthCheck.Status, isStartup); err != nil {
+		logrus.Errorf("Recording healthcheck history of container %s: %v", c.ID(), err)
+	}
+	return healthCheck.Status, nil
 }
 
 // HealthCheckLogPath returns the path for where the health check log is
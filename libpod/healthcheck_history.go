@@ -0,0 +1,181 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxHealthCheckHistorySize is the size in bytes at which the
+	// healthcheck history file is truncated to its newer half.
+	maxHealthCheckHistorySize = 1024 * 1024
+	// healthCheckHistoryPollInterval is the interval at which the history
+	// file is read again when following it.
+	healthCheckHistoryPollInterval = time.Second
+)
+
+// healthCheckHistoryPath returns the path of the file the results of all
+// probes are appended to.  Unlike the healthcheck log, it is kept across
+// restarts of the container.
+func (c *Container) healthCheckHistoryPath() string {
+	return filepath.Join(filepath.Dir(c.state.RunDir), "healthcheck-history.log")
+}
+
+// appendHealthCheckHistory appends a probe to the healthcheck history.
+// The caller should lock the container before this function is called.
+func (c *Container) appendHealthCheckHistory(hcl define.HealthCheckLog, status string, isStartup bool) error {
+	entry, err := json.Marshal(define.HealthCheckHistoryEntry{
+		HealthCheckLog: hcl,
+		Status:         status,
+		Startup:        isStartup,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal healthcheck history entry: %w", err)
+	}
+
+	path := c.healthCheckHistoryPath()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(entry, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	info, statErr := f.Stat()
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if statErr != nil {
+		return statErr
+	}
+	if info.Size() <= maxHealthCheckHistorySize {
+		return nil
+	}
+
+	// Keep the newer half of the history.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content = content[len(content)/2:]
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		content = content[i+1:]
+	}
+	return os.WriteFile(path, content, 0600)
+}
+
+// readHealthCheckHistory returns all probes in the healthcheck history.
+func (c *Container) readHealthCheckHistory() ([]define.HealthCheckHistoryEntry, error) {
+	f, err := os.Open(c.healthCheckHistoryPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []define.HealthCheckHistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry define.HealthCheckHistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A partially written entry, skip it.
+			logrus.Debugf("Skipping healthcheck history entry of container %s: %v", c.ID(), err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// HealthCheckHistory sends the probes in the healthcheck history selected by
+// options to entries, oldest first.  If options.Follow is set, new probes are
+// sent as they are recorded until the container stops running or ctx is
+// cancelled.  The channel is closed before returning.
+func (c *Container) HealthCheckHistory(ctx context.Context, options define.HealthCheckHistoryOptions, entries chan<- *define.HealthCheckHistoryEntry) error {
+	defer close(entries)
+
+	if !c.HasHealthCheck() {
+		return fmt.Errorf("container %s has no defined healthcheck: %w", c.ID(), define.ErrInvalidArg)
+	}
+	if err := define.ValidateHealthCheckHistoryStatus(options.Status); err != nil {
+		return err
+	}
+
+	var last time.Time
+	send := func() error {
+		history, err := c.readHealthCheckHistory()
+		if err != nil {
+			return fmt.Errorf("reading healthcheck history of container %s: %w", c.ID(), err)
+		}
+		for i := range history {
+			entry := history[i]
+			start, err := time.Parse(time.RFC3339Nano, entry.Start)
+			if err != nil || !start.After(last) {
+				continue
+			}
+			last = start
+			if !options.Since.IsZero() && start.Before(options.Since) {
+				continue
+			}
+			if !options.Until.IsZero() && start.After(options.Until) {
+				continue
+			}
+			if options.Status != "" && entry.Status != options.Status {
+				continue
+			}
+			select {
+			case entries <- &entry:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		return nil
+	}
+
+	if err := send(); err != nil {
+		return err
+	}
+	if !options.Follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(healthCheckHistoryPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		state, err := c.State()
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				return nil
+			}
+			return err
+		}
+		if err := send(); err != nil {
+			return err
+		}
+		if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+			return nil
+		}
+		if !options.Until.IsZero() && time.Now().After(options.Until) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,96 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckHistory(t *testing.T) {
+	lockManager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	ctr, err := getTestCtr1(lockManager)
+	require.NoError(t, err)
+	ctr.state.RunDir = filepath.Join(t.TempDir(), "userdata")
+	ctr.config.HealthCheckConfig = &manifest.Schema2HealthConfig{Test: []string{"CMD", "true"}}
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	statuses := []string{define.HealthCheckStarting, define.HealthCheckHealthy, define.HealthCheckUnhealthy, define.HealthCheckHealthy}
+	for i, status := range statuses {
+		probeStart := start.Add(time.Duration(i) * time.Minute)
+		hcl := newHealthCheckLog(probeStart, probeStart.Add(time.Second), i%2, "output")
+		require.NoError(t, ctr.appendHealthCheckHistory(hcl, status, i == 0))
+	}
+
+	read := func(options define.HealthCheckHistoryOptions) []*define.HealthCheckHistoryEntry {
+		entries := make(chan *define.HealthCheckHistoryEntry)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- ctr.HealthCheckHistory(context.Background(), options, entries)
+		}()
+		var result []*define.HealthCheckHistoryEntry
+		for entry := range entries {
+			result = append(result, entry)
+		}
+		require.NoError(t, <-errChan)
+		return result
+	}
+
+	all := read(define.HealthCheckHistoryOptions{})
+	require.Len(t, all, 4)
+	assert.True(t, all[0].Startup)
+	assert.Equal(t, define.HealthCheckStarting, all[0].Status)
+	assert.Equal(t, 1, all[1].ExitCode)
+
+	healthy := read(define.HealthCheckHistoryOptions{Status: define.HealthCheckHealthy})
+	require.Len(t, healthy, 2)
+	assert.Equal(t, all[1].Start, healthy[0].Start)
+	assert.Equal(t, all[3].Start, healthy[1].Start)
+
+	window := read(define.HealthCheckHistoryOptions{Since: start.Add(time.Minute), Until: start.Add(2 * time.Minute)})
+	require.Len(t, window, 2)
+	assert.Equal(t, define.HealthCheckHealthy, window[0].Status)
+	assert.Equal(t, define.HealthCheckUnhealthy, window[1].Status)
+
+	entries := make(chan *define.HealthCheckHistoryEntry)
+	err = ctr.HealthCheckHistory(context.Background(), define.HealthCheckHistoryOptions{Status: "broken"}, entries)
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+}
+
+func TestHealthCheckHistoryTruncate(t *testing.T) {
+	lockManager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+	ctr, err := getTestCtr1(lockManager)
+	require.NoError(t, err)
+	ctr.state.RunDir = filepath.Join(t.TempDir(), "userdata")
+
+	output := make([]byte, MaxHealthCheckLogLength)
+	for i := range output {
+		output[i] = 'x'
+	}
+	start := time.Now()
+	var last define.HealthCheckLog
+	for i := 0; i < 2*maxHealthCheckHistorySize/MaxHealthCheckLogLength; i++ {
+		last = newHealthCheckLog(start.Add(time.Duration(i)*time.Second), start, 0, string(output))
+		require.NoError(t, ctr.appendHealthCheckHistory(last, define.HealthCheckHealthy, false))
+	}
+
+	info, err := os.Stat(ctr.healthCheckHistoryPath())
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(maxHealthCheckHistorySize))
+
+	history, err := ctr.readHealthCheckHistory()
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+	assert.Equal(t, last.Start, history[len(history)-1].Start)
+}
@@ -40,6 +40,11 @@ func (c *Container) createTimer(interval string, isStartup bool) error {
 	if logrus.IsLevelEnabled(logrus.DebugLevel) {
 		cmd = append(cmd, "--log-level=debug", "--syslog")
 	}
+	// The timer does not inherit the environment, forward the endpoint of
+	// the webhook events backend like the exit command does.
+	if c.runtime.eventsWebhookURL != "" {
+		cmd = append(cmd, "--events-webhook-url", c.runtime.eventsWebhookURL)
+	}
 
 	cmd = append(cmd, "healthcheck", "run", c.ID())
 
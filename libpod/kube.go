@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/containers/common/libnetwork/types"
 	"github.com/containers/common/pkg/config"
+	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/annotations"
 	"github.com/containers/podman/v4/pkg/domain/entities"
@@ -864,6 +866,12 @@ func containerToV1Container(ctx context.Context, c *Container, getService bool)
 		kubeVolumes = append(kubeVolumes, volumes...)
 	}
 
+	if len(c.config.Secrets) > 0 {
+		volumeMounts, volumes := libpodSecretsToKubeVolumeMounts(c.config.Secrets)
+		kubeContainer.VolumeMounts = append(kubeContainer.VolumeMounts, volumeMounts...)
+		kubeVolumes = append(kubeVolumes, volumes...)
+	}
+
 	portmappings, err := c.PortMappings()
 	if err != nil {
 		return kubeContainer, kubeVolumes, nil, annotations, err
@@ -932,7 +940,7 @@ func containerToV1Container(ctx context.Context, c *Container, getService bool)
 	if err != nil {
 		return kubeContainer, kubeVolumes, nil, annotations, err
 	}
-	kubeContainer.Env = envVariables
+	kubeContainer.Env = append(envVariables, libpodEnvSecretsToKubeEnvVars(c.config.EnvSecrets)...)
 
 	kubeContainer.Ports = ports
 	// This should not be applicable
@@ -1078,6 +1086,64 @@ func libpodEnvVarsToKubeEnvVars(envs []string, imageEnvs []string) ([]v1.EnvVar,
 	return envVars, nil
 }
 
+// libpodEnvSecretsToKubeEnvVars converts secrets exposed as environment
+// variables to env vars referencing a kube secret of the same name.  The
+// secret's data is expected under a key named after the secret.
+func libpodEnvSecretsToKubeEnvVars(envSecrets map[string]*secrets.Secret) []v1.EnvVar {
+	envVars := make([]v1.EnvVar, 0, len(envSecrets))
+	for name, secret := range envSecrets {
+		envVars = append(envVars, v1.EnvVar{
+			Name: name,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: secret.Name},
+					Key:                  secret.Name,
+				},
+			},
+		})
+	}
+	sort.Slice(envVars, func(i, j int) bool { return envVars[i].Name < envVars[j].Name })
+	return envVars
+}
+
+// libpodSecretsToKubeVolumeMounts converts secrets mounted into the container
+// to secret volumes referencing a kube secret of the same name.  Every secret
+// is mounted as a single file using the key named after the secret as subPath.
+func libpodSecretsToKubeVolumeMounts(ctrSecrets []*ContainerSecret) ([]v1.VolumeMount, []v1.Volume) {
+	vms := make([]v1.VolumeMount, 0, len(ctrSecrets))
+	vos := make([]v1.Volume, 0, len(ctrSecrets))
+	for _, secret := range ctrSecrets {
+		mountPath := secret.Target
+		if mountPath == "" {
+			mountPath = secret.Name
+		}
+		if !filepath.IsAbs(mountPath) {
+			mountPath = filepath.Join("/run/secrets", mountPath)
+		}
+		name := strings.ToLower(removeUnderscores(secret.Name)) + "-secret"
+		vms = append(vms, v1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath,
+			SubPath:   secret.Name,
+			ReadOnly:  true,
+		})
+		vo := v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secret.Name,
+				},
+			},
+		}
+		if secret.Mode != 0 {
+			mode := int32(secret.Mode)
+			vo.Secret.DefaultMode = &mode
+		}
+		vos = append(vos, vo)
+	}
+	return vms, vos
+}
+
 // libpodMountsToKubeVolumeMounts converts the containers mounts to a struct kube understands
 func libpodMountsToKubeVolumeMounts(c *Container) ([]v1.VolumeMount, []v1.Volume, map[string]string, error) {
 	namedVolumes, mounts := c.SortUserVolumes(c.config.Spec)
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/containers/podman/v4/libpod/logs/reversereader"
@@ -44,6 +45,11 @@ type LogOptions struct {
 	Multi      bool
 	WaitGroup  *sync.WaitGroup
 	UseName    bool
+	// Merge orders the lines of all containers by their timestamps.
+	Merge bool
+	// Prefix is written before every line instead of the container ID
+	// or name.
+	Prefix *template.Template
 }
 
 // LogLine describes the information for each line of a log
@@ -54,10 +60,34 @@ type LogLine struct {
 	Msg          string
 	CID          string
 	CName        string
+	PodName      string
 	ColorID      int64
 }
 
-// GetLogFile returns an hp tail for a container given options
+// LogPrefix is the data available to the prefix template.
+type LogPrefix struct {
+	// ID is the short ID of the container
+	ID string
+	// Name is the name of the container
+	Name string
+	// Pod is the name of the pod of the container
+	Pod string
+}
+
+// ParsePrefix parses a prefix template for LogOptions.Prefix.
+func ParsePrefix(prefix string) (*template.Template, error) {
+	tmpl, err := template.New("prefix").Parse(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("parsing log prefix %q: %w", prefix, err)
+	}
+	return tmpl, nil
+}
+
+// GetLogFile returns an hp tail for a container given options.  If
+// options.Tail is positive, the last lines of the log file, including the
+// ones of its rotated segments, are returned along with the tail.  Otherwise,
+// the rotated segments are not read and the caller reads them with
+// ReadRotatedLog before the lines of the tail.
 func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error) {
 	var (
 		whence  int
@@ -74,6 +104,15 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 			return nil, nil, err
 		}
 	}
+	// Read the rotated segments of the log file if the log file itself
+	// does not have enough lines.
+	if needed := int(options.Tail) - countFullLines(logTail); options.Tail > 0 && needed > 0 {
+		rotated, err := getRotatedLog(path, needed)
+		if err != nil {
+			return nil, nil, err
+		}
+		logTail = append(rotated, logTail...)
+	}
 	seek := tail.SeekInfo{
 		Offset: 0,
 		Whence: whence,
@@ -187,7 +226,17 @@ func (l *LogLine) colorize(prefix string) string {
 // bool is specified.
 func (l *LogLine) String(options *LogOptions) string {
 	var out string
-	if options.Multi {
+	if options.Prefix != nil {
+		cid := l.CID
+		if len(cid) > 12 {
+			cid = cid[:12]
+		}
+		var prefix strings.Builder
+		if err := options.Prefix.Execute(&prefix, LogPrefix{ID: cid, Name: l.CName, Pod: l.PodName}); err != nil {
+			logrus.Errorf("Executing log prefix template: %v", err)
+		}
+		out = prefix.String()
+	} else if options.Multi {
 		if options.UseName {
 			out = l.CName + " "
 		} else {
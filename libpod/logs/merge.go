@@ -0,0 +1,93 @@
+package logs
+
+import (
+	"sort"
+	"time"
+)
+
+// mergeWindow is how long lines are held back after they have been read when
+// following logs, so that lines of other containers with earlier timestamps
+// can still be ordered before them.
+const mergeWindow = time.Second
+
+// mergeEntry is a full log line along with the partial lines preceding it,
+// which are never separated when ordering lines.
+type mergeEntry struct {
+	time     time.Time
+	received time.Time
+	lines    []*LogLine
+}
+
+// logMerger orders the lines of multiple containers by their timestamps.
+type logMerger struct {
+	// partial holds the partial lines of every container which have not
+	// been completed by a full line yet.
+	partial map[string][]*LogLine
+	pending []mergeEntry
+}
+
+func (m *logMerger) add(line *LogLine, received time.Time) {
+	lines := append(m.partial[line.CID], line)
+	if line.Partial() {
+		m.partial[line.CID] = lines
+		return
+	}
+	delete(m.partial, line.CID)
+	m.pending = append(m.pending, mergeEntry{time: lines[0].Time, received: received, lines: lines})
+}
+
+// flush writes the lines received before until in order, all lines if until is
+// zero.  It stops at the first line received later to keep the order.
+func (m *logMerger) flush(until time.Time, write func(*LogLine)) {
+	if until.IsZero() {
+		// Write incomplete partial lines as they are.
+		for cid, lines := range m.partial {
+			m.pending = append(m.pending, mergeEntry{time: lines[0].Time, lines: lines})
+			delete(m.partial, cid)
+		}
+	}
+	sort.SliceStable(m.pending, func(i, j int) bool {
+		return m.pending[i].time.Before(m.pending[j].time)
+	})
+	n := 0
+	for _, entry := range m.pending {
+		if !until.IsZero() && !entry.received.Before(until) {
+			break
+		}
+		for _, line := range entry.lines {
+			write(line)
+		}
+		n++
+	}
+	m.pending = m.pending[n:]
+}
+
+// Merge reads lines of multiple containers until the channel is closed and
+// writes them ordered by their timestamps.  When following logs, lines are
+// written a short while after they have been read, so lines which are read
+// much later than lines of other containers may still be written out of order.
+func Merge(lines <-chan *LogLine, follow bool, write func(*LogLine)) {
+	m := &logMerger{partial: make(map[string][]*LogLine)}
+	if !follow {
+		for line := range lines {
+			m.add(line, time.Time{})
+		}
+		m.flush(time.Time{}, write)
+		return
+	}
+
+	ticker := time.NewTicker(mergeWindow / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				m.flush(time.Time{}, write)
+				return
+			}
+			m.add(line, time.Now())
+		case now := <-ticker.C:
+			m.flush(now.Add(-mergeWindow), write)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	line := func(cid string, offset int, typ, msg string) *LogLine {
+		return &LogLine{CID: cid, Device: "stdout", ParseLogType: typ, Msg: msg, Time: logTime.Add(time.Duration(offset) * time.Second)}
+	}
+
+	lines := make(chan *LogLine, 10)
+	// The lines of each container arrive in order, one container after
+	// the other.
+	lines <- line("a", 1, FullLogType, "a1")
+	lines <- line("a", 3, PartialLogType, "a3-")
+	lines <- line("a", 5, FullLogType, "a3-end")
+	lines <- line("a", 6, PartialLogType, "a6-")
+	lines <- line("b", 2, FullLogType, "b2")
+	lines <- line("b", 4, FullLogType, "b4")
+	close(lines)
+
+	var msgs []string
+	Merge(lines, false, func(l *LogLine) {
+		msgs = append(msgs, l.Msg)
+	})
+	// Partial lines stay in front of the full line they belong to and
+	// unterminated partial lines are written last.
+	assert.Equal(t, []string{"a1", "b2", "a3-", "a3-end", "b4", "a6-"}, msgs)
+}
+
+func TestMergeFlushReceived(t *testing.T) {
+	m := &logMerger{partial: make(map[string][]*LogLine)}
+	start := time.Now()
+	m.add(&LogLine{CID: "a", ParseLogType: FullLogType, Msg: "a2", Time: logTime.Add(2 * time.Second)}, start)
+	m.add(&LogLine{CID: "b", ParseLogType: FullLogType, Msg: "b3", Time: logTime.Add(3 * time.Second)}, start)
+	m.add(&LogLine{CID: "b", ParseLogType: FullLogType, Msg: "b1", Time: logTime.Add(time.Second)}, start.Add(time.Second))
+
+	var msgs []string
+	write := func(l *LogLine) {
+		msgs = append(msgs, l.Msg)
+	}
+	// b1 has the earliest timestamp but was received too recently, so
+	// nothing can be written before it.
+	m.flush(start.Add(time.Millisecond), write)
+	assert.Empty(t, msgs)
+
+	m.flush(start.Add(2*time.Second), write)
+	assert.Equal(t, []string{"b1", "a2", "b3"}, msgs)
+}
+
+func TestLogLinePrefix(t *testing.T) {
+	prefix, err := ParsePrefix("{{.Pod}}/{{.Name}} ")
+	assert.NoError(t, err)
+	l := &LogLine{CID: "0123456789abcdef", CName: "web", PodName: "app", Msg: "hello"}
+	assert.Equal(t, "app/web hello", l.String(&LogOptions{Prefix: prefix}))
+
+	prefix, err = ParsePrefix("{{.ID}}: ")
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789ab: hello", l.String(&LogOptions{Prefix: prefix, Multi: true, UseName: true}))
+
+	_, err = ParsePrefix("{{.ID")
+	assert.Error(t, err)
+}
@@ -0,0 +1,242 @@
+package logs
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// compressedSuffix is appended to the name of compressed rotated log files.
+const compressedSuffix = ".gz"
+
+// RotatedLogPath returns the path of the n-th rotated segment of the log file
+// at path, 1 being the most recent one.
+func RotatedLogPath(path string, n uint, compressed bool) string {
+	rotated := fmt.Sprintf("%s.%d", path, n)
+	if compressed {
+		rotated += compressedSuffix
+	}
+	return rotated
+}
+
+// RotatedLogFiles returns the paths of the rotated segments of the log file at
+// path, oldest first.
+func RotatedLogFiles(path string) ([]string, error) {
+	var files []string
+	for n := uint(1); ; n++ {
+		found := false
+		for _, compressed := range []bool{false, true} {
+			rotated := RotatedLogPath(path, n, compressed)
+			if _, err := os.Stat(rotated); err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			files = append([]string{rotated}, files...)
+			found = true
+			break
+		}
+		if !found {
+			return files, nil
+		}
+	}
+}
+
+// RotateLogFile moves the log file at path to its first rotated segment,
+// keeping at most maxFiles segments.  If compress is set, the segment is
+// compressed with gzip.  The caller must create a new log file at path.
+func RotateLogFile(path string, maxFiles uint, compress bool) error {
+	if maxFiles == 0 {
+		return fmt.Errorf("cannot rotate log file %s without keeping any rotated files", path)
+	}
+	for _, compressed := range []bool{false, true} {
+		if err := os.Remove(RotatedLogPath(path, maxFiles, compressed)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for n := maxFiles - 1; n >= 1; n-- {
+		for _, compressed := range []bool{false, true} {
+			if err := os.Rename(RotatedLogPath(path, n, compressed), RotatedLogPath(path, n+1, compressed)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	rotated := RotatedLogPath(path, 1, false)
+	if err := os.Rename(path, rotated); err != nil {
+		return err
+	}
+	if !compress {
+		return nil
+	}
+	if err := compressFile(rotated, RotatedLogPath(path, 1, true)); err != nil {
+		return fmt.Errorf("compressing rotated log file %s: %w", rotated, err)
+	}
+	return os.Remove(rotated)
+}
+
+// compressFile writes the gzip compressed content of src to dst.
+func compressFile(src, dst string) (retErr error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			out.Close()
+			os.Remove(tmp)
+		}
+	}()
+
+	zw := gzip.NewWriter(out)
+	if _, err := io.Copy(zw, in); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// errStopReading stops readLogFile without an error.
+var errStopReading = errors.New("stop reading")
+
+// readLogFile calls fn for every line of a plain or gzip compressed log file.
+func readLogFile(path string, fn func(*LogLine) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, compressedSuffix) {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("reading compressed log file %s: %w", path, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimSuffix(line, "\n"); line != "" {
+			nll, err := NewLogLine(line)
+			if err != nil {
+				return err
+			}
+			if err := fn(nll); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("reading log file %s: %w", path, err)
+		}
+	}
+}
+
+// readRotatedLog calls fn for every line of the rotated segments of the log
+// file at path, oldest first.
+func readRotatedLog(path string, fn func(*LogLine) error) error {
+	files, err := RotatedLogFiles(path)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := readLogFile(file, fn); err != nil {
+			// The segment may have been rotated away in the meantime.
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadRotatedLog calls fn for the lines of the rotated segments of the log
+// file at path, oldest first, skipping the lines outside of options.Since and
+// options.Until.  The segments are read line by line, so the lines are not
+// kept in memory.
+func ReadRotatedLog(path string, options *LogOptions, fn func(*LogLine) error) error {
+	err := readRotatedLog(path, func(nll *LogLine) error {
+		if !nll.Until(options.Until) {
+			// The segments are ordered by time, all further
+			// lines are after until as well.
+			return errStopReading
+		}
+		if !nll.Since(options.Since) {
+			return nil
+		}
+		return fn(nll)
+	})
+	if errors.Is(err, errStopReading) {
+		return nil
+	}
+	return err
+}
+
+// getRotatedLog returns the last tail lines of the rotated segments of the log
+// file at path, oldest first.  Only full lines are counted, like getTailLog
+// does, and at most tail of them are kept in memory.
+func getRotatedLog(path string, tail int) ([]*LogLine, error) {
+	var (
+		lines []*LogLine
+		full  int
+	)
+	err := readRotatedLog(path, func(nll *LogLine) error {
+		lines = append(lines, nll)
+		count := full + 1
+		if !nll.Partial() {
+			full++
+		}
+		// A trailing partial line counts as well.
+		for ; count > tail; count-- {
+			// Drop the oldest line with the partial lines before it.
+			for len(lines) > 0 {
+				dropped := lines[0]
+				lines = lines[1:]
+				if !dropped.Partial() {
+					full--
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// countFullLines returns the number of lines getTailLog counted for lines.
+func countFullLines(lines []*LogLine) int {
+	count := 0
+	for i, line := range lines {
+		if !line.Partial() || i == len(lines)-1 {
+			count++
+		}
+	}
+	return count
+}
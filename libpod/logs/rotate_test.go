@@ -0,0 +1,100 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestLog(t *testing.T, path string, msgs ...string) {
+	content := ""
+	for _, msg := range msgs {
+		content += fmt.Sprintf("%s stdout F %s\n", logTime.Format(LogTimeFormat), msg)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestRotateLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+
+	for i := 1; i <= 4; i++ {
+		writeTestLog(t, path, fmt.Sprintf("line%d", i))
+		require.NoError(t, RotateLogFile(path, 2, i%2 == 0))
+	}
+	writeTestLog(t, path, "line5")
+
+	files, err := RotatedLogFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{RotatedLogPath(path, 2, false), RotatedLogPath(path, 1, true)}, files)
+
+	var lines []*LogLine
+	require.NoError(t, ReadRotatedLog(path, &LogOptions{}, func(nll *LogLine) error {
+		lines = append(lines, nll)
+		return nil
+	}))
+	require.Len(t, lines, 2)
+	assert.Equal(t, "line3", lines[0].Msg)
+	assert.Equal(t, "line4", lines[1].Msg)
+
+	assert.Error(t, RotateLogFile(path, 0, false))
+}
+
+func TestReadRotatedLogSinceUntil(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	content := ""
+	for i := 1; i <= 4; i++ {
+		content += fmt.Sprintf("%s stdout F line%d\n", logTime.Add(time.Duration(i)*time.Second).Format(LogTimeFormat), i)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, RotateLogFile(path, 1, true))
+
+	var msgs []string
+	options := &LogOptions{Since: logTime.Add(time.Second), Until: logTime.Add(4 * time.Second)}
+	require.NoError(t, ReadRotatedLog(path, options, func(nll *LogLine) error {
+		msgs = append(msgs, nll.Msg)
+		return nil
+	}))
+	assert.Equal(t, []string{"line2", "line3"}, msgs)
+}
+
+func TestGetLogFileRotated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctr.log")
+	writeTestLog(t, path, "line1", "line2")
+	require.NoError(t, RotateLogFile(path, 3, true))
+	writeTestLog(t, path, "line3", "line4")
+	require.NoError(t, RotateLogFile(path, 3, false))
+	writeTestLog(t, path, "line5")
+
+	msgs := func(lines []*LogLine) []string {
+		var result []string
+		for _, line := range lines {
+			result = append(result, line.Msg)
+		}
+		return result
+	}
+
+	tests := []struct {
+		tail int64
+		want []string
+	}{
+		// The rotated segments are read by ReadRotatedLog.
+		{tail: -1, want: nil},
+		{tail: 0, want: nil},
+		{tail: 1, want: []string{"line5"}},
+		{tail: 4, want: []string{"line2", "line3", "line4", "line5"}},
+		{tail: 10, want: []string{"line1", "line2", "line3", "line4", "line5"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("tail %d", tt.tail), func(t *testing.T) {
+			tail, lines, err := GetLogFile(path, &LogOptions{Tail: tt.tail})
+			require.NoError(t, err)
+			defer tail.Cleanup()
+			assert.Equal(t, tt.want, msgs(lines))
+		})
+	}
+}
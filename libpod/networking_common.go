@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -337,7 +338,7 @@ func resultToBasicNetworkConfig(result types.StatusBlock) define.InspectBasicNet
 }
 
 // NetworkDisconnect removes a container from the network
-func (c *Container) NetworkDisconnect(nameOrID, netName string, force bool) error {
+func (c *Container) NetworkDisconnect(ctx context.Context, nameOrID, netName string, force bool) error {
 	// only the bridge mode supports cni networks
 	if err := isBridgeNetMode(c.config.NetMode); err != nil {
 		return err
@@ -373,7 +374,7 @@ func (c *Container) NetworkDisconnect(nameOrID, netName string, force bool) erro
 		return err
 	}
 
-	c.newNetworkEvent(events.NetworkDisconnect, netName)
+	c.newNetworkEvent(ctx, events.NetworkDisconnect, netName)
 	if !c.ensureState(define.ContainerStateRunning, define.ContainerStateCreated) {
 		return nil
 	}
@@ -451,7 +452,7 @@ func (c *Container) NetworkDisconnect(nameOrID, netName string, force bool) erro
 }
 
 // ConnectNetwork connects a container to a given network
-func (c *Container) NetworkConnect(nameOrID, netName string, netOpts types.PerNetworkOptions) error {
+func (c *Container) NetworkConnect(ctx context.Context, nameOrID, netName string, netOpts types.PerNetworkOptions) error {
 	// only the bridge mode supports networks
 	if err := isBridgeNetMode(c.config.NetMode); err != nil {
 		return err
@@ -496,7 +497,7 @@ func (c *Container) NetworkConnect(nameOrID, netName string, netOpts types.PerNe
 
 		return err
 	}
-	c.newNetworkEvent(events.NetworkConnect, netName)
+	c.newNetworkEvent(ctx, events.NetworkConnect, netName)
 	if !c.ensureState(define.ContainerStateRunning, define.ContainerStateCreated) {
 		return nil
 	}
@@ -615,21 +616,21 @@ func getExtraNetworkAliases(c *Container) []string {
 }
 
 // DisconnectContainerFromNetwork removes a container from its network
-func (r *Runtime) DisconnectContainerFromNetwork(nameOrID, netName string, force bool) error {
+func (r *Runtime) DisconnectContainerFromNetwork(ctx context.Context, nameOrID, netName string, force bool) error {
 	ctr, err := r.LookupContainer(nameOrID)
 	if err != nil {
 		return err
 	}
-	return ctr.NetworkDisconnect(nameOrID, netName, force)
+	return ctr.NetworkDisconnect(ctx, nameOrID, netName, force)
 }
 
 // ConnectContainerToNetwork connects a container to a network
-func (r *Runtime) ConnectContainerToNetwork(nameOrID, netName string, netOpts types.PerNetworkOptions) error {
+func (r *Runtime) ConnectContainerToNetwork(ctx context.Context, nameOrID, netName string, netOpts types.PerNetworkOptions) error {
 	ctr, err := r.LookupContainer(nameOrID)
 	if err != nil {
 		return err
 	}
-	return ctr.NetworkConnect(nameOrID, netName, netOpts)
+	return ctr.NetworkConnect(ctx, nameOrID, netName, netOpts)
 }
 
 // normalizeNetworkName takes a network name, a partial or a full network ID and returns the network name.
@@ -1070,7 +1070,7 @@ func getPreserveFdExtraFiles(preserveFD []uint, preserveFDs uint) (uint, []*os.F
 }
 
 // createOCIContainer generates this container's main conmon instance and prepares it for starting
-func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *ContainerCheckpointOptions) (int64, error) {
+func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *ContainerCheckpointOptions) (_ int64, retErr error) {
 	var stderrBuf bytes.Buffer
 
 	parentSyncPipe, childSyncPipe, err := newPipe()
@@ -1107,7 +1107,19 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		pidfile = filepath.Join(ctr.state.RunDir, "pidfile")
 	}
 
-	args := r.sharedConmonArgs(ctr, ctr.ID(), ctr.bundlePath(), pidfile, ctr.LogPath(), r.exitsDir, ociLog, ctr.LogDriver(), logTag)
+	logPath, logLimiterPID, err := ctr.startLogLimiter(r.logSizeMaxFor(ctr))
+	if err != nil {
+		return 0, err
+	}
+	if logPath != ctr.LogPath() {
+		defer func() {
+			if retErr != nil {
+				ctr.stopLogLimiter()
+			}
+		}()
+	}
+
+	args := r.sharedConmonArgs(ctr, ctr.ID(), ctr.bundlePath(), pidfile, logPath, r.exitsDir, ociLog, ctr.LogDriver(), logTag)
 
 	if ctr.config.SdNotifyMode == define.SdNotifyModeContainer && ctr.config.SdNotifySocket != "" {
 		args = append(args, fmt.Sprintf("--sdnotify-socket=%s", ctr.config.SdNotifySocket))
@@ -1134,7 +1146,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		args = append(args, "--no-pivot")
 	}
 
-	exitCommand, err := specgenutil.CreateExitCommandArgs(ctr.runtime.storageConfig, ctr.runtime.config, ctr.runtime.syslog || logrus.IsLevelEnabled(logrus.DebugLevel), ctr.AutoRemove(), false)
+	exitCommand, err := specgenutil.CreateExitCommandArgs(ctr.runtime.storageConfig, ctr.runtime.config, ctr.runtime.eventsWebhookURL, ctr.runtime.syslog || logrus.IsLevelEnabled(logrus.DebugLevel), ctr.AutoRemove(), false)
 	if err != nil {
 		return 0, err
 	}
@@ -1319,6 +1331,9 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		// conmon not having a pid file is a valid state, so don't set it if we don't have it
 		logrus.Infof("Got Conmon PID as %d", conmonPID)
 		ctr.state.ConmonPID = conmonPID
+		if logLimiterPID > 0 {
+			moveLogLimiterToConmonCgroup(logLimiterPID, conmonPID)
+		}
 	}
 
 	runtimeRestoreDuration := func() int64 {
@@ -1362,6 +1377,15 @@ func (r *ConmonOCIRuntime) configureConmonEnv() ([]string, error) {
 	return res, nil
 }
 
+// logSizeMaxFor returns the size at which the log file of the container is
+// rotated, 0 if it is never rotated.
+func (r *ConmonOCIRuntime) logSizeMaxFor(ctr *Container) int64 {
+	if ctr.config.LogSize > 0 {
+		return ctr.config.LogSize
+	}
+	return r.logSizeMax
+}
+
 // sharedConmonArgs takes common arguments for exec and create/restore and formats them for the conmon CLI
 func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath, logPath, exitDir, ociLogPath, logDriver, logTag string) []string {
 	// set the conmon API version to be able to use the correct sync struct keys
@@ -1418,11 +1442,8 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 	logrus.Debugf("%s messages will be logged to syslog", r.conmonPath)
 	args = append(args, "--syslog")
 
-	size := r.logSizeMax
-	if ctr.config.LogSize > 0 {
-		size = ctr.config.LogSize
-	}
-	if size > 0 {
+	// The log limiter rotates the log file itself.
+	if size := r.logSizeMaxFor(ctr); size > 0 && logPath != ctr.logLimiterFIFOPath() {
 		args = append(args, "--log-size-max", strconv.FormatInt(size, 10))
 	}
 
@@ -28,6 +28,10 @@ func (r *ConmonOCIRuntime) moveConmonToCgroupAndSignal(ctr *Container, cmd *exec
 	return nil
 }
 
+// moveLogLimiterToConmonCgroup is a no-op as there are no cgroups on FreeBSD.
+func moveLogLimiterToConmonCgroup(limiterPID, conmonPID int) {
+}
+
 func moveToRuntimeCgroup() error {
 	return errors.New("moveToRuntimeCgroup not supported on freebsd")
 }
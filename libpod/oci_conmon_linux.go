@@ -167,6 +167,19 @@ func (r *ConmonOCIRuntime) moveConmonToCgroupAndSignal(ctr *Container, cmd *exec
 	return writeConmonPipeData(startFd)
 }
 
+// moveLogLimiterToConmonCgroup moves the log limiter of a container to the
+// cgroup of its conmon process, so it is accounted and stopped together with
+// conmon instead of podman.
+func moveLogLimiterToConmonCgroup(limiterPID, conmonPID int) {
+	cgroup, err := cgroups.GetCgroupProcess(conmonPID)
+	if err == nil {
+		err = cgroups.MoveUnderCgroup(cgroup, "", []uint32{uint32(limiterPID)})
+	}
+	if err != nil {
+		logrus.Debugf("Failed to move log limiter %d to the cgroup of conmon %d: %v", limiterPID, conmonPID, err)
+	}
+}
+
 // GetLimits converts spec resource limits to cgroup consumable limits
 func GetLimits(resource *spec.LinuxResources) (runcconfig.Resources, error) {
 	if resource == nil {
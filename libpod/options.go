@@ -522,6 +522,30 @@ func WithMigrateRuntime(requestedRuntime string) RuntimeOption {
 	}
 }
 
+// WithMigrateDatabase instructs Engine to copy the state of all containers,
+// pods and volumes to the given database backend during a migration, and to
+// use it from then on. The old database is kept as a backup.
+// This is not used if `WithMigrate()` is not also passed.
+func WithMigrateDatabase(backend string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		parsed, err := config.ParseDBBackend(backend)
+		if err != nil {
+			return err
+		}
+		if parsed == config.DBBackendDefault {
+			return fmt.Errorf("must provide a database backend to migrate to: %w", define.ErrInvalidArg)
+		}
+
+		rt.migrateDBBackend = parsed.String()
+
+		return nil
+	}
+}
+
 // WithEventsLogger sets the events backend to use.
 // Currently supported values are "file" for file backend and "journald" for
 // journald backend.
@@ -540,6 +564,19 @@ func WithEventsLogger(logger string) RuntimeOption {
 	}
 }
 
+// WithEventsWebhookURL sets the endpoint the webhook events backend forwards
+// events to.
+func WithEventsWebhookURL(webhookURL string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.eventsWebhookURL = webhookURL
+		return nil
+	}
+}
+
 // WithEnableSDNotify sets a runtime option so we know whether to disable socket/FD
 // listening
 func WithEnableSDNotify() RuntimeOption {
@@ -583,6 +620,45 @@ func WithMaxLogSize(limit int64) CtrCreateOption {
 	}
 }
 
+// WithLogRateLimit limits the log output of the container to rate bytes per
+// second, allowing bursts of up to burst bytes.
+// If burst is 0, it defaults to rate.
+func WithLogRateLimit(rate, burst int64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if rate < 0 || burst < 0 {
+			return fmt.Errorf("log rate and burst must not be negative: %w", define.ErrInvalidArg)
+		}
+		if burst > 0 && rate == 0 {
+			return fmt.Errorf("cannot set a log burst without a log rate: %w", define.ErrInvalidArg)
+		}
+		ctr.config.LogRateLimit = rate
+		ctr.config.LogRateBurst = burst
+
+		return nil
+	}
+}
+
+// WithLogRotation keeps maxFiles rotated log files, compressed with gzip if
+// compress is set, instead of truncating the log file when it reaches its
+// maximum size.
+func WithLogRotation(maxFiles uint, compress bool) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if compress && maxFiles == 0 {
+			return fmt.Errorf("cannot compress rotated log files without keeping any: %w", define.ErrInvalidArg)
+		}
+		ctr.config.LogMaxFiles = maxFiles
+		ctr.config.LogCompress = compress
+
+		return nil
+	}
+}
+
 // WithShmDir sets the directory that should be mounted on /dev/shm.
 func WithShmDir(dir string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1542,6 +1618,19 @@ func WithHealthCheckOnFailureAction(action define.HealthCheckOnFailureAction) Ct
 	}
 }
 
+// WithHealthCheckOnFailureCommand sets a command that is run once the
+// container turns unhealthy, on the host or inside the container.
+func WithHealthCheckOnFailureCommand(command []string, inContainer bool) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.HealthCheckOnFailureCommand = command
+		ctr.config.HealthCheckOnFailureCommandInContainer = inContainer
+		return nil
+	}
+}
+
 // WithPreserveFDs forwards from the process running Libpod into the container
 // the given number of extra FDs (starting after the standard streams) to the created container
 func WithPreserveFDs(fd uint) CtrCreateOption {
@@ -1980,6 +2069,30 @@ func WithStartupHealthcheck(startupHC *define.StartupHealthCheck) CtrCreateOptio
 	}
 }
 
+// WithPostStartCommand sets a command that is executed in the container
+// right after it has been started.
+func WithPostStartCommand(command []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.PostStartCommand = command
+		return nil
+	}
+}
+
+// WithPreStopCommand sets a command that is executed in the container right
+// before it is being stopped.
+func WithPreStopCommand(command []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.PreStopCommand = command
+		return nil
+	}
+}
+
 // Pod Creation Options
 
 // WithPodCreateCommand adds the full command plus arguments of the current
@@ -1994,6 +2107,21 @@ func WithPodCreateCommand(createCmd []string) PodCreateOption {
 	}
 }
 
+// WithPodLogQuota limits the number of bytes the log files of all containers of
+// the pod may take up on disk together to quota.
+func WithPodLogQuota(quota int64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if quota < 0 {
+			return fmt.Errorf("log quota must not be negative: %w", define.ErrInvalidArg)
+		}
+		pod.config.LogQuota = quota
+		return nil
+	}
+}
+
 // WithPodName sets the name of the pod.
 func WithPodName(name string) PodCreateOption {
 	return func(pod *Pod) error {
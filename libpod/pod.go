@@ -94,6 +94,10 @@ type PodConfig struct {
 
 	// ResourceLimits hold the pod level resource limits
 	ResourceLimits specs.LinuxResources
+
+	// LogQuota is the number of bytes the log files of all containers of
+	// the pod may take up on disk together.
+	LogQuota int64 `json:"logQuota,omitempty"`
 }
 
 // podState represents a pod's state
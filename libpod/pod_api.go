@@ -114,7 +114,7 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 	if len(ctrErrors) > 0 {
 		return ctrErrors, fmt.Errorf("starting some containers: %w", define.ErrPodPartialFail)
 	}
-	defer p.newPodEvent(events.Start)
+	defer p.newPodEvent(ctx, events.Start)
 	return nil, nil
 }
 
@@ -189,7 +189,7 @@ func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int) (m
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Stop)
+	p.newPodEvent(ctx, events.Stop)
 
 	ctrErrors := make(map[string]error)
 
@@ -371,7 +371,7 @@ func (p *Pod) Pause(ctx context.Context) (map[string]error, error) {
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Pause)
+	p.newPodEvent(ctx, events.Pause)
 
 	ctrErrors := make(map[string]error)
 
@@ -427,7 +427,7 @@ func (p *Pod) Unpause(ctx context.Context) (map[string]error, error) {
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Unpause)
+	p.newPodEvent(ctx, events.Unpause)
 
 	ctrErrors := make(map[string]error)
 
@@ -500,8 +500,8 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 	if len(ctrErrors) > 0 {
 		return ctrErrors, fmt.Errorf("stopping some containers: %w", define.ErrPodPartialFail)
 	}
-	p.newPodEvent(events.Stop)
-	p.newPodEvent(events.Start)
+	p.newPodEvent(ctx, events.Stop)
+	p.newPodEvent(ctx, events.Start)
 	return nil, nil
 }
 
@@ -542,7 +542,7 @@ func (p *Pod) Kill(ctx context.Context, signal uint) (map[string]error, error) {
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Kill)
+	p.newPodEvent(ctx, events.Kill)
 
 	ctrErrors := make(map[string]error)
 
@@ -756,6 +756,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		BlkioDeviceWriteBps: p.BlkiThrottleWriteBps(),
 		CPUShares:           p.CPUShares(),
 		RestartPolicy:       p.config.RestartPolicy,
+		LogQuota:            p.config.LogQuota,
 		LockNumber:          p.lock.ID(),
 	}
 
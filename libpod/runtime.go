@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -104,6 +105,8 @@ type Runtime struct {
 	// We make no promises that these migrated containers work on the new
 	// runtime, though.
 	migrateRuntime string
+	// System migrate can also copy the state to a new database backend.
+	migrateDBBackend string
 
 	// valid indicates whether the runtime is ready to use.
 	// valid is set to true when a runtime is returned from GetRuntime(),
@@ -113,6 +116,12 @@ type Runtime struct {
 
 	// mechanism to read and write even logs
 	eventer events.Eventer
+	// eventsWebhookURL is the endpoint events are forwarded to by the
+	// webhook events backend
+	eventsWebhookURL string
+	// imageEventCallers tracks the callers of API requests to attribute
+	// image events to them
+	imageEventCallers eventCallers
 
 	// secretsManager manages secrets
 	secretsManager *secrets.SecretsManager
@@ -295,6 +304,15 @@ func getLockManager(runtime *Runtime) (lock.Manager, error) {
 	return manager, nil
 }
 
+// boltStatePath returns the path of the BoltDB state database.
+func boltStatePath(runtime *Runtime) string {
+	baseDir := runtime.config.Engine.StaticDir
+	if runtime.storageConfig.TransientStore {
+		baseDir = runtime.config.Engine.TmpDir
+	}
+	return filepath.Join(baseDir, "bolt_state.db")
+}
+
 func getDBState(runtime *Runtime) (State, error) {
 	// TODO - if we further break out the state implementation into
 	// libpod/state, the config could take care of the code below.  It
@@ -305,12 +323,7 @@ func getDBState(runtime *Runtime) (State, error) {
 		return nil, err
 	}
 
-	// get default boltdb path
-	baseDir := runtime.config.Engine.StaticDir
-	if runtime.storageConfig.TransientStore {
-		baseDir = runtime.config.Engine.TmpDir
-	}
-	boltDBPath := filepath.Join(baseDir, "bolt_state.db")
+	boltDBPath := boltStatePath(runtime)
 
 	switch backend {
 	case config.DBBackendDefault:
@@ -674,7 +687,11 @@ func makeRuntime(runtime *Runtime) (retErr error) {
 	runtime.valid = true
 
 	if runtime.doMigrate {
-		if err := runtime.migrate(); err != nil {
+		if runtime.migrateDBBackend != "" {
+			if err := runtime.migrateDB(); err != nil {
+				return err
+			}
+		} else if err := runtime.migrate(); err != nil {
 			return err
 		}
 	}
@@ -762,6 +779,7 @@ func (r *Runtime) libimageEvents() {
 					Time:   libimageEvent.Time,
 					Type:   events.Image,
 				}
+				r.setImageEventAttributes(&e)
 				if err := r.eventer.Write(e); err != nil {
 					logrus.Errorf("Unable to write image event: %q", err)
 				}
@@ -835,6 +853,13 @@ func (r *Runtime) Shutdown(force bool) error {
 			lastError = fmt.Errorf("shutting down container storage: %w", err)
 		}
 	}
+	// Some event backends deliver events asynchronously, flush them.
+	if closer, ok := r.eventer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logrus.Errorf("Flushing events: %v", err)
+		}
+	}
+
 	if err := r.state.Close(); err != nil {
 		if lastError != nil {
 			logrus.Error(lastError)
@@ -990,6 +1015,12 @@ func (r *Runtime) StorageConfig() storage.StoreOptions {
 	return r.storageConfig
 }
 
+// EventsWebhookURL returns the endpoint the webhook events backend forwards
+// events to
+func (r *Runtime) EventsWebhookURL() string {
+	return r.eventsWebhookURL
+}
+
 func (r *Runtime) GarbageCollect() error {
 	return r.store.GarbageCollect()
 }
@@ -0,0 +1,377 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// checkStorageContainerMinAge is the minimum age of a c/storage container
+// without a libpod record before it is considered dangling. Younger
+// containers may still be in the process of being created. It is a variable
+// so that tests can lower it.
+var checkStorageContainerMinAge = 10 * time.Minute
+
+// SystemCheck walks the database, c/storage and the lock manager and reports
+// every inconsistency between them. If options.Repair is set, the
+// inconsistencies that are safe to fix are repaired.
+// Other podman processes should not be running while repairs are made.
+func (r *Runtime) SystemCheck(ctx context.Context, options define.SystemCheckOptions) (*define.SystemCheckReport, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	report := new(define.SystemCheckReport)
+
+	ctrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.checkExecSessions(ctrs, options, report); err != nil {
+		return nil, err
+	}
+	if err := r.checkContainerDependencies(ctx, ctrs, options, report); err != nil {
+		return nil, err
+	}
+	if !options.Quick {
+		if err := r.checkStorageContainers(options, report); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.checkLocks(ctrs, options, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// addIssue adds an issue to the report. If repair is not nil, it is called
+// to fix the issue and the outcome is recorded.
+func addIssue(report *define.SystemCheckReport, kind, id, message string, repair func() error) {
+	issue := define.SystemCheckIssue{
+		Kind:    kind,
+		ID:      id,
+		Message: message,
+	}
+	if repair != nil {
+		if err := repair(); err != nil {
+			logrus.Errorf("Repairing %s %s: %v", kind, id, err)
+			issue.RepairError = err.Error()
+		} else {
+			issue.Repaired = true
+		}
+	}
+	report.Issues = append(report.Issues, issue)
+}
+
+// checkExecSessions finds exec sessions registered in the database that
+// their container does not know about, and exec sessions recorded as running
+// in containers that are not running.
+func (r *Runtime) checkExecSessions(ctrs []*Container, options define.SystemCheckOptions, report *define.SystemCheckReport) error {
+	for _, ctr := range ctrs {
+		sessions, err := r.state.GetContainerExecSessions(ctr)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return fmt.Errorf("retrieving exec sessions of container %s: %w", ctr.ID(), err)
+		}
+		sort.Strings(sessions)
+		for _, id := range sessions {
+			if _, ok := ctr.state.ExecSessions[id]; ok {
+				continue
+			}
+			if _, ok := ctr.state.LegacyExecSessions[id]; ok {
+				continue
+			}
+			var repair func() error
+			if options.Repair {
+				session := &ExecSession{Id: id, ContainerId: ctr.ID()}
+				repair = func() error {
+					return r.state.RemoveExecSession(session)
+				}
+			}
+			addIssue(report, define.CheckOrphanedExecSession, id,
+				fmt.Sprintf("exec session is registered in the database but unknown to container %s", ctr.ID()), repair)
+		}
+
+		if ctr.state.State == define.ContainerStateRunning || ctr.state.State == define.ContainerStatePaused {
+			continue
+		}
+		stale := []string{}
+		for id, session := range ctr.state.ExecSessions {
+			if session.State == define.ExecStateRunning {
+				stale = append(stale, id)
+			}
+		}
+		sort.Strings(stale)
+		for _, id := range stale {
+			var repair func() error
+			if options.Repair {
+				sessionID := id
+				repair = func() error {
+					return ctr.stopStaleExecSession(sessionID)
+				}
+			}
+			addIssue(report, define.CheckStaleExecSession, id,
+				fmt.Sprintf("exec session is running but container %s is %s", ctr.ID(), ctr.state.State), repair)
+		}
+	}
+	return nil
+}
+
+// stopStaleExecSession marks an exec session as stopped if the container is
+// still not running.
+func (c *Container) stopStaleExecSession(id string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if c.state.State == define.ContainerStateRunning || c.state.State == define.ContainerStatePaused {
+		return fmt.Errorf("container %s is %s: %w", c.ID(), c.state.State, define.ErrCtrStateInvalid)
+	}
+	session, ok := c.state.ExecSessions[id]
+	if !ok || session.State != define.ExecStateRunning {
+		return nil
+	}
+	session.State = define.ExecStateStopped
+	session.PID = 0
+	if err := c.cleanupExecBundle(id); err != nil {
+		logrus.Debugf("Cleaning up exec session %s bundle: %v", id, err)
+	}
+	return c.save()
+}
+
+// checkContainerDependencies finds containers referencing pods, named
+// volumes or images that no longer exist. Only missing volumes are
+// repaired, by recreating them empty; once recreated, other containers
+// mounting the same volume are no longer reported.
+func (r *Runtime) checkContainerDependencies(ctx context.Context, ctrs []*Container, options define.SystemCheckOptions, report *define.SystemCheckReport) error {
+	for _, ctr := range ctrs {
+		if ctr.config.Pod != "" {
+			exists, err := r.state.HasPod(ctr.config.Pod)
+			if err != nil {
+				return fmt.Errorf("looking up pod %s: %w", ctr.config.Pod, err)
+			}
+			if !exists {
+				addIssue(report, define.CheckMissingPod, ctr.ID(),
+					fmt.Sprintf("container belongs to pod %s which does not exist", ctr.config.Pod), nil)
+			}
+		}
+
+		for _, namedVol := range ctr.config.NamedVolumes {
+			exists, err := r.state.HasVolume(namedVol.Name)
+			if err != nil {
+				return fmt.Errorf("looking up volume %s: %w", namedVol.Name, err)
+			}
+			if exists {
+				continue
+			}
+			var repair func() error
+			if options.Repair {
+				name := namedVol.Name
+				repair = func() error {
+					_, err := r.newVolume(ctx, false, WithVolumeName(name))
+					return err
+				}
+			}
+			addIssue(report, define.CheckMissingVolume, ctr.ID(),
+				fmt.Sprintf("container mounts volume %s which does not exist", namedVol.Name), repair)
+		}
+
+		if options.Quick || ctr.config.RootfsImageID == "" {
+			continue
+		}
+		if _, err := r.store.Image(ctr.config.RootfsImageID); err != nil {
+			if !errors.Is(err, storage.ErrImageUnknown) {
+				return fmt.Errorf("looking up image %s: %w", ctr.config.RootfsImageID, err)
+			}
+			addIssue(report, define.CheckMissingImage, ctr.ID(),
+				fmt.Sprintf("container was created from image %s which is no longer in storage", ctr.config.RootfsImageID), nil)
+		}
+	}
+	return nil
+}
+
+// checkStorageContainers finds containers in c/storage that are neither
+// known to libpod nor created by buildah nor backing an image volume.
+func (r *Runtime) checkStorageContainers(options define.SystemCheckOptions, report *define.SystemCheckReport) error {
+	storageCtrs, err := r.ListStorageContainers()
+	if err != nil {
+		return err
+	}
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	volumeStorage := make(map[string]bool)
+	for _, vol := range vols {
+		if vol.config.Driver == define.VolumeDriverImage && vol.config.StorageID != "" {
+			volumeStorage[vol.config.StorageID] = true
+		}
+	}
+
+	for _, ctr := range storageCtrs {
+		if ctr.PresentInLibpod || volumeStorage[ctr.ID] || time.Since(ctr.CreateTime) < checkStorageContainerMinAge {
+			continue
+		}
+		isBuildah, err := r.IsBuildahContainer(ctr.ID)
+		if err != nil {
+			return fmt.Errorf("checking if storage container %s is a buildah container: %w", ctr.ID, err)
+		}
+		if isBuildah {
+			continue
+		}
+		var repair func() error
+		if options.Repair {
+			id := ctr.ID
+			repair = func() error {
+				return r.RemoveStorageContainer(id, true)
+			}
+		}
+		addIssue(report, define.CheckDanglingStorageContainer, ctr.ID,
+			fmt.Sprintf("storage container %v has no libpod record", ctr.Names), repair)
+	}
+	return nil
+}
+
+// withNewLock allocates a new lock and passes it to rewrite, which switches a
+// container, pod or volume over to it.  The lock is freed if rewrite fails.
+func (r *Runtime) withNewLock(rewrite func(lock.Locker) error) error {
+	newLock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return err
+	}
+	if err := rewrite(newLock); err != nil {
+		if freeErr := newLock.Free(); freeErr != nil {
+			logrus.Errorf("Freeing lock %d: %v", newLock.ID(), freeErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkLocks finds lock IDs used by more than one container, pod or volume.
+// Repairs keep the lock for the first user and allocate new locks for the
+// others.
+func (r *Runtime) checkLocks(ctrs []*Container, options define.SystemCheckOptions, report *define.SystemCheckReport) error {
+	type lockUser struct {
+		name   string
+		reLock func() error
+	}
+	users := make(map[uint32][]lockUser)
+
+	for _, ctr := range ctrs {
+		ctr := ctr
+		users[ctr.config.LockID] = append(users[ctr.config.LockID], lockUser{
+			name: "container " + ctr.ID(),
+			reLock: func() error {
+				return r.withNewLock(func(newLock lock.Locker) error {
+					newConfig := new(ContainerConfig)
+					if err := JSONDeepCopy(ctr.config, newConfig); err != nil {
+						return err
+					}
+					newConfig.LockID = newLock.ID()
+					if err := r.state.RewriteContainerConfig(ctr, newConfig); err != nil {
+						return err
+					}
+					ctr.config = newConfig
+					ctr.lock = newLock
+					return nil
+				})
+			},
+		})
+	}
+	pods, err := r.state.AllPods()
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		pod := pod
+		users[pod.config.LockID] = append(users[pod.config.LockID], lockUser{
+			name: "pod " + pod.ID(),
+			reLock: func() error {
+				return r.withNewLock(func(newLock lock.Locker) error {
+					newConfig := new(PodConfig)
+					if err := JSONDeepCopy(pod.config, newConfig); err != nil {
+						return err
+					}
+					newConfig.LockID = newLock.ID()
+					if err := r.state.RewritePodConfig(pod, newConfig); err != nil {
+						return err
+					}
+					pod.config = newConfig
+					pod.lock = newLock
+					return nil
+				})
+			},
+		})
+	}
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	for _, vol := range vols {
+		vol := vol
+		users[vol.config.LockID] = append(users[vol.config.LockID], lockUser{
+			name: "volume " + vol.Name(),
+			reLock: func() error {
+				return r.withNewLock(func(newLock lock.Locker) error {
+					newConfig := new(VolumeConfig)
+					if err := JSONDeepCopy(vol.config, newConfig); err != nil {
+						return err
+					}
+					newConfig.LockID = newLock.ID()
+					if err := r.state.RewriteVolumeConfig(vol, newConfig); err != nil {
+						return err
+					}
+					vol.config = newConfig
+					vol.lock = newLock
+					return nil
+				})
+			},
+		})
+	}
+
+	lockIDs := make([]uint32, 0, len(users))
+	for lockID, lockUsers := range users {
+		if len(lockUsers) > 1 {
+			lockIDs = append(lockIDs, lockID)
+		}
+	}
+	sort.Slice(lockIDs, func(i, j int) bool { return lockIDs[i] < lockIDs[j] })
+
+	for _, lockID := range lockIDs {
+		lockUsers := users[lockID]
+		names := make([]string, 0, len(lockUsers))
+		for _, user := range lockUsers {
+			names = append(names, user.name)
+		}
+		var repair func() error
+		if options.Repair {
+			repair = func() error {
+				for _, user := range lockUsers[1:] {
+					if err := user.reLock(); err != nil {
+						return fmt.Errorf("allocating new lock for %s: %w", user.name, err)
+					}
+				}
+				return nil
+			}
+		}
+		addIssue(report, define.CheckDuplicateLock, fmt.Sprintf("%d", lockID),
+			fmt.Sprintf("lock is used by %v", names), repair)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getCheckTestRuntime returns a runtime backed by the given database backend
+// and a vfs c/storage store in a temporary directory.
+func getCheckTestRuntime(t *testing.T, backend string) *Runtime {
+	tmpDir := t.TempDir()
+
+	lockManager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.config.Engine.StaticDir = tmpDir
+	runtime.config.Engine.VolumePath = filepath.Join(tmpDir, "volumes")
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = lockManager
+	runtime.eventer = events.EventToNull{}
+
+	store, err := storage.GetStore(storage.StoreOptions{
+		RunRoot:         filepath.Join(tmpDir, "runroot"),
+		GraphRoot:       filepath.Join(tmpDir, "root"),
+		GraphDriverName: "vfs",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = store.Shutdown(true) })
+	runtime.store = store
+
+	switch backend {
+	case "boltdb":
+		runtime.state, err = NewBoltState(filepath.Join(tmpDir, "bolt_state.db"), runtime)
+	case "sqlite":
+		runtime.state, err = NewSqliteState(runtime)
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { runtime.state.Close() })
+
+	runtime.valid = true
+	return runtime
+}
+
+// addCheckTestCtr adds a stopped container without an image to the state.
+func addCheckTestCtr(t *testing.T, r *Runtime, n string) *Container {
+	ctr, err := getTestCtrN(n, r.lockManager)
+	require.NoError(t, err)
+	ctr.config.RootfsImageID = ""
+	ctr.state.State = define.ContainerStateExited
+	ctr.state.ExecSessions = map[string]*ExecSession{}
+	require.NoError(t, r.state.AddContainer(ctr))
+	return ctr
+}
+
+func issueKinds(report *define.SystemCheckReport) []string {
+	kinds := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		kinds = append(kinds, issue.Kind)
+	}
+	return kinds
+}
+
+func TestSystemCheck(t *testing.T) {
+	defer func(age time.Duration) { checkStorageContainerMinAge = age }(checkStorageContainerMinAge)
+	checkStorageContainerMinAge = 0
+
+	for _, backend := range []string{"boltdb", "sqlite"} {
+		t.Run(backend, func(t *testing.T) {
+			r := getCheckTestRuntime(t, backend)
+			ctx := context.Background()
+
+			report, err := r.SystemCheck(ctx, define.SystemCheckOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, report.Issues, "clean state")
+
+			// An exec session in the database the container does not know about.
+			ctr1 := addCheckTestCtr(t, r, "1")
+			require.NoError(t, r.state.AddExecSession(ctr1, &ExecSession{Id: "orphan", ContainerId: ctr1.ID()}))
+
+			// A container mounting a volume that was removed behind its back.
+			ctr2 := addCheckTestCtr(t, r, "2")
+			ctr2.config.NamedVolumes = []*ContainerNamedVolume{{Name: "missing", Dest: "/data"}}
+			require.NoError(t, r.state.RewriteContainerConfig(ctr2, ctr2.config))
+
+			// Two containers sharing a lock.
+			ctr3 := addCheckTestCtr(t, r, "3")
+			ctr3.config.LockID = ctr1.config.LockID
+			require.NoError(t, r.state.RewriteContainerConfig(ctr3, ctr3.config))
+
+			// A c/storage container without a libpod record.
+			dangling, err := r.store.CreateContainer("", []string{"dangling"}, "", "", "", nil)
+			require.NoError(t, err)
+
+			report, err = r.SystemCheck(ctx, define.SystemCheckOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, []string{
+				define.CheckOrphanedExecSession,
+				define.CheckMissingVolume,
+				define.CheckDanglingStorageContainer,
+				define.CheckDuplicateLock,
+			}, issueKinds(report))
+			assert.Equal(t, "orphan", report.Issues[0].ID)
+			assert.Equal(t, ctr2.ID(), report.Issues[1].ID)
+			assert.Equal(t, dangling.ID, report.Issues[2].ID)
+			assert.Equal(t, 4, report.Unrepaired())
+
+			// Quick checks skip c/storage.
+			report, err = r.SystemCheck(ctx, define.SystemCheckOptions{Quick: true})
+			require.NoError(t, err)
+			assert.NotContains(t, issueKinds(report), define.CheckDanglingStorageContainer)
+
+			report, err = r.SystemCheck(ctx, define.SystemCheckOptions{Repair: true})
+			require.NoError(t, err)
+			require.Len(t, report.Issues, 4)
+			for _, issue := range report.Issues {
+				assert.True(t, issue.Repaired, "%s %s: %s", issue.Kind, issue.ID, issue.RepairError)
+			}
+			assert.Zero(t, report.Unrepaired())
+
+			sessions, err := r.state.GetContainerExecSessions(ctr1)
+			require.NoError(t, err)
+			assert.Empty(t, sessions)
+			exists, err := r.state.HasVolume("missing")
+			require.NoError(t, err)
+			assert.True(t, exists)
+			_, err = r.store.Container(dangling.ID)
+			assert.ErrorIs(t, err, storage.ErrContainerUnknown)
+			repaired, err := r.state.Container(ctr3.ID())
+			require.NoError(t, err)
+			assert.NotEqual(t, ctr1.config.LockID, repaired.config.LockID)
+
+			report, err = r.SystemCheck(ctx, define.SystemCheckOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, report.Issues, "repaired state")
+		})
+	}
+}
+
+func TestCheckLocksRepairFailure(t *testing.T) {
+	r := getCheckTestRuntime(t, "sqlite")
+	ctr1 := addCheckTestCtr(t, r, "1")
+
+	// A container sharing the lock whose config cannot be rewritten, as
+	// it is not in the database.
+	ctr2, err := getTestCtrN("2", r.lockManager)
+	require.NoError(t, err)
+	ctr2.config.LockID = ctr1.config.LockID
+	ctr2.lock = ctr1.lock
+	nextLock, err := r.lockManager.AllocateLock()
+	require.NoError(t, err)
+	require.NoError(t, nextLock.Free())
+
+	report := new(define.SystemCheckReport)
+	require.NoError(t, r.checkLocks([]*Container{ctr1, ctr2}, define.SystemCheckOptions{Repair: true}, report))
+	require.Len(t, report.Issues, 1)
+	assert.False(t, report.Issues[0].Repaired)
+	assert.NotEmpty(t, report.Issues[0].RepairError)
+
+	// The container keeps its lock and the new lock is freed again.
+	assert.Equal(t, ctr1.config.LockID, ctr2.config.LockID)
+	assert.Equal(t, ctr1.lock, ctr2.lock)
+	newLock, err := r.lockManager.AllocateLock()
+	require.NoError(t, err)
+	assert.Equal(t, nextLock.ID(), newLock.ID())
+}
@@ -979,6 +979,11 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, opts ctrRmO
 		}
 	}
 
+	// The log files are removed with the container's storage.
+	if !opts.RemovePod {
+		c.releaseLogQuota(pod)
+	}
+
 	// Stop the container's storage
 	if err := c.teardownStorage(); err != nil {
 		reportErrorf("cleaning up storage: %w", err)
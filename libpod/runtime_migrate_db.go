@@ -0,0 +1,310 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// migrateDB copies the state of all containers, pods and volumes to the
+// database backend requested with WithMigrateDatabase and switches the
+// runtime over to it. The old database is renamed and kept as a backup.
+// The migration is refused while containers are running: their cleanup
+// processes would recreate the old database, which Podman picks when no
+// database backend is configured.
+func (r *Runtime) migrateDB() (retErr error) {
+	current := r.config.Engine.DBBackend
+	target := r.migrateDBBackend
+	if current == target {
+		return fmt.Errorf("the database backend is already %s: %w", target, define.ErrInvalidArg)
+	}
+
+	boltPath := boltStatePath(r)
+	sqlitePath := filepath.Join(sqliteStateDir(r), "db.sql")
+	oldPath, newPath := boltPath, sqlitePath
+	if target == config.DBBackendBoltDB.String() {
+		oldPath, newPath = sqlitePath, boltPath
+	}
+
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("database %s already exists, move it away before migrating to %s: %w", newPath, target, define.ErrInvalidArg)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := checkNoActiveContainers(r.state); err != nil {
+		return err
+	}
+
+	logrus.Infof("Migrating the database from %s to %s", current, target)
+
+	var (
+		newState State
+		err      error
+	)
+	if target == config.DBBackendBoltDB.String() {
+		newState, err = NewBoltState(newPath, r)
+	} else {
+		newState, err = NewSqliteState(r)
+	}
+	if err != nil {
+		return fmt.Errorf("creating %s database: %w", target, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if err := newState.Close(); err != nil {
+				logrus.Errorf("Closing %s database: %v", target, err)
+			}
+			if err := os.Remove(newPath); err != nil {
+				logrus.Errorf("Removing incomplete %s database: %v", target, err)
+			}
+		}
+	}()
+
+	if err := copyState(r.state, newState); err != nil {
+		return fmt.Errorf("copying state to %s database: %w", target, err)
+	}
+	if err := newState.ValidateDBConfig(r); err != nil {
+		return fmt.Errorf("validating %s database: %w", target, err)
+	}
+	if err := compareStates(r.state, newState); err != nil {
+		return fmt.Errorf("validating %s database: %w", target, err)
+	}
+
+	if err := r.state.Close(); err != nil {
+		return fmt.Errorf("closing %s database: %w", current, err)
+	}
+	backupPath := fmt.Sprintf("%s.%s.bak", oldPath, time.Now().Format("20060102150405"))
+	if err := os.Rename(oldPath, backupPath); err != nil {
+		// The old database has been closed, keep using the new one.
+		r.state = newState
+		r.config.Engine.DBBackend = target
+		return fmt.Errorf("moving %s database to %s, remove it or set database_backend to %q in containers.conf: %w", current, backupPath, target, err)
+	}
+	r.state = newState
+	r.config.Engine.DBBackend = target
+
+	fmt.Printf("Migrated the database from %s to %s, the old database is kept at %s\n", current, target, backupPath)
+	fmt.Printf("If database_backend is set in containers.conf, change it to %q\n", target)
+	return nil
+}
+
+// checkNoActiveContainers returns an error if a container of the state is
+// running, paused or stopping.  Their exit commands refer to the current
+// database backend.
+func checkNoActiveContainers(state State) error {
+	ctrs, err := state.AllContainers(true)
+	if err != nil {
+		return err
+	}
+	var active []string
+	for _, ctr := range ctrs {
+		switch ctr.state.State {
+		case define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopping:
+			active = append(active, ctr.Name())
+		}
+	}
+	if len(active) > 0 {
+		return fmt.Errorf("cannot migrate the database while containers are running, stop them first: %s: %w", strings.Join(active, ", "), define.ErrCtrStateInvalid)
+	}
+	return nil
+}
+
+// copyState copies all volumes, pods and containers, including their state,
+// network attachments, exit codes and exec sessions, from one state to
+// another.
+func copyState(from, to State) error {
+	vols, err := from.AllVolumes()
+	if err != nil {
+		return err
+	}
+	for _, vol := range vols {
+		if err := from.UpdateVolume(vol); err != nil {
+			return fmt.Errorf("retrieving state of volume %s: %w", vol.Name(), err)
+		}
+		if err := to.AddVolume(vol); err != nil {
+			return fmt.Errorf("adding volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	pods, err := from.AllPods()
+	if err != nil {
+		return err
+	}
+	podsByID := make(map[string]*Pod, len(pods))
+	infraIDs := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		if err := from.UpdatePod(pod); err != nil {
+			return fmt.Errorf("retrieving state of pod %s: %w", pod.ID(), err)
+		}
+		// The infra container is not in the new database yet, it is
+		// set again once all containers have been added.
+		infraIDs[pod.ID()] = pod.state.InfraContainerID
+		pod.state.InfraContainerID = ""
+		if err := to.AddPod(pod); err != nil {
+			return fmt.Errorf("adding pod %s: %w", pod.ID(), err)
+		}
+		podsByID[pod.ID()] = pod
+	}
+
+	ctrs, err := from.AllContainers(true)
+	if err != nil {
+		return err
+	}
+	ordered, err := orderByDependencies(ctrs)
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ordered {
+		networks, err := from.GetNetworks(ctr)
+		if err != nil {
+			return fmt.Errorf("retrieving networks of container %s: %w", ctr.ID(), err)
+		}
+		ctr.config.Networks = networks
+
+		if ctr.config.Pod != "" {
+			pod, ok := podsByID[ctr.config.Pod]
+			if !ok {
+				return fmt.Errorf("container %s belongs to pod %s which does not exist: %w", ctr.ID(), ctr.config.Pod, define.ErrNoSuchPod)
+			}
+			err = to.AddContainerToPod(pod, ctr)
+		} else {
+			err = to.AddContainer(ctr)
+		}
+		if err != nil {
+			return fmt.Errorf("adding container %s: %w", ctr.ID(), err)
+		}
+
+		exitCode, err := from.GetContainerExitCode(ctr.ID())
+		switch {
+		case err == nil:
+			if err := to.AddContainerExitCode(ctr.ID(), exitCode); err != nil {
+				return fmt.Errorf("adding exit code of container %s: %w", ctr.ID(), err)
+			}
+		case !errors.Is(err, define.ErrNoSuchExitCode):
+			return fmt.Errorf("retrieving exit code of container %s: %w", ctr.ID(), err)
+		}
+
+		sessions, err := from.GetContainerExecSessions(ctr)
+		if err != nil {
+			return fmt.Errorf("retrieving exec sessions of container %s: %w", ctr.ID(), err)
+		}
+		for _, id := range sessions {
+			session, ok := ctr.state.ExecSessions[id]
+			if !ok {
+				session = &ExecSession{Id: id, ContainerId: ctr.ID()}
+			}
+			if err := to.AddExecSession(ctr, session); err != nil {
+				return fmt.Errorf("adding exec session %s of container %s: %w", id, ctr.ID(), err)
+			}
+		}
+	}
+
+	for _, pod := range pods {
+		if infraIDs[pod.ID()] == "" {
+			continue
+		}
+		pod.state.InfraContainerID = infraIDs[pod.ID()]
+		if err := to.SavePod(pod); err != nil {
+			return fmt.Errorf("saving pod %s: %w", pod.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// orderByDependencies sorts containers so that every container comes after
+// the containers it depends on.
+func orderByDependencies(ctrs []*Container) ([]*Container, error) {
+	pending := make(map[string]*Container, len(ctrs))
+	for _, ctr := range ctrs {
+		pending[ctr.ID()] = ctr
+	}
+
+	ordered := make([]*Container, 0, len(ctrs))
+	for len(pending) > 0 {
+		progress := false
+		for _, ctr := range ctrs {
+			if _, ok := pending[ctr.ID()]; !ok {
+				continue
+			}
+			ready := true
+			for _, dep := range ctr.Dependencies() {
+				if _, ok := pending[dep]; ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, ctr)
+				delete(pending, ctr.ID())
+				progress = true
+			}
+		}
+		if !progress {
+			for id := range pending {
+				return nil, fmt.Errorf("container %s has a dependency cycle: %w", id, define.ErrInternal)
+			}
+		}
+	}
+	return ordered, nil
+}
+
+// compareStates verifies that two states contain the same containers, pods
+// and volumes.
+func compareStates(a, b State) error {
+	aCtrs, err := a.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	bCtrs, err := b.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	if len(aCtrs) != len(bCtrs) {
+		return fmt.Errorf("found %d containers, expected %d: %w", len(bCtrs), len(aCtrs), define.ErrInternal)
+	}
+	for _, ctr := range aCtrs {
+		exists, err := b.HasContainer(ctr.ID())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("container %s is missing: %w", ctr.ID(), define.ErrInternal)
+		}
+	}
+
+	aPods, err := a.AllPods()
+	if err != nil {
+		return err
+	}
+	bPods, err := b.AllPods()
+	if err != nil {
+		return err
+	}
+	if len(aPods) != len(bPods) {
+		return fmt.Errorf("found %d pods, expected %d: %w", len(bPods), len(aPods), define.ErrInternal)
+	}
+
+	aVols, err := a.AllVolumes()
+	if err != nil {
+		return err
+	}
+	bVols, err := b.AllVolumes()
+	if err != nil {
+		return err
+	}
+	if len(aVols) != len(bVols) {
+		return fmt.Errorf("found %d volumes, expected %d: %w", len(bVols), len(aVols), define.ErrInternal)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/libnetwork/types"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/lock"
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyStateBoltToSqlite(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	lockManager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	runtime := new(Runtime)
+	runtime.config = new(config.Config)
+	runtime.config.Engine.StaticDir = tmpDir
+	runtime.storageConfig = storage.StoreOptions{}
+	runtime.lockManager = lockManager
+
+	from, err := NewBoltState(filepath.Join(tmpDir, "bolt_state.db"), runtime)
+	require.NoError(t, err)
+	defer from.Close()
+	to, err := NewSqliteState(runtime)
+	require.NoError(t, err)
+	defer to.Close()
+
+	pod, err := getTestPodN("4", lockManager)
+	require.NoError(t, err)
+	require.NoError(t, from.AddPod(pod))
+
+	infraCtr, err := getTestCtr1(lockManager)
+	require.NoError(t, err)
+	infraCtr.config.Pod = pod.ID()
+	infraCtr.config.NetMode = "bridge"
+	infraCtr.config.Networks = map[string]types.PerNetworkOptions{
+		"podman": {InterfaceName: "eth0", Aliases: []string{"web"}},
+	}
+	require.NoError(t, from.AddContainerToPod(pod, infraCtr))
+	pod.state.InfraContainerID = infraCtr.ID()
+	require.NoError(t, from.SavePod(pod))
+
+	podCtr, err := getTestCtr2(lockManager)
+	require.NoError(t, err)
+	podCtr.config.Pod = pod.ID()
+	podCtr.config.NetNsCtr = infraCtr.ID()
+	require.NoError(t, from.AddContainerToPod(pod, podCtr))
+
+	ctr, err := getTestCtrN("3", lockManager)
+	require.NoError(t, err)
+	ctr.state.ExecSessions = map[string]*ExecSession{
+		"exec1": {Id: "exec1", ContainerId: ctr.ID(), State: define.ExecStateRunning},
+	}
+	require.NoError(t, from.AddContainer(ctr))
+	require.NoError(t, from.AddExecSession(ctr, ctr.state.ExecSessions["exec1"]))
+	require.NoError(t, from.AddContainerExitCode(ctr.ID(), 42))
+
+	require.NoError(t, copyState(from, to))
+	require.NoError(t, compareStates(from, to))
+
+	copiedPod, err := to.Pod(pod.ID())
+	require.NoError(t, err)
+	require.NoError(t, to.UpdatePod(copiedPod))
+	assert.Equal(t, infraCtr.ID(), copiedPod.state.InfraContainerID)
+	podCtrs, err := to.PodContainersByID(copiedPod)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{infraCtr.ID(), podCtr.ID()}, podCtrs)
+
+	copiedInfra, err := to.Container(infraCtr.ID())
+	require.NoError(t, err)
+	networks, err := to.GetNetworks(copiedInfra)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, networks["podman"].Aliases)
+
+	copiedCtr, err := to.Container(ctr.ID())
+	require.NoError(t, err)
+	require.NoError(t, to.UpdateContainer(copiedCtr))
+	assert.Equal(t, define.ContainerStateRunning, copiedCtr.state.State)
+	sessions, err := to.GetContainerExecSessions(copiedCtr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exec1"}, sessions)
+	exitCode, err := to.GetContainerExitCode(ctr.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), exitCode)
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	lockManager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	ctr1, err := getTestCtr1(lockManager)
+	require.NoError(t, err)
+	ctr2, err := getTestCtr2(lockManager)
+	require.NoError(t, err)
+	ctr2.config.IPCNsCtr = ctr1.ID()
+	ctr3, err := getTestCtrN("3", lockManager)
+	require.NoError(t, err)
+	ctr3.config.Dependencies = []string{ctr2.ID()}
+
+	ordered, err := orderByDependencies([]*Container{ctr3, ctr2, ctr1})
+	require.NoError(t, err)
+	require.Len(t, ordered, 3)
+	assert.Equal(t, ctr1.ID(), ordered[0].ID())
+	assert.Equal(t, ctr2.ID(), ordered[1].ID())
+	assert.Equal(t, ctr3.ID(), ordered[2].ID())
+
+	ctr1.config.Dependencies = []string{ctr3.ID()}
+	_, err = orderByDependencies([]*Container{ctr3, ctr2, ctr1})
+	assert.ErrorIs(t, err, define.ErrInternal)
+}
+
+func TestMigrateDBDefaultBackend(t *testing.T) {
+	r := getCheckTestRuntime(t, "boltdb")
+	ctr := addCheckTestCtr(t, r, "1")
+	r.config.Engine.DBBackend = config.DBBackendBoltDB.String()
+	r.migrateDBBackend = config.DBBackendSQLite.String()
+
+	require.NoError(t, r.migrateDB())
+	assert.Equal(t, config.DBBackendSQLite.String(), r.config.Engine.DBBackend)
+	require.NoError(t, r.state.Close())
+
+	// Without database_backend in containers.conf the next Podman
+	// invocation must pick up the migrated database.
+	r.config.Engine.DBBackend = ""
+	state, err := getDBState(r)
+	require.NoError(t, err)
+	r.state = state
+	assert.Equal(t, config.DBBackendSQLite.String(), r.config.Engine.DBBackend)
+	_, err = state.Container(ctr.ID())
+	require.NoError(t, err)
+}
+
+func TestMigrateDBRunningContainer(t *testing.T) {
+	r := getCheckTestRuntime(t, "boltdb")
+	ctr := addCheckTestCtr(t, r, "1")
+	ctr.state.State = define.ContainerStateRunning
+	require.NoError(t, r.state.SaveContainer(ctr))
+	r.config.Engine.DBBackend = config.DBBackendBoltDB.String()
+	r.migrateDBBackend = config.DBBackendSQLite.String()
+
+	err := r.migrateDB()
+	require.ErrorIs(t, err, define.ErrCtrStateInvalid)
+	assert.Contains(t, err.Error(), ctr.Name())
+	assert.NoFileExists(t, filepath.Join(sqliteStateDir(r), "db.sql"))
+	assert.FileExists(t, boltStatePath(r))
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/libpod/events"
@@ -106,19 +107,19 @@ func (r *Runtime) AddInfra(ctx context.Context, pod *Pod, infraCtr *Container) (
 	if err := pod.save(); err != nil {
 		return nil, err
 	}
-	pod.newPodEvent(events.Create)
+	pod.newPodEvent(ctx, events.Create)
 	return pod, nil
 }
 
 // SavePod is a helper function to save the pod state from outside of libpod
-func (r *Runtime) SavePod(pod *Pod) error {
+func (r *Runtime) SavePod(ctx context.Context, pod *Pod) error {
 	if !r.valid {
 		return define.ErrRuntimeStopped
 	}
 	if err := pod.save(); err != nil {
 		return err
 	}
-	pod.newPodEvent(events.Create)
+	pod.newPodEvent(ctx, events.Create)
 	return nil
 }
 
@@ -294,7 +295,11 @@ func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool,
 
 	// Mark pod invalid
 	p.valid = false
-	p.newPodEvent(events.Remove)
+	p.newPodEvent(ctx, events.Remove)
+
+	if err := os.Remove(p.logQuotaUsagePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Errorf("Removing log quota usage of pod %s: %v", p.ID(), err)
+	}
 
 	// Deallocate the pod lock
 	if err := p.lock.Free(); err != nil {
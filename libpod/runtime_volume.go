@@ -142,7 +142,7 @@ func (r *Runtime) PruneVolumes(ctx context.Context, filterFuncs []VolumeFilter)
 				continue
 			}
 		} else {
-			vol.newVolumeEvent(events.Prune)
+			vol.newVolumeEvent(ctx, events.Prune)
 		}
 		preports = append(preports, report)
 	}
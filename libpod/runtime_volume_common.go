@@ -229,7 +229,7 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 	if err := r.state.AddVolume(volume); err != nil {
 		return nil, fmt.Errorf("adding volume to state: %w", err)
 	}
-	defer volume.newVolumeEvent(events.Create)
+	defer volume.newVolumeEvent(ctx, events.Create)
 	return volume, nil
 }
 
@@ -481,7 +481,7 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool, timeo
 		}
 	}
 
-	defer v.newVolumeEvent(events.Remove)
+	defer v.newVolumeEvent(ctx, events.Remove)
 	logrus.Debugf("Removed volume %s", v.Name())
 	return removalErr
 }
@@ -106,6 +106,14 @@ func Uninhibit() {
 	shutdownInhibit.RUnlock()
 }
 
+// Block waits until no operation inhibits signals from shutting down Libpod
+// and blocks further ones, like the signal handler does before running the
+// on-signal handlers.  It is meant for processes handling the signals
+// themselves, which exit afterwards, so it cannot be undone.
+func Block() {
+	shutdownInhibit.Lock()
+}
+
 // Register registers a function that will be executed when Podman is terminated
 // by a signal. Handlers are invoked LIFO - the last handler registered is the
 // first run.
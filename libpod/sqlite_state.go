@@ -53,17 +53,23 @@ const (
 		sqliteOptionBusyTimeout
 )
 
-// NewSqliteState creates a new SQLite-backed state database.
-func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
-	logrus.Info("Using sqlite as database backend")
-	state := new(SQLiteState)
-
+// sqliteStateDir returns the directory of the SQLite state database.
+func sqliteStateDir(runtime *Runtime) string {
 	basePath := runtime.storageConfig.GraphRoot
 	if runtime.storageConfig.TransientStore {
 		basePath = runtime.storageConfig.RunRoot
 	} else if !runtime.storageSet.StaticDirSet {
 		basePath = runtime.config.Engine.StaticDir
 	}
+	return basePath
+}
+
+// NewSqliteState creates a new SQLite-backed state database.
+func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
+	logrus.Info("Using sqlite as database backend")
+	state := new(SQLiteState)
+
+	basePath := sqliteStateDir(runtime)
 
 	// c/storage is set up *after* the DB - so even though we use the c/s
 	// root (or, for transient, runroot) dir, we need to make the dir
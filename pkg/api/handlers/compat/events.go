@@ -26,9 +26,10 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	// NOTE: the "filters" parameter is extracted separately for backwards
 	// compat via `filterFromRequest()`.
 	query := struct {
-		Since  string `schema:"since"`
-		Until  string `schema:"until"`
-		Stream bool   `schema:"stream"`
+		Since       string `schema:"since"`
+		Until       string `schema:"until"`
+		Stream      bool   `schema:"stream"`
+		AfterCursor string `schema:"afterCursor"`
 	}{
 		Stream: true,
 	}
@@ -58,6 +59,7 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 			EventChannel: eventChannel,
 			Since:        query.Since,
 			Until:        query.Until,
+			AfterCursor:  query.AfterCursor,
 		}
 		errorChannel <- runtime.Events(r.Context(), readOpts)
 	}()
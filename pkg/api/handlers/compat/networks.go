@@ -438,7 +438,7 @@ func Connect(w http.ResponseWriter, r *http.Request) {
 			netOpts.StaticMAC = nettypes.HardwareAddr(staticMac)
 		}
 	}
-	err := runtime.ConnectContainerToNetwork(netConnect.Container, name, netOpts)
+	err := runtime.ConnectContainerToNetwork(r.Context(), netConnect.Container, name, netOpts)
 	if err != nil {
 		if errors.Is(err, define.ErrNoSuchCtr) {
 			utils.ContainerNotFound(w, netConnect.Container, err)
@@ -469,7 +469,7 @@ func Disconnect(w http.ResponseWriter, r *http.Request) {
 	}
 
 	name, _ := normalizeNetworkName(runtime, utils.GetName(r))
-	err := runtime.DisconnectContainerFromNetwork(netDisconnect.Container, name, netDisconnect.Force)
+	err := runtime.DisconnectContainerFromNetwork(r.Context(), netDisconnect.Container, name, netDisconnect.Force)
 	if err != nil {
 		if errors.Is(err, define.ErrNoSuchCtr) {
 			utils.Error(w, http.StatusNotFound, err)
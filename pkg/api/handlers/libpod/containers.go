@@ -16,6 +16,7 @@ import (
 	api "github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/gorilla/schema"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -402,6 +403,25 @@ func InitContainer(w http.ResponseWriter, r *http.Request) {
 func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		RestartPolicy     string   `schema:"restartPolicy"`
+		HealthCmd         string   `schema:"healthCmd"`
+		HealthInterval    string   `schema:"healthInterval"`
+		HealthRetries     uint     `schema:"healthRetries"`
+		HealthStartPeriod string   `schema:"healthStartPeriod"`
+		HealthTimeout     string   `schema:"healthTimeout"`
+		HealthOnFailure   string   `schema:"healthOnFailure"`
+		Env               []string `schema:"env"`
+		UnsetEnv          []string `schema:"unsetEnv"`
+		Labels            []string `schema:"labels"`
+		UnsetLabels       []string `schema:"unsetLabels"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
 	ctr, err := runtime.LookupContainer(name)
 	if err != nil {
 		utils.ContainerNotFound(w, name, err)
@@ -413,8 +433,45 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("decode(): %w", err))
 		return
 	}
-	err = ctr.Update(options.Resources)
-	if err != nil {
+
+	updateOptions := &entities.ContainerUpdateOptions{
+		NameOrID:    ctr.ID(),
+		Specgen:     &specgen.SpecGenerator{},
+		Env:         query.Env,
+		UnsetEnv:    query.UnsetEnv,
+		Labels:      query.Labels,
+		UnsetLabels: query.UnsetLabels,
+	}
+	updateOptions.Specgen.ResourceLimits = options.Resources
+	params := r.URL.Query()
+	if _, found := params["restartPolicy"]; found {
+		updateOptions.Restart = &query.RestartPolicy
+	}
+	if _, found := params["healthCmd"]; found {
+		updateOptions.HealthCmd = &query.HealthCmd
+	}
+	if _, found := params["healthInterval"]; found {
+		updateOptions.HealthInterval = &query.HealthInterval
+	}
+	if _, found := params["healthRetries"]; found {
+		updateOptions.HealthRetries = &query.HealthRetries
+	}
+	if _, found := params["healthStartPeriod"]; found {
+		updateOptions.HealthStartPeriod = &query.HealthStartPeriod
+	}
+	if _, found := params["healthTimeout"]; found {
+		updateOptions.HealthTimeout = &query.HealthTimeout
+	}
+	if _, found := params["healthOnFailure"]; found {
+		updateOptions.HealthOnFailure = &query.HealthOnFailure
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	if _, err := containerEngine.ContainerUpdate(r.Context(), updateOptions); err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
 		utils.InternalServerError(w, err)
 		return
 	}
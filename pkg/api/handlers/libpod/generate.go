@@ -95,6 +95,8 @@ func GenerateKube(w http.ResponseWriter, r *http.Request) {
 		Type       string   `schema:"type"`
 		Replicas   int32    `schema:"replicas"`
 		NoTrunc    bool     `schema:"noTrunc"`
+		ConfigMaps bool     `schema:"configMaps"`
+		SecretData bool     `schema:"secretData"`
 	}{
 		// Defaults would go here.
 		Replicas: 1,
@@ -123,6 +125,8 @@ func GenerateKube(w http.ResponseWriter, r *http.Request) {
 		Type:               generateType,
 		Replicas:           query.Replicas,
 		UseLongAnnotations: query.NoTrunc,
+		ConfigMaps:         query.ConfigMaps,
+		SecretData:         query.SecretData,
 	}
 	report, err := containerEngine.GenerateKube(r.Context(), query.Names, options)
 	if err != nil {
@@ -1,12 +1,19 @@
 package libpod
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/api/handlers/utils"
 	api "github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/sirupsen/logrus"
 )
 
 func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
@@ -40,3 +47,99 @@ func RunHealthCheck(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+func InspectHealthCheck(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.HealthCheckInspect(r.Context(), name, entities.HealthCheckInspectOptions{})
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchCtr):
+			utils.ContainerNotFound(w, name, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusConflict, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func HealthCheckLogs(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		Follow bool   `schema:"follow"`
+		Since  string `schema:"since"`
+		Status string `schema:"status"`
+		Until  string `schema:"until"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	options := define.HealthCheckHistoryOptions{
+		Follow: query.Follow,
+		Status: query.Status,
+	}
+	var err error
+	if query.Since != "" {
+		options.Since, err = util.ParseInputTime(query.Since, true)
+		if err != nil {
+			utils.BadRequest(w, "since", query.Since, err)
+			return
+		}
+	}
+	if query.Until != "" {
+		options.Until, err = util.ParseInputTime(query.Until, false)
+		if err != nil {
+			utils.BadRequest(w, "until", query.Until, err)
+			return
+		}
+	}
+	if err := define.ValidateHealthCheckHistoryStatus(query.Status); err != nil {
+		utils.BadRequest(w, "status", query.Status, err)
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if !ctr.HasHealthCheck() {
+		utils.Error(w, http.StatusConflict, fmt.Errorf("container %s has no defined healthcheck: %w", ctr.ID(), define.ErrInvalidArg))
+		return
+	}
+
+	entries := make(chan *define.HealthCheckHistoryEntry)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ctr.HealthCheckHistory(r.Context(), options, entries)
+	}()
+
+	flush := func() {}
+	if flusher, ok := w.(http.Flusher); ok {
+		flush = flusher.Flush
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush()
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	for entry := range entries {
+		if err := coder.Encode(entry); err != nil {
+			logrus.Errorf("Unable to write json: %q", err)
+		}
+		flush()
+	}
+	if err := <-errChan; err != nil {
+		logrus.Errorf("Reading healthcheck history of container %s: %v", ctr.ID(), err)
+	}
+}
@@ -35,9 +35,12 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		TLSVerify        bool              `schema:"tlsVerify"`
 		Userns           string            `schema:"userns"`
 		Wait             bool              `schema:"wait"`
+		WaitReady        bool              `schema:"waitReady"`
+		WaitReadyTimeout uint              `schema:"waitReadyTimeout"`
 	}{
-		TLSVerify: true,
-		Start:     true,
+		TLSVerify:        true,
+		Start:            true,
+		WaitReadyTimeout: entities.DefaultPlayKubeWaitReadyTimeout,
 	}
 
 	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
@@ -108,6 +111,8 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		Username:           username,
 		Userns:             query.Userns,
 		Wait:               query.Wait,
+		WaitReady:          query.WaitReady,
+		WaitReadyTimeout:   query.WaitReadyTimeout,
 	}
 	if _, found := r.URL.Query()["tlsVerify"]; found {
 		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
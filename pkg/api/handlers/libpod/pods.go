@@ -73,7 +73,7 @@ func PodCreate(w http.ResponseWriter, r *http.Request) {
 		psg.InfraContainerSpec.RawImageName = psg.InfraImage
 	}
 	podSpecComplete := entities.PodSpec{PodSpecGen: psg}
-	pod, err := generate.MakePod(&podSpecComplete, runtime)
+	pod, err := generate.MakePod(r.Context(), &podSpecComplete, runtime)
 	if err != nil {
 		httpCode := http.StatusInternalServerError
 		if errors.Is(err, define.ErrPodExists) {
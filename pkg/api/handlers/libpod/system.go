@@ -53,6 +53,36 @@ func SystemPrune(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
+// SystemCheck verifies the consistency of the libpod state
+func SystemCheck(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	query := struct {
+		Quick  bool `schema:"quick"`
+		Repair bool `schema:"repair"`
+	}{}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest,
+			fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	checkOptions := entities.SystemCheckOptions{
+		Quick:  query.Quick,
+		Repair: query.Repair,
+	}
+	report, err := containerEngine.SystemCheck(r.Context(), checkOptions)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
 func DiskUsage(w http.ResponseWriter, r *http.Request) {
 	// Options are only used by the CLI
 	options := entities.SystemDfOptions{}
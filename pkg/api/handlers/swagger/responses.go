@@ -180,6 +180,20 @@ type healthCheck struct {
 	Body define.HealthCheckResults
 }
 
+// Healthcheck inspect
+// swagger:response
+type healthCheckInspect struct {
+	// in:body
+	Body entities.HealthCheckInspectReport
+}
+
+// Healthcheck history
+// swagger:response
+type healthCheckLogs struct {
+	// in:body
+	Body define.HealthCheckHistoryEntry
+}
+
 // Version
 // swagger:response
 type versionResponse struct {
@@ -201,6 +215,20 @@ type systemPruneResponse struct {
 	Body entities.SystemPruneReport
 }
 
+// System Check results
+// swagger:response
+type systemCheckResponse struct {
+	// in:body
+	Body define.SystemCheckReport
+}
+
+// System Drain results
+// swagger:response
+type systemDrainResponse struct {
+	// in:body
+	Body entities.ServiceDrainReport
+}
+
 // Auth response
 // swagger:response
 type systemAuthResponse struct {
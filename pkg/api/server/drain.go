@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/containers/podman/v4/libpod/shutdown"
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	"github.com/containers/podman/v4/pkg/api/server/idle"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/coreos/go-systemd/v22/daemon"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultDrainTimeout is how long a drain requested via the API waits for the
+// requests in flight if the service was started without a drain timeout.
+const DefaultDrainTimeout = 60 * time.Second
+
+// drainStatusInterval is how often the requests still in flight are reported
+// while draining.
+const drainStatusInterval = 5 * time.Second
+
+// inFlightHandler tracks the requests in flight to wait for them when draining
+func inFlightHandler(tracker *idle.Tracker) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer tracker.TrackRequest(r)()
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Drain stops accepting new connections and shuts the service down once the
+// requests in flight finished, at the latest after timeout.  It returns the
+// deadline and does not wait for the requests.
+func (s *APIServer) Drain(timeout time.Duration) time.Time {
+	s.drainOnce.Do(func() {
+		s.drainDeadline = time.Now().Add(timeout)
+		s.draining.Store(true)
+		go s.drain()
+	})
+	return s.drainDeadline
+}
+
+func (s *APIServer) drain() {
+	defer close(s.drained)
+
+	requests := s.idleTracker.Requests()
+	logrus.Infof("API service draining, waiting up to %s for %d request(s) in flight", time.Until(s.drainDeadline).Round(time.Second), len(requests))
+	for _, req := range requests {
+		logrus.Infof("API request in flight since %s: %s %s", req.Start.Format(time.RFC3339), req.Method, req.Path)
+	}
+	s.notifySystemd(daemon.SdNotifyStopping + "\n" + drainStatus(len(requests)))
+
+	// Shutdown stops accepting new connections right away and closes idle
+	// ones, it does not wait for hijacked connections though.
+	ctx, cancel := context.WithDeadline(context.Background(), s.drainDeadline)
+	defer cancel()
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- s.Server.Shutdown(ctx)
+	}()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	lastStatus := time.Now()
+	shutdownDone := false
+	for {
+		select {
+		case err := <-shutdownErr:
+			shutdownDone = true
+			if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+				logrus.Errorf("Failed to drain API service: %v", err)
+			}
+		case <-ctx.Done():
+			requests := s.idleTracker.Requests()
+			logrus.Warnf("API service drain timed out, closing %d request(s) still in flight", len(requests))
+			for _, req := range requests {
+				logrus.Warnf("Closing API request in flight since %s: %s %s", req.Start.Format(time.RFC3339), req.Method, req.Path)
+			}
+			s.stop()
+			return
+		case now := <-ticker.C:
+			requests := s.idleTracker.Requests()
+			if shutdownDone && len(requests) == 0 {
+				logrus.Info("API service drained")
+				s.stop()
+				return
+			}
+			if now.Sub(lastStatus) >= drainStatusInterval {
+				lastStatus = now
+				logrus.Infof("API service draining, %d request(s) in flight", len(requests))
+				s.notifySystemd(drainStatus(len(requests)))
+			}
+		}
+	}
+}
+
+// stop closes all connections and releases the resources of the service.  It
+// first waits for the containers being created, as the shutdown signal
+// handler does.
+func (s *APIServer) stop() {
+	s.stopOnce.Do(func() {
+		logrus.Debug("API service waiting for operations inhibiting the shutdown")
+		shutdown.Block()
+	})
+	if err := s.Server.Close(); err != nil {
+		logrus.Errorf("Failed to close API service: %v", err)
+	}
+	s.CancelFunc()
+}
+
+func drainStatus(inFlight int) string {
+	return fmt.Sprintf("STATUS=Draining, %d request(s) in flight", inFlight)
+}
+
+// drainOnSignal drains the service on SIGTERM or SIGINT instead of shutting
+// it down right away.  A second signal closes the requests still in flight
+// once the containers being created are.
+func (s *APIServer) drainOnSignal() error {
+	// The shutdown handlers block requests creating containers until they
+	// finished, so the service handles the signals itself.  stop honours
+	// shutdown.Inhibit the same way.
+	if err := shutdown.Stop(); err != nil && !errors.Is(err, shutdown.ErrNotStarted) {
+		return err
+	}
+	sigChan := make(chan os.Signal, 2)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		logrus.Infof("Received shutdown signal %q, draining API service", sig.String())
+		s.Drain(s.drainTimeout)
+		select {
+		case sig := <-sigChan:
+			logrus.Infof("Received shutdown signal %q while draining, closing API service", sig.String())
+			s.stop()
+		case <-s.drained:
+		}
+		signal.Stop(sigChan)
+	}()
+	return nil
+}
+
+// notifySystemd sends state to the systemd notify socket the service was
+// started with, if any.
+func (s *APIServer) notifySystemd(state string) {
+	if s.notifySocket == "" {
+		return
+	}
+	addr := &net.UnixAddr{Name: s.notifySocket, Net: "unixgram"}
+	conn, err := net.DialUnix(addr.Net, nil, addr)
+	if err != nil {
+		logrus.Warnf("API service unable to notify systemd: %v", err)
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		logrus.Warnf("API service unable to notify systemd: %v", err)
+	}
+}
+
+// drainHandler starts draining the service and reports the requests in flight
+func (s *APIServer) drainHandler(w http.ResponseWriter, r *http.Request) {
+	query := struct {
+		Timeout *uint `schema:"timeout"`
+	}{}
+	if err := utils.GetDecoder(r).Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	timeout := s.drainTimeout
+	switch {
+	case query.Timeout != nil:
+		timeout = time.Duration(*query.Timeout) * time.Second
+	case timeout == 0:
+		timeout = DefaultDrainTimeout
+	}
+
+	report := entities.ServiceDrainReport{Requests: []entities.ServiceRequest{}}
+	for _, req := range s.idleTracker.Requests() {
+		// Leave out the drain request itself.
+		if req.Path == r.URL.Path && req.Method == r.Method {
+			continue
+		}
+		report.Requests = append(report.Requests, entities.ServiceRequest{
+			Method:  req.Method,
+			Path:    req.Path,
+			Started: req.Start,
+		})
+	}
+	report.Deadline = s.Drain(timeout)
+	utils.WriteResponse(w, http.StatusOK, report)
+}
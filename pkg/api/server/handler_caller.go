@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/pkg/api/types"
+	"github.com/containers/podman/v4/pkg/rootless"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// imageEventPaths are the parts of the paths of requests which may cause image
+// events, e.g., by pulling an image.
+var imageEventPaths = []string{"/images/", "/build", "/commit", "/manifests/", "/containers/create", "/pods/create", "/play/kube", "/kube/play"}
+
+// callerHandler records the client of the request as the caller of the events
+// caused by it
+func callerHandler(runtime *libpod.Runtime) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c, ok := r.Context().Value(types.ConnKey).(net.Conn)
+			if !ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+			r = r.WithContext(events.WithCaller(r.Context(), connCaller(c)))
+			for _, p := range imageEventPaths {
+				if strings.Contains(r.URL.Path, p) {
+					defer runtime.TrackImageEventCaller(r.Context())()
+					break
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// connCaller identifies the client connected over c by its peer credentials
+// on a unix socket or else by its remote address.
+func connCaller(c net.Conn) events.Caller {
+	caller := events.Caller{UID: -1}
+	if unixConn, ok := c.(*net.UnixConn); ok {
+		uid, pid, err := peerCredentials(unixConn)
+		if err == nil {
+			// The rootless service runs in the user namespace where
+			// the user is mapped to root.
+			if uid == 0 && rootless.IsRootless() {
+				uid = rootless.GetRootlessUID()
+			}
+			caller.UID = uid
+			caller.PID = pid
+			return caller
+		}
+		logrus.Debugf("Unable to get the peer credentials of the API client: %v", err)
+		return caller
+	}
+	if addr := c.RemoteAddr(); addr != nil {
+		caller.Address = addr.String()
+	}
+	return caller
+}
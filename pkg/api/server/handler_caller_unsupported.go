@@ -0,0 +1,13 @@
+//go:build !linux
+
+package server
+
+import (
+	"errors"
+	"net"
+)
+
+// peerCredentials returns the UID and PID of the process connected over c.
+func peerCredentials(c *net.UnixConn) (int, int, error) {
+	return 0, 0, errors.New("peer credentials are not supported on this platform")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -19,6 +20,15 @@ type Tracker struct {
 	mux      sync.Mutex            // protect managed map
 	timer    *time.Timer
 	total    int // total number of connections made to this server instance
+
+	requests map[*Request]struct{} // set of requests in flight, protected by mux
+}
+
+// Request describes an API request in flight
+type Request struct {
+	Method string
+	Path   string
+	Start  time.Time
 }
 
 // NewTracker creates and initializes a new Tracker object
@@ -28,6 +38,7 @@ func NewTracker(idle time.Duration) *Tracker {
 		managed:  make(map[net.Conn]struct{}),
 		Duration: idle,
 		timer:    time.NewTimer(idle),
+		requests: make(map[*Request]struct{}),
 	}
 }
 
@@ -101,6 +112,43 @@ func (t *Tracker) TotalConnections() int {
 	return t.total
 }
 
+// Connections returns the number of active and total connections, it is safe
+// to call concurrently with ConnState
+func (t *Tracker) Connections() (active int, total int) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.ActiveConnections(), t.TotalConnections()
+}
+
+// TrackRequest records r as in flight until the returned function is called
+func (t *Tracker) TrackRequest(r *http.Request) func() {
+	req := &Request{Method: r.Method, Path: r.URL.Path, Start: time.Now()}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.requests[req] = struct{}{}
+	return func() {
+		t.mux.Lock()
+		defer t.mux.Unlock()
+		delete(t.requests, req)
+	}
+}
+
+// Requests returns the requests in flight, oldest first.  It is safe to call
+// concurrently with TrackRequest.
+func (t *Tracker) Requests() []Request {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	requests := make([]Request, 0, len(t.requests))
+	for req := range t.requests {
+		requests = append(requests, *req)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Start.Before(requests[j].Start)
+	})
+	return requests
+}
+
 // Done is called when idle timer has expired
 func (t *Tracker) Done() <-chan time.Time {
 	return t.timer.C
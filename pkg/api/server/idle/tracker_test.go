@@ -0,0 +1,33 @@
+package idle
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackerRequests(t *testing.T) {
+	tracker := NewTracker(time.Minute)
+	assert.Empty(t, tracker.Requests())
+
+	doneAttach := tracker.TrackRequest(httptest.NewRequest("POST", "/v4.0.0/libpod/containers/c1/attach", nil))
+	doneWait := tracker.TrackRequest(httptest.NewRequest("POST", "/v4.0.0/libpod/containers/c2/wait", nil))
+
+	requests := tracker.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "/v4.0.0/libpod/containers/c1/attach", requests[0].Path)
+		assert.Equal(t, "POST", requests[0].Method)
+		assert.Equal(t, "/v4.0.0/libpod/containers/c2/wait", requests[1].Path)
+		assert.False(t, requests[1].Start.Before(requests[0].Start))
+	}
+
+	doneAttach()
+	requests = tracker.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "/v4.0.0/libpod/containers/c2/wait", requests[0].Path)
+	}
+	doneWait()
+	assert.Empty(t, tracker.Requests())
+}
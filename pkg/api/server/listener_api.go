@@ -1,6 +1,9 @@
 package server
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -30,3 +33,38 @@ func ListenUnix(network string, path string) (net.Listener, error) {
 
 	return listener, nil
 }
+
+// ListenTLS wraps the given TCP listener so that connections are served over TLS
+//
+//	When clientCAFile is set, clients must present a certificate signed by one of its CAs.
+//	Otherwise any client may connect, the connection is only encrypted.
+func ListenTLS(listener net.Listener, certFile, keyFile, clientCAFile string) (net.Listener, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both a TLS certificate and a TLS key are required to serve the API over TLS")
+	}
+	if network := listener.Addr().Network(); network != "tcp" {
+		return nil, fmt.Errorf("TLS is only supported for tcp listeners, not %q", network)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("api.ListenTLS() failed to load key pair %s, %s: %w", certFile, keyFile, err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("api.ListenTLS() failed to read client CA %s: %w", clientCAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("api.ListenTLS() found no PEM encoded certificates in %s", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tls.NewListener(listener, config), nil
+}
@@ -0,0 +1,382 @@
+// Package metrics exposes the state of the Podman API service and of the
+// containers, pods, images and volumes it manages in the Prometheus text
+// exposition format.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/libpod/events"
+	"github.com/containers/podman/v4/pkg/api/server/idle"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// requestKey identifies the requests accounted in one latency histogram
+type requestKey struct {
+	method, route, code string
+}
+
+// storageTTL is how long the disk usage of images and volumes is cached.
+// Computing it walks the storage, which is too expensive for every scrape.
+const storageTTL = 5 * time.Minute
+
+// eventKey identifies the events accounted in one counter
+type eventKey struct {
+	typ, status string
+}
+
+// Collector gathers API request and event statistics and renders them,
+// together with the current container, pod and storage state, on request
+type Collector struct {
+	runtime  *libpod.Runtime
+	tracker  *idle.Tracker
+	mux      sync.Mutex // protect requests and events
+	requests map[requestKey]*histogram
+	events   map[eventKey]uint64
+
+	systemDf func(context.Context) (*entities.SystemDfReport, error)
+	dfMux    sync.Mutex // protect df and dfTime, serializes SystemDf calls
+	df       *entities.SystemDfReport
+	dfTime   time.Time
+}
+
+// NewCollector creates a Collector for the given runtime, tracker may be nil
+func NewCollector(runtime *libpod.Runtime, tracker *idle.Tracker) *Collector {
+	ic := abi.ContainerEngine{Libpod: runtime}
+	return &Collector{
+		runtime:  runtime,
+		tracker:  tracker,
+		requests: make(map[requestKey]*histogram),
+		events:   make(map[eventKey]uint64),
+		systemDf: func(ctx context.Context) (*entities.SystemDfReport, error) {
+			return ic.SystemDf(ctx, entities.SystemDfOptions{})
+		},
+	}
+}
+
+// statusWriter records the status code written by a handler
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (s *statusWriter) WriteHeader(code int) {
+	if s.code == 0 {
+		s.code = code
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusWriter) Write(b []byte) (int, error) {
+	if s.code == 0 {
+		s.code = http.StatusOK
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+func (s *statusWriter) Flush() {
+	if wrapped, ok := s.ResponseWriter.(http.Flusher); ok {
+		wrapped.Flush()
+	}
+}
+
+func (s *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if wrapped, ok := s.ResponseWriter.(http.Hijacker); ok {
+		// the handler owns the connection from now on, report it as switching protocols
+		s.code = http.StatusSwitchingProtocols
+		return wrapped.Hijack()
+	}
+	return nil, nil, errors.New("ResponseWriter does not support hijacking")
+}
+
+// Middleware records the latency of every request handled by a registered route
+func (c *Collector) Middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w}
+		h.ServeHTTP(sw, r)
+
+		route := "<N/A>"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+		if sw.code == 0 {
+			sw.code = http.StatusOK
+		}
+		key := requestKey{method: r.Method, route: route, code: strconv.Itoa(sw.code)}
+
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		hist, found := c.requests[key]
+		if !found {
+			hist = newHistogram()
+			c.requests[key] = hist
+		}
+		hist.observe(time.Since(start).Seconds())
+	})
+}
+
+// WatchEvents counts the events written by the runtime until ctx is canceled
+func (c *Collector) WatchEvents(ctx context.Context) {
+	eventChannel := make(chan *events.Event)
+	go func() {
+		for e := range eventChannel {
+			if e == nil {
+				continue
+			}
+			c.mux.Lock()
+			c.events[eventKey{typ: e.Type.String(), status: e.Status.String()}]++
+			c.mux.Unlock()
+		}
+	}()
+	go func() {
+		err := c.runtime.Events(ctx, events.ReadOptions{
+			EventChannel: eventChannel,
+			Stream:       true,
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logrus.Warnf("Metrics: unable to read events: %v", err)
+		}
+	}()
+}
+
+// ServeHTTP renders all metrics in the Prometheus text exposition format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	out := &writer{w: w}
+	c.writeService(out)
+	c.writeContainers(out)
+	c.writePods(out)
+	c.writeStorage(r.Context(), out)
+	if out.err != nil {
+		logrus.Debugf("Metrics: unable to write response: %v", out.err)
+	}
+}
+
+// writeService renders the connection, request and event statistics of the service
+func (c *Collector) writeService(out *writer) {
+	if c.tracker != nil {
+		active, total := c.tracker.Connections()
+		out.family("podman_api_connections_active", "Number of open connections to the API service.", "gauge")
+		out.sample("podman_api_connections_active", float64(active))
+		out.family("podman_api_connections_total", "Total number of connections made to the API service.", "counter")
+		out.sample("podman_api_connections_total", float64(total))
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	requests := make([]requestKey, 0, len(c.requests))
+	for k := range c.requests {
+		requests = append(requests, k)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.code < b.code
+	})
+	out.family("podman_api_request_duration_seconds", "Latency of API requests by route, method and status code.", "histogram")
+	for _, k := range requests {
+		out.histogram("podman_api_request_duration_seconds", c.requests[k],
+			label{"method", k.method}, label{"route", k.route}, label{"code", k.code})
+	}
+
+	evts := make([]eventKey, 0, len(c.events))
+	for k := range c.events {
+		evts = append(evts, k)
+	}
+	sort.Slice(evts, func(i, j int) bool {
+		if evts[i].typ != evts[j].typ {
+			return evts[i].typ < evts[j].typ
+		}
+		return evts[i].status < evts[j].status
+	})
+	out.family("podman_events_total", "Number of events since the API service started by type and status.", "counter")
+	for _, k := range evts {
+		out.sample("podman_events_total", float64(c.events[k]), label{"type", k.typ}, label{"status", k.status})
+	}
+}
+
+// writeContainers renders the container state counts, health and resource usage
+func (c *Collector) writeContainers(out *writer) {
+	ctrs, err := c.runtime.GetAllContainers()
+	if err != nil {
+		logrus.Warnf("Metrics: unable to list containers: %v", err)
+		return
+	}
+	sort.Slice(ctrs, func(i, j int) bool { return ctrs[i].Name() < ctrs[j].Name() })
+
+	states := make(map[string]int)
+	type ctrHealth struct {
+		ctr    *libpod.Container
+		status string
+	}
+	var (
+		health []ctrHealth
+		stats  []*define.ContainerStats
+	)
+	for _, ctr := range ctrs {
+		state, err := ctr.State()
+		if err != nil {
+			// the container may have been removed in the meantime
+			logrus.Debugf("Metrics: unable to get state of container %s: %v", ctr.ID(), err)
+			continue
+		}
+		states[state.String()]++
+
+		if ctr.HasHealthCheck() {
+			status, err := ctr.HealthCheckStatus()
+			if err != nil {
+				logrus.Debugf("Metrics: unable to get health of container %s: %v", ctr.ID(), err)
+			} else {
+				health = append(health, ctrHealth{ctr: ctr, status: status})
+			}
+		}
+
+		if state != define.ContainerStateRunning {
+			continue
+		}
+		s, err := ctr.GetContainerStats(nil)
+		if err != nil {
+			logrus.Debugf("Metrics: unable to get stats of container %s: %v", ctr.ID(), err)
+			continue
+		}
+		stats = append(stats, s)
+	}
+
+	out.family("podman_containers", "Number of containers by state.", "gauge")
+	for _, state := range sortedKeys(states) {
+		out.sample("podman_containers", float64(states[state]), label{"state", state})
+	}
+
+	out.family("podman_container_health", "Health check status of containers with a health check, 1 for the current status.", "gauge")
+	for _, h := range health {
+		out.sample("podman_container_health", 1,
+			label{"id", h.ctr.ID()}, label{"name", h.ctr.Name()}, label{"status", h.status})
+	}
+
+	families := []struct {
+		name, help, typ string
+		value           func(*define.ContainerStats) float64
+	}{
+		{"podman_container_cpu_seconds_total", "Total CPU time consumed by the container in seconds.", "counter",
+			func(s *define.ContainerStats) float64 { return float64(s.CPUNano) / float64(time.Second) }},
+		{"podman_container_memory_usage_bytes", "Memory used by the container in bytes.", "gauge",
+			func(s *define.ContainerStats) float64 { return float64(s.MemUsage) }},
+		{"podman_container_memory_limit_bytes", "Memory limit of the container in bytes.", "gauge",
+			func(s *define.ContainerStats) float64 { return float64(s.MemLimit) }},
+		{"podman_container_network_receive_bytes_total", "Bytes received by the container over the network.", "counter",
+			func(s *define.ContainerStats) float64 { return float64(s.NetInput) }},
+		{"podman_container_network_transmit_bytes_total", "Bytes sent by the container over the network.", "counter",
+			func(s *define.ContainerStats) float64 { return float64(s.NetOutput) }},
+		{"podman_container_block_read_bytes_total", "Bytes read by the container from block devices.", "counter",
+			func(s *define.ContainerStats) float64 { return float64(s.BlockInput) }},
+		{"podman_container_block_write_bytes_total", "Bytes written by the container to block devices.", "counter",
+			func(s *define.ContainerStats) float64 { return float64(s.BlockOutput) }},
+		{"podman_container_pids", "Number of processes in the container.", "gauge",
+			func(s *define.ContainerStats) float64 { return float64(s.PIDs) }},
+	}
+	for _, f := range families {
+		out.family(f.name, f.help, f.typ)
+		for _, s := range stats {
+			out.sample(f.name, f.value(s), label{"id", s.ContainerID}, label{"name", s.Name})
+		}
+	}
+}
+
+// writePods renders the pod state counts
+func (c *Collector) writePods(out *writer) {
+	pods, err := c.runtime.GetAllPods()
+	if err != nil {
+		logrus.Warnf("Metrics: unable to list pods: %v", err)
+		return
+	}
+	states := make(map[string]int)
+	for _, pod := range pods {
+		status, err := pod.GetPodStatus()
+		if err != nil {
+			logrus.Debugf("Metrics: unable to get status of pod %s: %v", pod.ID(), err)
+			continue
+		}
+		states[status]++
+	}
+	out.family("podman_pods", "Number of pods by state.", "gauge")
+	for _, state := range sortedKeys(states) {
+		out.sample("podman_pods", float64(states[state]), label{"state", state})
+	}
+}
+
+// diskUsage returns the disk usage of images and volumes, computed at most
+// once per storageTTL
+func (c *Collector) diskUsage(ctx context.Context) (*entities.SystemDfReport, error) {
+	c.dfMux.Lock()
+	defer c.dfMux.Unlock()
+	if c.df != nil && time.Since(c.dfTime) < storageTTL {
+		return c.df, nil
+	}
+	df, err := c.systemDf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.df, c.dfTime = df, time.Now()
+	return df, nil
+}
+
+// writeStorage renders the disk usage of images and volumes
+func (c *Collector) writeStorage(ctx context.Context, out *writer) {
+	df, err := c.diskUsage(ctx)
+	if err != nil {
+		logrus.Warnf("Metrics: unable to get disk usage: %v", err)
+		return
+	}
+
+	out.family("podman_image_size_bytes", "Size of images in bytes.", "gauge")
+	for _, img := range df.Images {
+		out.sample("podman_image_size_bytes", float64(img.Size),
+			label{"id", img.ImageID}, label{"repository", img.Repository}, label{"tag", img.Tag})
+	}
+	out.family("podman_image_unique_size_bytes", "Size of image data not shared with other images in bytes.", "gauge")
+	for _, img := range df.Images {
+		out.sample("podman_image_unique_size_bytes", float64(img.UniqueSize),
+			label{"id", img.ImageID}, label{"repository", img.Repository}, label{"tag", img.Tag})
+	}
+	out.family("podman_volume_size_bytes", "Size of volumes in bytes.", "gauge")
+	for _, vol := range df.Volumes {
+		out.sample("podman_volume_size_bytes", float64(vol.Size), label{"name", vol.VolumeName})
+	}
+	out.family("podman_volume_links", "Number of containers using a volume.", "gauge")
+	for _, vol := range df.Volumes {
+		out.sample("podman_volume_links", float64(vol.Links), label{"name", vol.VolumeName})
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsageCached(t *testing.T) {
+	calls := 0
+	var dfErr error
+	c := &Collector{
+		systemDf: func(context.Context) (*entities.SystemDfReport, error) {
+			calls++
+			return &entities.SystemDfReport{}, dfErr
+		},
+	}
+
+	first, err := c.diskUsage(context.Background())
+	assert.NoError(t, err)
+	second, err := c.diskUsage(context.Background())
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, calls)
+
+	// refreshed once the cached report expired
+	c.dfTime = time.Now().Add(-storageTTL)
+	third, err := c.diskUsage(context.Background())
+	assert.NoError(t, err)
+	assert.NotSame(t, first, third)
+	assert.Equal(t, 2, calls)
+
+	// errors are not cached
+	c.dfTime = time.Now().Add(-storageTTL)
+	dfErr = errors.New("storage busy")
+	_, err = c.diskUsage(context.Background())
+	assert.Error(t, err)
+	dfErr = nil
+	_, err = c.diskUsage(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the media type of the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// defaultBuckets are the upper bounds, in seconds, of the request latency histogram
+var defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// histogram is a cumulative histogram of observed values
+type histogram struct {
+	counts []uint64 // per bucket in defaultBuckets, not cumulative
+	count  uint64
+	sum    float64
+}
+
+func newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(defaultBuckets))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, bound := range defaultBuckets {
+		if v <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// label is a single name/value pair attached to a sample
+type label struct {
+	name, value string
+}
+
+// writer renders metric families in the Prometheus text exposition format
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (w *writer) printf(format string, a ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.w, format, a...)
+}
+
+// family writes the HELP and TYPE header of a metric family
+func (w *writer) family(name, help, typ string) {
+	w.printf("# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	w.printf("# TYPE %s %s\n", name, typ)
+}
+
+// sample writes a single sample of a metric family
+func (w *writer) sample(name string, value float64, labels ...label) {
+	w.printf("%s%s %s\n", name, formatLabels(labels), formatValue(value))
+}
+
+// histogram writes the bucket, sum and count samples of a histogram
+func (w *writer) histogram(name string, h *histogram, labels ...label) {
+	var cumulative uint64
+	for i, bound := range defaultBuckets {
+		cumulative += h.counts[i]
+		w.sample(name+"_bucket", float64(cumulative), append(labels, label{"le", formatValue(bound)})...)
+	}
+	w.sample(name+"_bucket", float64(h.count), append(labels, label{"le", "+Inf"})...)
+	w.sample(name+"_sum", h.sum, labels...)
+	w.sample(name+"_count", float64(h.count), labels...)
+}
+
+func formatLabels(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		pairs = append(pairs, l.name+`="`+escape.Replace(l.value)+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
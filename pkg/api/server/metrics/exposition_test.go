@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterSample(t *testing.T) {
+	var buf bytes.Buffer
+	out := &writer{w: &buf}
+	out.family("podman_containers", "Number of containers by state.", "gauge")
+	out.sample("podman_containers", 3, label{"state", "running"})
+	out.sample("podman_containers", 0.5, label{"name", "a\"b\\c\nd"})
+	out.sample("podman_containers", math.Inf(1))
+	assert.NoError(t, out.err)
+	assert.Equal(t, `# HELP podman_containers Number of containers by state.
+# TYPE podman_containers gauge
+podman_containers{state="running"} 3
+podman_containers{name="a\"b\\c\nd"} 0.5
+podman_containers +Inf
+`, buf.String())
+}
+
+func TestWriterHistogram(t *testing.T) {
+	h := newHistogram()
+	h.observe(0.003)
+	h.observe(0.2)
+	h.observe(0.2)
+	h.observe(42)
+
+	var buf bytes.Buffer
+	out := &writer{w: &buf}
+	out.histogram("latency", h, label{"route", "/_ping"})
+	assert.NoError(t, out.err)
+	assert.Equal(t, `latency_bucket{route="/_ping",le="0.005"} 1
+latency_bucket{route="/_ping",le="0.01"} 1
+latency_bucket{route="/_ping",le="0.025"} 1
+latency_bucket{route="/_ping",le="0.05"} 1
+latency_bucket{route="/_ping",le="0.1"} 1
+latency_bucket{route="/_ping",le="0.25"} 3
+latency_bucket{route="/_ping",le="0.5"} 3
+latency_bucket{route="/_ping",le="1"} 3
+latency_bucket{route="/_ping",le="2.5"} 3
+latency_bucket{route="/_ping",le="5"} 3
+latency_bucket{route="/_ping",le="10"} 3
+latency_bucket{route="/_ping",le="+Inf"} 4
+latency_sum{route="/_ping"} 42.403
+latency_count{route="/_ping"} 4
+`, buf.String())
+}
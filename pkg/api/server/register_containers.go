@@ -1747,14 +1747,66 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// ---
 	// tags:
 	//   - containers
-	// summary: Update an existing containers cgroup configuration
-	// description: Update an existing containers cgroup configuration.
+	// summary: Update an existing containers configuration
+	// description: Update an existing containers cgroup configuration, restart policy, healthcheck, environment and labels.
 	// parameters:
 	//  - in: path
 	//    name: name
 	//    type: string
 	//    required: true
 	//    description: Full or partial ID or full name of the container to update
+	//  - in: query
+	//    name: restartPolicy
+	//    type: string
+	//    description: New restart policy for the container, e.g. on-failure:3.
+	//  - in: query
+	//    name: healthCmd
+	//    type: string
+	//    description: New healthcheck command for the container, "none" removes the healthcheck.
+	//  - in: query
+	//    name: healthInterval
+	//    type: string
+	//    description: Interval of the healthcheck.
+	//  - in: query
+	//    name: healthRetries
+	//    type: integer
+	//    description: Number of retries allowed before the container is considered to be unhealthy.
+	//  - in: query
+	//    name: healthStartPeriod
+	//    type: string
+	//    description: Initialization time needed for the container to bootstrap.
+	//  - in: query
+	//    name: healthTimeout
+	//    type: string
+	//    description: Maximum time allowed to complete the healthcheck.
+	//  - in: query
+	//    name: healthOnFailure
+	//    type: string
+	//    description: Action to take once the container turns unhealthy.
+	//  - in: query
+	//    name: env
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: Environment variables to add or change, in the form key=value.
+	//  - in: query
+	//    name: unsetEnv
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: Environment variables to remove.
+	//  - in: query
+	//    name: labels
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: Labels to add or change, in the form key=value.
+	//  - in: query
+	//    name: unsetLabels
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: Labels to remove.
 	//  - in: body
 	//    name: resources
 	//    description: attributes for updating the container
@@ -1766,6 +1818,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   responses:
 	//     201:
 	//       $ref: "#/responses/containerUpdateResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//     $ref: "#/responses/containerNotFound"
 	//   500:
@@ -63,6 +63,10 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//   in: query
 	//   default: true
 	//   description: when false, do not follow events
+	// - name: afterCursor
+	//   type: string
+	//   in: query
+	//   description: start streaming events right after the event with this cursor, takes precedence over since
 	// responses:
 	//   200:
 	//     description: returns a string of json data describing an event
@@ -32,5 +32,72 @@ func (s *APIServer) registerHealthCheckHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/containers/{name:.*}/healthcheck"), s.APIHandler(libpod.RunHealthCheck)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/healthcheck/json libpod ContainerHealthcheckInspectLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Inspect a container's healthcheck
+	// description: Return the healthcheck configuration, the current health status and all probes recorded in the healthcheck history
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/healthCheckInspect"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     description: container has no healthcheck
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/containers/{name}/healthcheck/json"), s.APIHandler(libpod.InspectHealthCheck)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/{name}/healthcheck/logs libpod ContainerHealthcheckLogsLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get a container's healthcheck history
+	// description: Stream the probes recorded in the healthcheck history as a sequence of JSON objects, oldest first
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: follow
+	//    type: boolean
+	//    description: Keep streaming new probes until the container stops running
+	//  - in: query
+	//    name: since
+	//    type: string
+	//    description: Only return probes started at or after this time, as a timestamp or a duration relative to now
+	//  - in: query
+	//    name: until
+	//    type: string
+	//    description: Only return probes started at or before this time, as a timestamp or a duration relative to now
+	//  - in: query
+	//    name: status
+	//    type: string
+	//    enum: ["healthy", "unhealthy", "starting"]
+	//    description: Only return probes which left the container in this health status
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/healthCheckLogs"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   409:
+	//     description: container has no healthcheck
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/containers/{name}/healthcheck/logs"), s.APIHandler(libpod.HealthCheckLogs)).Methods(http.MethodGet)
 	return nil
 }
@@ -97,6 +97,16 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: false
 	//    description: Clean up all objects created when a SIGTERM is received or pods exit.
+	//  - in: query
+	//    name: waitReady
+	//    type: boolean
+	//    default: false
+	//    description: Wait until all containers are running and healthy, report their final state in ContainerStatuses and set NotReady if not all of them became ready.
+	//  - in: query
+	//    name: waitReadyTimeout
+	//    type: integer
+	//    default: 300
+	//    description: Maximum time in seconds to wait for the containers to become ready, 0 waits forever.
 	//  - in: body
 	//    name: request
 	//    description: Kubernetes YAML file.
@@ -174,6 +184,16 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: false
 	//    description: add podman-only reserved annotations in generated YAML file (cannot be used by Kubernetes)
+	//  - in: query
+	//    name: configMaps
+	//    type: boolean
+	//    default: false
+	//    description: move the environment variables of the containers into ConfigMaps referenced via envFrom
+	//  - in: query
+	//    name: secretData
+	//    type: boolean
+	//    default: false
+	//    description: generate Secrets including the data of the podman secrets used by the containers
 	// produces:
 	// - text/vnd.yaml
 	// - application/json
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerMetricsHandlers(r *mux.Router) error {
+	if s.metrics == nil {
+		return nil
+	}
+	// swagger:operation GET /metrics libpod SystemMetrics
+	// ---
+	//   summary: Prometheus metrics
+	//   description: |
+	//     Return metrics about the API service, containers, pods, images and volumes
+	//     in the Prometheus text exposition format.
+	//     The endpoint is only available when the service was started with `--enable-metrics`.
+	//     The '/metrics' endpoint is not versioned.
+	//   tags:
+	//   - system
+	//   produces:
+	//   - text/plain
+	//   responses:
+	//     200:
+	//       description: Metrics in the Prometheus text exposition format
+	//     404:
+	//       description: Metrics are not enabled
+	r.Handle("/metrics", s.metrics).Methods(http.MethodGet)
+	return nil
+}
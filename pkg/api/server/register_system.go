@@ -40,6 +40,31 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/system/prune"), s.APIHandler(libpod.SystemPrune)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/system/check libpod SystemCheckLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Check the libpod state
+	// description: Check the libpod database, storage and locks for inconsistencies, and optionally repair them
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: quick
+	//    type: boolean
+	//    description: Skip the checks that have to walk container storage
+	//  - in: query
+	//    name: repair
+	//    type: boolean
+	//    description: Repair the inconsistencies that are safe to fix
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemCheckResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/check"), s.APIHandler(libpod.SystemCheck)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/system/df libpod SystemDataUsageLibpod
 	// ---
 	// tags:
@@ -54,5 +79,30 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/system/df"), s.APIHandler(libpod.DiskUsage)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/system/drain libpod SystemDrainLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Drain the API service
+	// description: |
+	//   Stop accepting new connections and shut the service down once the requests in flight finished.
+	//   The call returns right away with the requests in flight, it does not wait for them.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: timeout
+	//    type: integer
+	//    description: |
+	//      Seconds to wait for the requests in flight before closing them.
+	//      Defaults to the --drain-timeout of the service, or 60 seconds if it is not set.
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemDrainResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/drain"), s.APIHandler(s.drainHandler)).Methods(http.MethodPost)
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/containers/podman/v4/libpod/shutdown"
 	"github.com/containers/podman/v4/pkg/api/handlers"
 	"github.com/containers/podman/v4/pkg/api/server/idle"
+	"github.com/containers/podman/v4/pkg/api/server/metrics"
 	"github.com/containers/podman/v4/pkg/api/types"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/coreos/go-systemd/v22/daemon"
@@ -27,15 +29,23 @@ import (
 )
 
 type APIServer struct {
-	http.Server                      // The  HTTP work happens here
-	net.Listener                     // mux for routing HTTP API calls to libpod routines
-	*libpod.Runtime                  // Where the real work happens
-	*schema.Decoder                  // Decoder for Query parameters to structs
-	context.CancelFunc               // Stop APIServer
-	context.Context                  // Context to carry objects to handlers
-	CorsHeaders        string        // Inject Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr          string        // Binding network address for pprof profiles
-	idleTracker        *idle.Tracker // Track connections to support idle shutdown
+	http.Server                           // The  HTTP work happens here
+	net.Listener                          // mux for routing HTTP API calls to libpod routines
+	*libpod.Runtime                       // Where the real work happens
+	*schema.Decoder                       // Decoder for Query parameters to structs
+	context.CancelFunc                    // Stop APIServer
+	context.Context                       // Context to carry objects to handlers
+	CorsHeaders        string             // Inject Cross-Origin Resource Sharing (CORS) headers
+	PProfAddr          string             // Binding network address for pprof profiles
+	idleTracker        *idle.Tracker      // Track connections to support idle shutdown
+	metrics            *metrics.Collector // Prometheus metrics, nil unless enabled
+	notifySocket       string             // systemd notify socket, empty if not started by systemd
+	drainTimeout       time.Duration      // Time to wait for requests in flight when stopped by a signal
+	drainOnce          sync.Once          // Drain the service only once
+	drainDeadline      time.Time          // Deadline of the drain, set by drainOnce
+	draining           atomic.Bool        // Whether the service is draining
+	drained            chan struct{}      // Closed when the drain finished
+	stopOnce           sync.Once          // Wait for shutdown.Inhibit only once when stopping
 }
 
 // Number of seconds to wait for next request, if exceeded shutdown server
@@ -82,10 +92,20 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 			Handler:     router,
 			IdleTimeout: opts.Timeout * 2,
 		},
-		CorsHeaders: opts.CorsHeaders,
-		Listener:    listener,
-		PProfAddr:   opts.PProfAddr,
-		idleTracker: tracker,
+		CorsHeaders:  opts.CorsHeaders,
+		Listener:     listener,
+		PProfAddr:    opts.PProfAddr,
+		idleTracker:  tracker,
+		drainTimeout: opts.DrainTimeout,
+		drained:      make(chan struct{}),
+	}
+
+	server.Context, server.CancelFunc = context.WithCancel(context.Background())
+	if opts.EnableMetrics {
+		logrus.Debug("Metrics endpoint enabled")
+		server.metrics = metrics.NewCollector(runtime, tracker)
+		server.metrics.WatchEvents(server.Context)
+		router.Use(server.metrics.Middleware)
 	}
 
 	server.BaseContext = func(l net.Listener) context.Context {
@@ -98,7 +118,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 
 	// Capture panics and print stack traces for diagnostics,
 	// additionally process X-Reference-Id Header to support event correlation
-	router.Use(panicHandler(), referenceIDHandler())
+	router.Use(panicHandler(), referenceIDHandler(), callerHandler(runtime), inFlightHandler(tracker))
 	router.NotFoundHandler = http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
 			// We can track user errors...
@@ -127,6 +147,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		server.registerImagesHandlers,
 		server.registerInfoHandlers,
 		server.registerManifestHandlers,
+		server.registerMetricsHandlers,
 		server.registerMonitorHandlers,
 		server.registerNetworkHandlers,
 		server.registerPingHandlers,
@@ -170,9 +191,12 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 // so conmon and containers are in the correct cgroup.  Also unset NOTIFY_SOCKET
 // to avoid any further usage of the socket.
 func (s *APIServer) setupSystemd() {
-	if _, found := os.LookupEnv("NOTIFY_SOCKET"); !found {
+	notifySocket, found := os.LookupEnv("NOTIFY_SOCKET")
+	if !found {
 		return
 	}
+	// Keep the socket to notify systemd when draining.
+	s.notifySocket = notifySocket
 
 	payload := fmt.Sprintf("MAINPID=%d\n", os.Getpid())
 	payload += daemon.SdNotifyReady
@@ -194,14 +218,20 @@ func (s *APIServer) setupSystemd() {
 func (s *APIServer) Serve() error {
 	s.setupPprof()
 
-	if err := shutdown.Register("service", func(sig os.Signal) error {
-		return s.Shutdown(true)
-	}); err != nil {
-		return err
-	}
-	// Start the shutdown signal handler.
-	if err := shutdown.Start(); err != nil {
-		return err
+	if s.drainTimeout > 0 {
+		if err := s.drainOnSignal(); err != nil {
+			return err
+		}
+	} else {
+		if err := shutdown.Register("service", func(sig os.Signal) error {
+			return s.Shutdown(true)
+		}); err != nil {
+			return err
+		}
+		// Start the shutdown signal handler.
+		if err := shutdown.Start(); err != nil {
+			return err
+		}
 	}
 
 	go func() {
@@ -221,6 +251,11 @@ func (s *APIServer) Serve() error {
 			errChan <- fmt.Errorf("failed to start API service: %w", err)
 			return
 		}
+		// Serve returns as soon as draining stops accepting new
+		// connections, wait for the requests in flight.
+		if s.draining.Load() {
+			<-s.drained
+		}
 		errChan <- nil
 	}()
 
@@ -270,6 +305,7 @@ func (s *APIServer) Shutdown(halt bool) error {
 	shutdownOnce.Do(func() {
 		logrus.Debugf("API service shutdown, %d/%d connection(s)",
 			s.idleTracker.ActiveConnections(), s.idleTracker.TotalConnections())
+		s.CancelFunc()
 
 		// Gracefully shutdown server(s), duration of wait same as idle window
 		deadline := 1 * time.Second
@@ -4,13 +4,18 @@ package autoupdate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/containers/common/libimage"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/libpod/events"
@@ -31,6 +36,9 @@ const (
 	PolicyRegistryImage = "registry"
 	// PolicyLocalImage is the policy to run auto-update based on a local image
 	PolicyLocalImage = "local"
+	// PolicySemver is the policy to update to the newest tag on the
+	// registry within a range of semantic versions, e.g., "semver:~1.4".
+	PolicySemver = "semver"
 )
 
 // Map for easy lookups of supported policies.
@@ -47,6 +55,7 @@ type updater struct {
 	conn             *dbus.Conn                  // DBUS connection
 	options          *entities.AutoUpdateOptions // User-specified options
 	unitToTasks      map[string][]*task          // Keeps track of tasks per unit
+	recreateTasks    []*task                     // Tasks of containers not running in a systemd unit
 	updatedRawImages map[string]bool             // Keeps track of updated images
 	runtime          *libpod.Runtime             // The libpod runtime
 }
@@ -59,6 +68,10 @@ const (
 	statusRolledBack = "rolled back" // Rollback after a failed update
 )
 
+// healthPollInterval is how often the health of updated containers is checked
+// while waiting for them to become healthy.
+const healthPollInterval = time.Second
+
 // task includes data and state for updating a container
 type task struct {
 	authfile     string            // Container-specific authfile
@@ -68,7 +81,16 @@ type task struct {
 	image        *libimage.Image   // Original image before the update
 	rawImageName string            // The container's raw image name
 	status       string            // Auto-update status
-	unit         string            // Name of the systemd unit
+	reason       string            // Why the update failed or was rolled back
+	unit         string            // Name of the systemd unit, empty if the container is recreated
+
+	newDigest    string // Digest of the image to update to
+	newImageName string // Name of the image to update to (semver policy)
+	version      string // Version of the image before the update (semver policy)
+	newVersion   string // Version of the image to update to (semver policy)
+
+	semverRange       semver.Range // Versions to update to (semver policy)
+	semverRangeString string       // Unparsed semverRange
 }
 
 // LookupPolicy looks up the corresponding Policy for the specified
@@ -81,6 +103,12 @@ func LookupPolicy(s string) (Policy, error) {
 	if exists {
 		return policy, nil
 	}
+	if strings.HasPrefix(s, semverPolicyPrefix) {
+		if _, err := parseSemverRange(strings.TrimPrefix(s, semverPolicyPrefix)); err != nil {
+			return "", fmt.Errorf("invalid auto-update policy %q: %w", s, err)
+		}
+		return PolicySemver, nil
+	}
 
 	// Sort the keys first as maps are non-deterministic.
 	keys := []string{}
@@ -91,6 +119,8 @@ func LookupPolicy(s string) (Policy, error) {
 	}
 	sort.Strings(keys)
 
+	keys = append(keys, semverPolicyPrefix+"RANGE")
+
 	return "", fmt.Errorf("invalid auto-update policy %q: valid policies are %+q", s, keys)
 }
 
@@ -106,6 +136,20 @@ func LookupPolicy(s string) (Policy, error) {
 // of a running container is different than the local one. If the image digests
 // differ, it restarts the systemd unit with the new image.
 //
+// If the policy is set to PolicySemver, it checks if the registry has a tag
+// with a higher version in the configured range than the one of the running
+// container.  If so, it pulls the image, tags it with the container's image
+// name and restarts the systemd unit.
+//
+// After restarting a unit, it waits for the health checks of its containers
+// to pass and, if rollbacks are enabled, rolls back to the previous image if
+// the restart failed or the containers do not become healthy.
+//
+// Containers which do not run in a systemd unit are recreated from their
+// configuration on the new image instead, keeping their name, networks,
+// volumes and pod.  The previous container is kept until the new one is
+// healthy and restored on rollback.
+//
 // It returns a slice of successfully restarted systemd units and a slice of
 // errors encountered during auto update.
 func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.AutoUpdateOptions) ([]*entities.AutoUpdateReport, []error) {
@@ -123,24 +167,30 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 	allErrors := auto.assembleTasks(ctx)
 
 	// Nothing to do.
-	if len(auto.unitToTasks) == 0 {
+	if len(auto.unitToTasks) == 0 && len(auto.recreateTasks) == 0 {
 		return nil, allErrors
 	}
 
-	// Connect to DBUS.
-	conn, err := systemd.ConnectToDBUS()
-	if err != nil {
-		logrus.Errorf(err.Error())
-		allErrors = append(allErrors, err)
-		return nil, allErrors
+	// Connect to DBUS only if there are units to restart.
+	if len(auto.unitToTasks) > 0 {
+		conn, err := systemd.ConnectToDBUS()
+		if err != nil {
+			logrus.Errorf(err.Error())
+			allErrors = append(allErrors, err)
+			return nil, allErrors
+		}
+		defer conn.Close()
+		auto.conn = conn
 	}
-	defer conn.Close()
-	auto.conn = conn
 
 	runtime.NewSystemEvent(events.AutoUpdate)
 
 	// Update all images/container according to their auto-update policy.
 	var allReports []*entities.AutoUpdateReport
+	for _, task := range auto.recreateTasks {
+		allErrors = append(allErrors, auto.recreateContainer(ctx, task)...)
+		allReports = append(allReports, task.report())
+	}
 	for unit, tasks := range auto.unitToTasks {
 		unitErrors := auto.updateUnit(ctx, unit, tasks)
 		allErrors = append(allErrors, unitErrors...)
@@ -154,68 +204,56 @@ func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options entities.A
 
 // updateUnit auto updates the tasks in the specified systemd unit.
 func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []error {
-	var errors []error
+	var errs []error
 	tasksUpdated := false
 
 	for _, task := range tasks {
-		err := func() error { // Use an anonymous function to avoid spaghetti continue's
-			updateAvailable, err := task.updateAvailable(ctx)
-			if err != nil {
-				task.status = statusFailed
-				return fmt.Errorf("checking image updates for container %s: %w", task.container.ID(), err)
-			}
-
-			if !updateAvailable {
-				task.status = statusNotUpdated
-				return nil
-			}
-
-			if u.options.DryRun {
-				task.status = statusPending
-				return nil
-			}
-
-			if err := task.update(ctx); err != nil {
-				task.status = statusFailed
-				return fmt.Errorf("updating image for container %s: %w", task.container.ID(), err)
-			}
-
-			tasksUpdated = true
-			return nil
-		}()
-
+		updated, err := task.updateImage(ctx)
 		if err != nil {
-			errors = append(errors, err)
+			errs = append(errs, err)
+		}
+		if updated {
+			tasksUpdated = true
 		}
 	}
 
 	// If no task has been updated, we can jump directly to the next unit.
 	if !tasksUpdated {
-		return errors
+		return errs
 	}
 
+	restarted := time.Now()
 	updateError := u.restartSystemdUnit(ctx, unit)
+	if updateError != nil {
+		updateError = fmt.Errorf("restarting unit %s during update: %w", unit, updateError)
+	} else if err := u.waitHealthy(ctx, func(ctx context.Context, final bool) (bool, error) {
+		return u.unitHealthy(ctx, unit, restarted, final)
+	}); err != nil {
+		updateError = fmt.Errorf("checking health of unit %s after update: %w", unit, err)
+	}
 	for _, task := range tasks {
 		if updateError == nil {
 			task.status = statusUpdated
 		} else {
 			task.status = statusFailed
+			task.reason = updateError.Error()
 		}
 	}
 
 	// Jump to the next unit on successful update or if rollbacks are disabled.
 	if updateError == nil || !u.options.Rollback {
 		if updateError != nil {
-			errors = append(errors, fmt.Errorf("restarting unit %s during update: %w", unit, updateError))
+			errs = append(errs, updateError)
 		}
-		return errors
+		return errs
 	}
 
 	// The update has failed and rollbacks are enabled.
+	logrus.Warnf("Rolling back unit %s: %v", unit, updateError)
 	for _, task := range tasks {
 		if err := task.rollbackImage(); err != nil {
 			err = fmt.Errorf("rolling back image for container %s in unit %s: %w", task.container.ID(), unit, err)
-			errors = append(errors, err)
+			errs = append(errs, err)
 		}
 	}
 
@@ -224,26 +262,180 @@ func (u *updater) updateUnit(ctx context.Context, unit string, tasks []*task) []
 			task.status = statusFailed
 		}
 		err = fmt.Errorf("restarting unit %s during rollback: %w", unit, err)
-		errors = append(errors, err)
-		return errors
+		errs = append(errs, err)
+		return errs
 	}
 
 	for _, task := range tasks {
 		task.status = statusRolledBack
 	}
 
-	return errors
+	return errs
+}
+
+// updateImage checks whether an update for the task is available and, unless
+// it's a dry run, updates the image.  It returns whether the image has been
+// updated and sets the task's status and reason on failure.
+func (t *task) updateImage(ctx context.Context) (bool, error) {
+	updateAvailable, err := t.updateAvailable(ctx)
+	if err != nil {
+		t.status = statusFailed
+		err = fmt.Errorf("checking image updates for container %s: %w", t.container.ID(), err)
+		t.reason = err.Error()
+		return false, err
+	}
+
+	if !updateAvailable {
+		t.status = statusNotUpdated
+		return false, nil
+	}
+
+	if t.auto.options.DryRun {
+		t.status = statusPending
+		return false, nil
+	}
+
+	if err := t.update(ctx); err != nil {
+		t.status = statusFailed
+		err = fmt.Errorf("updating image for container %s: %w", t.container.ID(), err)
+		t.reason = err.Error()
+		return false, err
+	}
+	return true, nil
+}
+
+// waitHealthy polls healthy until it reports the updated containers to be
+// healthy and returns an error if one of them becomes unhealthy or doesn't
+// become healthy within the configured timeout.  The last poll, once the
+// timeout expired, is marked as final.
+func (u *updater) waitHealthy(ctx context.Context, healthy func(ctx context.Context, final bool) (bool, error)) error {
+	if u.options.HealthTimeout <= 0 {
+		return nil
+	}
+	deadline := time.Now().Add(u.options.HealthTimeout)
+	for {
+		final := time.Now().After(deadline)
+		healthy, err := healthy(ctx, final)
+		if err != nil || healthy {
+			return err
+		}
+		if final {
+			return fmt.Errorf("containers not healthy within %s", u.options.HealthTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// unitHealthy returns whether the unit is active and all of its containers
+// started since the given time are healthy.  It returns an error if the unit
+// or one of the containers failed.  Containers without a timed health check
+// are considered healthy.
+func (u *updater) unitHealthy(ctx context.Context, unit string, since time.Time, final bool) (bool, error) {
+	property, err := u.conn.GetUnitPropertyContext(ctx, unit, "ActiveState")
+	if err != nil {
+		return false, fmt.Errorf("querying state of unit %s: %w", unit, err)
+	}
+	activeState, _ := property.Value.Value().(string)
+	switch activeState {
+	case "active", "reloading":
+	case "activating":
+		// Not ready yet, e.g., waiting for sd-notify.
+		return false, nil
+	default:
+		return false, fmt.Errorf("unit %s is %s", unit, activeState)
+	}
+
+	allContainers, err := u.runtime.GetAllContainers()
+	if err != nil {
+		return false, err
+	}
+	healthy := true
+	for _, ctr := range allContainers {
+		if !healthCheckTimed(ctr.HealthCheckConfig()) || ctr.CreatedTime().Before(since) {
+			continue
+		}
+		ctrUnit, exists, err := u.systemdUnitForContainer(ctr, ctr.Labels())
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchPod) || errors.Is(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return false, err
+		}
+		if !exists || ctrUnit != unit {
+			continue
+		}
+		ctrHealthy, err := containerHealthy(ctr, final)
+		if err != nil {
+			if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+				continue
+			}
+			return false, err
+		}
+		if !ctrHealthy {
+			logrus.Debugf("Waiting for container %s of unit %s to become healthy", ctr.Name(), unit)
+			healthy = false
+		}
+	}
+	return healthy, nil
+}
+
+// healthCheckTimed returns whether the health check runs periodically.  A
+// health check with a disabled interval only runs when triggered manually, so
+// its status does not change after an update.
+func healthCheckTimed(config *manifest.Schema2HealthConfig) bool {
+	return config != nil && config.Interval > 0
+}
+
+// containerHealthy returns whether the container passed its health check and
+// an error if it is unhealthy.
+func containerHealthy(ctr *libpod.Container, final bool) (bool, error) {
+	status, err := ctr.HealthCheckStatus()
+	if err != nil {
+		return false, err
+	}
+	return healthStatusHealthy(ctr.Name(), status, final)
+}
+
+// healthStatusHealthy returns whether the health check status of a container
+// is healthy and an error if it is unhealthy.  A container whose health check
+// is still starting on the final poll, e.g., because its start period and
+// retries exceed the health timeout, is considered healthy with a warning.
+func healthStatusHealthy(name, status string, final bool) (bool, error) {
+	switch status {
+	case define.HealthCheckHealthy:
+		return true, nil
+	case define.HealthCheckUnhealthy:
+		return false, fmt.Errorf("container %s is unhealthy", name)
+	case define.HealthCheckStarting:
+		if final {
+			logrus.Warnf("Health check of container %s is still starting after the health timeout, considering it healthy", name)
+			return true, nil
+		}
+		return false, nil
+	default:
+		return false, nil
+	}
 }
 
 // report creates an auto-update report for the task.
 func (t *task) report() *entities.AutoUpdateReport {
 	return &entities.AutoUpdateReport{
-		ContainerID:   t.container.ID(),
-		ContainerName: t.container.Name(),
-		ImageName:     t.container.RawImageName(),
-		Policy:        string(t.policy),
-		SystemdUnit:   t.unit,
-		Updated:       t.status,
+		ContainerID:     t.container.ID(),
+		ContainerName:   t.container.Name(),
+		ImageName:       t.container.RawImageName(),
+		Policy:          string(t.policy),
+		SystemdUnit:     t.unit,
+		Updated:         t.status,
+		Reason:          t.reason,
+		ImageDigest:     t.image.Digest().String(),
+		NewImageDigest:  t.newDigest,
+		NewImageName:    t.newImageName,
+		ImageVersion:    t.version,
+		NewImageVersion: t.newVersion,
 	}
 }
 
@@ -254,6 +446,8 @@ func (t *task) updateAvailable(ctx context.Context) (bool, error) {
 		return t.registryUpdateAvailable(ctx)
 	case PolicyLocalImage:
 		return t.localUpdateAvailable()
+	case PolicySemver:
+		return t.semverUpdateAvailable(ctx)
 	default:
 		return false, fmt.Errorf("unexpected auto-update policy %s for container %s", t.policy, t.container.ID())
 	}
@@ -267,6 +461,8 @@ func (t *task) update(ctx context.Context) error {
 	case PolicyLocalImage:
 		// Nothing to do as the image is already available in the local storage.
 		return nil
+	case PolicySemver:
+		return t.semverUpdate(ctx)
 	default:
 		return fmt.Errorf("unexpected auto-update policy %s for container %s", t.policy, t.container.ID())
 	}
@@ -288,7 +484,16 @@ func (t *task) registryUpdateAvailable(ctx context.Context) (bool, error) {
 		AuthFilePath:          t.authfile,
 		InsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
 	}
-	return t.image.HasDifferentDigest(ctx, remoteRef, options)
+	updateAvailable, err := t.image.HasDifferentDigest(ctx, remoteRef, options)
+	if err != nil || !updateAvailable {
+		return false, err
+	}
+	newDigest, err := docker.GetDigest(ctx, t.systemContext(), remoteRef)
+	if err != nil {
+		return false, fmt.Errorf("looking up digest of %s: %w", t.rawImageName, err)
+	}
+	t.newDigest = newDigest.String()
+	return true, nil
 }
 
 // registryUpdate pulls down the image from the registry.
@@ -316,7 +521,11 @@ func (t *task) localUpdateAvailable() (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return localImg.Digest().String() != t.image.Digest().String(), nil
+	if localImg.Digest().String() == t.image.Digest().String() {
+		return false, nil
+	}
+	t.newDigest = localImg.Digest().String()
+	return true, nil
 }
 
 // rollbackImage rolls back the task's image to the previous version before the update.
@@ -396,16 +605,19 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			continue
 		}
 
-		// Make sure the container runs in a systemd unit which is
-		// stored as a label at container creation.
-		unit, exists, err := u.systemdUnitForContainer(ctr, labels)
+		// Look up the systemd unit the container runs in, which is
+		// stored as a label at container creation.  Containers not
+		// running in a unit are recreated instead.
+		unit, hasUnit, err := u.systemdUnitForContainer(ctr, labels)
 		if err != nil {
 			errors = append(errors, err)
 			continue
 		}
-		if !exists {
-			errors = append(errors, fmt.Errorf("auto-updating container %q: no %s label found", ctr.ID(), systemdDefine.EnvVariable))
-			continue
+		if !hasUnit {
+			if err := canRecreate(ctr, allContainers); err != nil {
+				errors = append(errors, fmt.Errorf("auto-updating container %q without %s label: %w", ctr.ID(), systemdDefine.EnvVariable, err))
+				continue
+			}
 		}
 
 		id, _ := ctr.Image()
@@ -438,14 +650,28 @@ func (u *updater) assembleTasks(ctx context.Context) []error {
 			rawImageName: rawImageName,
 			status:       statusFailed, // must be updated later on
 		}
+		if policy == PolicySemver {
+			t.semverRangeString = strings.TrimPrefix(value, semverPolicyPrefix)
+			// The range has been validated by LookupPolicy.
+			t.semverRange, _ = parseSemverRange(t.semverRangeString)
+		}
 
-		// Add the task to the unit.
-		u.unitToTasks[unit] = append(u.unitToTasks[unit], &t)
+		u.addTask(&t, hasUnit)
 	}
 
 	return errors
 }
 
+// addTask adds the task to the tasks of its systemd unit or, if its container
+// does not run in a unit, to the tasks recreating their container.
+func (u *updater) addTask(t *task, hasUnit bool) {
+	if !hasUnit {
+		u.recreateTasks = append(u.recreateTasks, t)
+		return
+	}
+	u.unitToTasks[t.unit] = append(u.unitToTasks[t.unit], t)
+}
+
 // systemdUnitForContainer returns the name of the container's systemd unit.
 // If the container is part of a pod, the pod's infra container's systemd unit
 // is returned.  This allows for auto update to restart the pod's systemd unit.
@@ -0,0 +1,67 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckTimed(t *testing.T) {
+	assert.False(t, healthCheckTimed(nil), "no health check")
+	// --health-interval=disable
+	assert.False(t, healthCheckTimed(&manifest.Schema2HealthConfig{Test: []string{"CMD", "true"}}), "disabled interval")
+	assert.True(t, healthCheckTimed(&manifest.Schema2HealthConfig{Test: []string{"CMD", "true"}, Interval: 30 * time.Second}))
+}
+
+func TestHealthStatusHealthy(t *testing.T) {
+	healthy, err := healthStatusHealthy("ctr", define.HealthCheckHealthy, false)
+	require.NoError(t, err)
+	assert.True(t, healthy)
+
+	_, err = healthStatusHealthy("ctr", define.HealthCheckUnhealthy, true)
+	assert.ErrorContains(t, err, "container ctr is unhealthy")
+
+	// A health check still starting only passes once the timeout expired.
+	healthy, err = healthStatusHealthy("ctr", define.HealthCheckStarting, false)
+	require.NoError(t, err)
+	assert.False(t, healthy)
+	healthy, err = healthStatusHealthy("ctr", define.HealthCheckStarting, true)
+	require.NoError(t, err)
+	assert.True(t, healthy)
+}
+
+func TestWaitHealthy(t *testing.T) {
+	u := &updater{options: &entities.AutoUpdateOptions{HealthTimeout: time.Millisecond}}
+
+	var polls []bool
+	err := u.waitHealthy(context.Background(), func(_ context.Context, final bool) (bool, error) {
+		polls = append(polls, final)
+		return final, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, polls)
+
+	err = u.waitHealthy(context.Background(), func(context.Context, bool) (bool, error) {
+		return false, nil
+	})
+	assert.ErrorContains(t, err, "containers not healthy within 1ms")
+}
+
+func TestAddTask(t *testing.T) {
+	u := &updater{unitToTasks: make(map[string][]*task)}
+	unitTask := &task{unit: "app.service"}
+	standalone := &task{}
+	u.addTask(unitTask, true)
+	u.addTask(standalone, false)
+
+	assert.Equal(t, map[string][]*task{"app.service": {unitTask}}, u.unitToTasks)
+	assert.Equal(t, []*task{standalone}, u.recreateTasks)
+}
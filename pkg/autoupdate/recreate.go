@@ -0,0 +1,189 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containers/podman/v4/libpod"
+	"github.com/containers/podman/v4/libpod/define"
+	"github.com/containers/podman/v4/pkg/specgen"
+	"github.com/containers/podman/v4/pkg/specgen/generate"
+	"github.com/sirupsen/logrus"
+)
+
+// canRecreate returns an error if the container cannot be recreated by
+// auto-update, i.e., if it is removed when stopped or if other containers
+// depend on it.
+func canRecreate(ctr *libpod.Container, allContainers []*libpod.Container) error {
+	if ctr.IsInfra() || ctr.IsService() {
+		return errors.New("infra and service containers cannot be recreated")
+	}
+	if ctr.AutoRemove() {
+		return errors.New("the container is removed when stopped and cannot be recreated")
+	}
+	for _, other := range allContainers {
+		for _, dep := range other.Dependencies() {
+			if dep == ctr.ID() {
+				return fmt.Errorf("container %s depends on the container, so it cannot be recreated", other.ID())
+			}
+		}
+	}
+	return nil
+}
+
+// recreateContainer auto updates the task of a container which does not run
+// in a systemd unit by recreating the container on the updated image.
+func (u *updater) recreateContainer(ctx context.Context, t *task) []error {
+	updated, err := t.updateImage(ctx)
+	if err != nil {
+		return []error{err}
+	}
+	if !updated {
+		return nil
+	}
+
+	var errs []error
+	oldCtr := t.container
+	name := oldCtr.Name()
+	newCtr, updateError := t.recreate(ctx)
+	if updateError != nil {
+		updateError = fmt.Errorf("recreating container %s during update: %w", name, updateError)
+	} else if err := u.waitHealthy(ctx, func(_ context.Context, final bool) (bool, error) {
+		return newContainerHealthy(newCtr, final)
+	}); err != nil {
+		updateError = fmt.Errorf("checking health of container %s after update: %w", name, err)
+	}
+
+	if updateError == nil {
+		t.status = statusUpdated
+		t.container = newCtr
+		if err := u.runtime.RemoveContainer(ctx, oldCtr, true, false, nil); err != nil {
+			errs = append(errs, fmt.Errorf("removing previous container %s after update: %w", oldCtr.ID(), err))
+		}
+		return errs
+	}
+
+	t.status = statusFailed
+	t.reason = updateError.Error()
+	errs = append(errs, updateError)
+
+	// Keep the new container if it could be created and rollbacks are
+	// disabled.  Otherwise, the previous container is restored.
+	if newCtr != nil && !u.options.Rollback {
+		if err := u.runtime.RemoveContainer(ctx, oldCtr, true, false, nil); err != nil {
+			errs = append(errs, fmt.Errorf("removing previous container %s after update: %w", oldCtr.ID(), err))
+		}
+		return errs
+	}
+
+	if u.options.Rollback {
+		logrus.Warnf("Rolling back container %s: %v", name, updateError)
+		if err := t.rollbackImage(); err != nil {
+			errs = append(errs, fmt.Errorf("rolling back image for container %s: %w", oldCtr.ID(), err))
+		}
+	}
+	if err := t.restore(ctx, name, newCtr); err != nil {
+		errs = append(errs, fmt.Errorf("restoring container %s: %w", name, err))
+		return errs
+	}
+	if u.options.Rollback {
+		t.status = statusRolledBack
+	}
+	return errs
+}
+
+// recreate stops the task's container, renames it out of the way and creates
+// and starts a new container with the same configuration, including name,
+// networks, volumes and pod, on the task's raw image name, which refers to the
+// updated image.  It returns the new container if it has been created, even
+// if it failed to start.
+func (t *task) recreate(ctx context.Context) (*libpod.Container, error) {
+	runtime := t.auto.runtime
+	ctr := t.container
+	name := ctr.Name()
+
+	if err := ctr.Stop(); err != nil && !errors.Is(err, define.ErrCtrStopped) {
+		return nil, fmt.Errorf("stopping container: %w", err)
+	}
+	if _, err := runtime.RenameContainer(ctx, ctr, previousContainerName(ctr, name)); err != nil {
+		return nil, fmt.Errorf("renaming container: %w", err)
+	}
+
+	// Use the same data as `podman container clone`.
+	spec := specgen.NewSpecGenerator(t.rawImageName, false)
+	if _, _, err := generate.ConfigToSpec(runtime, spec, ctr.ID()); err != nil {
+		return nil, err
+	}
+	spec.Name = name
+	spec.RawImageName = t.rawImageName
+	spec.Terminal = ctr.Terminal()
+
+	warnings, err := generate.CompleteSpec(ctx, runtime, spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		logrus.Warnf("Recreating container %s: %s", name, w)
+	}
+
+	rtSpec, spec, opts, err := generate.MakeContainer(ctx, runtime, spec, true, ctr)
+	if err != nil {
+		return nil, err
+	}
+	newCtr, err := generate.ExecuteCreate(ctx, runtime, rtSpec, spec, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Recreated container %s as %s", name, newCtr.ID())
+
+	if err := newCtr.Start(ctx, true); err != nil {
+		return newCtr, fmt.Errorf("starting container: %w", err)
+	}
+	return newCtr, nil
+}
+
+// restore removes the container created by recreate, if any, and restores the
+// task's previous container under its original name.
+func (t *task) restore(ctx context.Context, name string, newCtr *libpod.Container) error {
+	runtime := t.auto.runtime
+	if newCtr != nil {
+		if err := runtime.RemoveContainer(ctx, newCtr, true, false, nil); err != nil {
+			return fmt.Errorf("removing updated container: %w", err)
+		}
+	}
+	if t.container.Name() != name {
+		if _, err := runtime.RenameContainer(ctx, t.container, name); err != nil {
+			return err
+		}
+	}
+	return t.container.Start(ctx, true)
+}
+
+// previousContainerName returns the name the container is renamed to while its
+// replacement is created.
+func previousContainerName(ctr *libpod.Container, name string) string {
+	return fmt.Sprintf("%s-autoupdate-%s", name, ctr.ID()[:12])
+}
+
+// newContainerHealthy returns whether the recreated container is running and
+// healthy.  Containers without a timed health check are considered healthy.
+func newContainerHealthy(ctr *libpod.Container, final bool) (bool, error) {
+	state, err := ctr.State()
+	if err != nil {
+		return false, err
+	}
+	if state != define.ContainerStateRunning {
+		return false, fmt.Errorf("container %s is %s", ctr.Name(), state)
+	}
+	if !healthCheckTimed(ctr.HealthCheckConfig()) {
+		return true, nil
+	}
+	healthy, err := containerHealthy(ctr, final)
+	if err == nil && !healthy {
+		logrus.Debugf("Waiting for container %s to become healthy", ctr.Name())
+	}
+	return healthy, err
+}
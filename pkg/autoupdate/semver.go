@@ -0,0 +1,237 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/containers/common/libimage"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+)
+
+// semverPolicyPrefix prefixes the version range of the semver policy, e.g.,
+// "semver:~1.4".
+const semverPolicyPrefix = string(PolicySemver) + ":"
+
+// parseSemverRange parses a range of semantic versions.  In addition to the
+// ranges supported by github.com/blang/semver (e.g., ">=1.4.0 <1.5.0"), it
+// supports tilde and caret ranges: "~1.4" allows patch updates starting with
+// 1.4.0, "^1.4" allows minor and patch updates starting with 1.4.0.
+func parseSemverRange(s string) (semver.Range, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty semver range")
+	}
+	if s[0] != '~' && s[0] != '^' {
+		r, err := semver.ParseRange(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid semver range %q: %w", s, err)
+		}
+		return r, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(s[1:], "v"), ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid semver range %q", s)
+	}
+	var numbers [3]uint64
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid semver range %q: %w", s, err)
+		}
+		numbers[i] = n
+	}
+	lower := semver.Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+	upper := lower
+	switch {
+	case len(parts) == 1:
+		upper = semver.Version{Major: lower.Major + 1}
+	case s[0] == '~':
+		upper = semver.Version{Major: lower.Major, Minor: lower.Minor + 1}
+	// A caret allows updates which do not change the left-most non-zero
+	// number.
+	case lower.Major > 0:
+		upper = semver.Version{Major: lower.Major + 1}
+	case lower.Minor > 0 || len(parts) == 2:
+		upper = semver.Version{Minor: lower.Minor + 1}
+	default:
+		upper = semver.Version{Patch: lower.Patch + 1}
+	}
+	return semver.ParseRange(fmt.Sprintf(">=%s <%s", lower, upper))
+}
+
+// tagVersion returns the version of a tag of the form [v]MAJOR.MINOR.PATCH.
+// Other tags, including pre-releases, are not considered versions.
+func tagVersion(tag string) (semver.Version, bool) {
+	v, err := semver.Parse(strings.TrimPrefix(tag, "v"))
+	if err != nil || len(v.Pre) > 0 || len(v.Build) > 0 {
+		return semver.Version{}, false
+	}
+	return v, true
+}
+
+// newestTag returns the tag with the highest version in the range, and its
+// version.  It returns false if no tag matches.
+func newestTag(tags []string, versionRange semver.Range) (string, semver.Version, bool) {
+	var (
+		newest        string
+		newestVersion semver.Version
+		found         bool
+	)
+	for _, tag := range tags {
+		v, ok := tagVersion(tag)
+		if !ok || !versionRange(v) {
+			continue
+		}
+		// Prefer tags without a "v" prefix for the same version to be
+		// deterministic.
+		if !found || v.GT(newestVersion) || (v.EQ(newestVersion) && tag < newest) {
+			newest, newestVersion, found = tag, v, true
+		}
+	}
+	return newest, newestVersion, found
+}
+
+// semverReference returns the reference to the task's raw image name which
+// must be tagged.
+func (t *task) semverReference() (reference.NamedTagged, error) {
+	named, err := reference.ParseNormalizedNamed(strings.TrimPrefix(t.rawImageName, "docker://"))
+	if err != nil {
+		return nil, err
+	}
+	tagged, ok := named.(reference.NamedTagged)
+	if !ok {
+		return nil, fmt.Errorf("image %q of container %s has no tag, which the %s policy requires", t.rawImageName, t.container.ID(), PolicySemver)
+	}
+	return tagged, nil
+}
+
+// deployedVersion returns the highest version among the tags of the task's
+// image in the repository of its raw image name.  When the semver policy
+// updates a container, the new image is tagged with both the raw image name
+// and its version, so the version the container runs can be found this way.
+func (t *task) deployedVersion(repo reference.Named) (semver.Version, bool) {
+	var (
+		deployed semver.Version
+		found    bool
+	)
+	for _, name := range t.image.Names() {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil || named.Name() != repo.Name() {
+			continue
+		}
+		tagged, ok := named.(reference.NamedTagged)
+		if !ok {
+			continue
+		}
+		if v, ok := tagVersion(tagged.Tag()); ok && (!found || v.GT(deployed)) {
+			deployed, found = v, true
+		}
+	}
+	return deployed, found
+}
+
+// semverUpdateAvailable returns whether the registry has a tag with a higher
+// version in the task's range than the version the container runs.
+func (t *task) semverUpdateAvailable(ctx context.Context) (bool, error) {
+	rawRef, err := t.semverReference()
+	if err != nil {
+		return false, err
+	}
+	deployed, found := t.deployedVersion(rawRef)
+	if found {
+		t.version = deployed.String()
+	}
+
+	repoRef, err := docker.NewReference(reference.TrimNamed(rawRef))
+	if err != nil {
+		return false, err
+	}
+	sys := t.systemContext()
+	tags, err := docker.GetRepositoryTags(ctx, sys, repoRef)
+	if err != nil {
+		return false, fmt.Errorf("listing tags of %s: %w", rawRef.Name(), err)
+	}
+	tag, newest, ok := newestTag(tags, t.semverRange)
+	if !ok {
+		return false, fmt.Errorf("no tag of %s matches version range %q", rawRef.Name(), t.semverRangeString)
+	}
+	if found && !newest.GT(deployed) {
+		return false, nil
+	}
+
+	newNamed, err := reference.WithTag(reference.TrimNamed(rawRef), tag)
+	if err != nil {
+		return false, err
+	}
+	newRef, err := docker.NewReference(newNamed)
+	if err != nil {
+		return false, err
+	}
+	newDigest, err := docker.GetDigest(ctx, sys, newRef)
+	if err != nil {
+		return false, fmt.Errorf("looking up digest of %s: %w", newNamed, err)
+	}
+	// Without a version tag, e.g., for a container created from app:1.4,
+	// the container may run the newest version already.
+	if !found && t.imageHasDigest(newDigest) {
+		t.version = newest.String()
+		return false, nil
+	}
+	t.newImageName = newNamed.String()
+	t.newVersion = newest.String()
+	t.newDigest = newDigest.String()
+	return true, nil
+}
+
+// imageHasDigest returns whether d is one of the digests of the task's image,
+// which include the digest of the manifest list the image was pulled from.
+func (t *task) imageHasDigest(d digest.Digest) bool {
+	for _, imageDigest := range t.image.Digests() {
+		if imageDigest == d {
+			return true
+		}
+	}
+	return false
+}
+
+// semverUpdate pulls the image with the newest version in the task's range and
+// tags it with the container's raw image name, which the systemd unit uses to
+// create the container.
+func (t *task) semverUpdate(ctx context.Context) error {
+	pullOptions := &libimage.PullOptions{}
+	pullOptions.AuthFilePath = t.authfile
+	pullOptions.Writer = os.Stderr
+	pullOptions.InsecureSkipTLSVerify = t.auto.options.InsecureSkipTLSVerify
+	pulled, err := t.auto.runtime.LibimageRuntime().Pull(ctx, t.newImageName, config.PullPolicyAlways, pullOptions)
+	if err != nil {
+		return err
+	}
+	if len(pulled) == 0 {
+		return fmt.Errorf("internal error: no image pulled for %s", t.newImageName)
+	}
+	if err := pulled[0].Tag(t.rawImageName); err != nil {
+		return err
+	}
+	t.auto.updatedRawImages[t.rawImageName] = true
+	return nil
+}
+
+// systemContext returns the system context for contacting the registry of the
+// task's image.
+func (t *task) systemContext() *types.SystemContext {
+	return &types.SystemContext{
+		AuthFilePath:                t.authfile,
+		DockerInsecureSkipTLSVerify: t.auto.options.InsecureSkipTLSVerify,
+	}
+}
@@ -0,0 +1,67 @@
+//go:build !remote
+
+package autoupdate
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemverRange(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		match    []string
+		mismatch []string
+	}{
+		{"~1.4", []string{"1.4.0", "1.4.9"}, []string{"1.3.9", "1.5.0"}},
+		{"~1.4.2", []string{"1.4.2", "1.4.9"}, []string{"1.4.1", "1.5.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"0.9.0", "2.0.0"}},
+		{"^1.4", []string{"1.4.0", "1.9.0"}, []string{"1.3.0", "2.0.0"}},
+		{"^0.4.1", []string{"0.4.1", "0.4.9"}, []string{"0.4.0", "0.5.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"^v2.1", []string{"2.1.0", "2.9.9"}, []string{"3.0.0"}},
+		{">=1.4.0 <1.6.0", []string{"1.4.0", "1.5.9"}, []string{"1.6.0"}},
+	} {
+		r, err := parseSemverRange(tc.input)
+		require.NoError(t, err, tc.input)
+		for _, v := range tc.match {
+			assert.True(t, r(semver.MustParse(v)), "%s should match %s", tc.input, v)
+		}
+		for _, v := range tc.mismatch {
+			assert.False(t, r(semver.MustParse(v)), "%s should not match %s", tc.input, v)
+		}
+	}
+
+	for _, input := range []string{"", "~", "~1.x", "^1.2.3.4", "latest"} {
+		_, err := parseSemverRange(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNewestTag(t *testing.T) {
+	r, err := parseSemverRange("~1.4")
+	require.NoError(t, err)
+
+	tags := []string{"latest", "1.4", "1.4.2", "v1.4.10", "1.4.10", "1.4.11-rc1", "1.5.0", "1.4.3-alpine"}
+	tag, v, ok := newestTag(tags, r)
+	assert.True(t, ok)
+	assert.Equal(t, "1.4.10", tag)
+	assert.Equal(t, "1.4.10", v.String())
+
+	_, _, ok = newestTag([]string{"latest", "2.0.0"}, r)
+	assert.False(t, ok)
+}
+
+func TestLookupSemverPolicy(t *testing.T) {
+	policy, err := LookupPolicy("semver:~1.4")
+	require.NoError(t, err)
+	assert.Equal(t, Policy(PolicySemver), policy)
+
+	_, err = LookupPolicy("semver:")
+	assert.Error(t, err)
+	_, err = LookupPolicy("semver")
+	assert.ErrorContains(t, err, `"semver:RANGE"`)
+}
@@ -2,6 +2,8 @@ package bindings
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -85,6 +87,7 @@ func NewConnection(ctx context.Context, uri string) (context.Context, error) {
 //
 // A valid URI connection should be scheme://
 // For example tcp://localhost:<port>
+// or tcp+tls://localhost:<port>?tls_ca=<path>&tls_cert=<path>&tls_key=<path>
 // or unix:///run/podman/podman.sock
 // or ssh://<user>@<host>[:port]/run/podman/podman.sock?secure=True
 func NewConnectionWithIdentity(ctx context.Context, uri string, identity string, machine bool) (context.Context, error) {
@@ -143,7 +146,20 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string,
 		if !strings.HasPrefix(uri, "tcp://") {
 			return nil, errors.New("tcp URIs should begin with tcp://")
 		}
-		conn, err := tcpClient(_url)
+		conn, err := tcpClient(_url, nil)
+		if err != nil {
+			return nil, newConnectError(err)
+		}
+		connection = conn
+	case "tcp+tls":
+		if !strings.HasPrefix(uri, "tcp+tls://") {
+			return nil, errors.New("tcp+tls URIs should begin with tcp+tls://")
+		}
+		tlsConfig, err := newTLSConfig(_url)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := tcpClient(_url, tlsConfig)
 		if err != nil {
 			return nil, newConnectError(err)
 		}
@@ -161,7 +177,41 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string,
 	return ctx, nil
 }
 
-func tcpClient(_url *url.URL) (Connection, error) {
+// newTLSConfig loads the certificates referenced by the tls_ca, tls_cert and
+// tls_key query parameters of a tcp+tls URI.  Without tls_ca, the service is
+// verified against the system roots.
+func newTLSConfig(_url *url.URL) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: _url.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+	query := _url.Query()
+	caFile, certFile, keyFile := query.Get("tls_ca"), query.Get("tls_cert"), query.Get("tls_key")
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both a TLS client certificate and key are required")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func tcpClient(_url *url.URL, tlsConfig *tls.Config) (Connection, error) {
 	connection := Connection{
 		URI: _url,
 	}
@@ -193,6 +243,22 @@ func tcpClient(_url *url.URL) (Connection, error) {
 			}
 		}
 	}
+	if tlsConfig != nil {
+		// requests are always built for http://, so the handshake happens here
+		dialPlain := dialContext
+		dialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dialPlain(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			tlsConn := tls.Client(conn, tlsConfig)
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		}
+	}
 	connection.Client = &http.Client{
 		Transport: &http.Transport{
 			DialContext:        dialContext,
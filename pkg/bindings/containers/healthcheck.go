@@ -2,10 +2,14 @@ package containers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/domain/entities"
 )
 
 // RunHealthCheck executes the container's healthcheck and returns the health status of the
@@ -30,3 +34,63 @@ func RunHealthCheck(ctx context.Context, nameOrID string, options *HealthCheckOp
 
 	return &status, response.Process(&status)
 }
+
+// HealthCheckInspect returns the healthcheck configuration, the current health
+// status and the probe history of a container.
+func HealthCheckInspect(ctx context.Context, nameOrID string, options *HealthCheckInspectOptions) (*entities.HealthCheckInspectReport, error) {
+	if options == nil {
+		options = new(HealthCheckInspectOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var report entities.HealthCheckInspectReport
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/healthcheck/json", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// HealthCheckLogs sends the probes selected from the healthcheck history of a
+// container to entries.  If follow is set, new probes are sent until the
+// container stops running.  The channel is closed before returning.
+func HealthCheckLogs(ctx context.Context, nameOrID string, options *HealthCheckLogsOptions, entries chan *define.HealthCheckHistoryEntry) error {
+	defer close(entries)
+	if options == nil {
+		options = new(HealthCheckLogsOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/healthcheck/logs", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return response.Process(nil)
+	}
+
+	dec := json.NewDecoder(response.Body)
+	for {
+		entry := new(define.HealthCheckHistoryEntry)
+		if err := dec.Decode(entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("unable to decode healthcheck history: %w", err)
+		}
+		entries <- entry
+	}
+}
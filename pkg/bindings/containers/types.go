@@ -123,6 +123,23 @@ type ExecStartOptions struct {
 //go:generate go run ../generator/generator.go HealthCheckOptions
 type HealthCheckOptions struct{}
 
+// HealthCheckInspectOptions are optional options for inspecting
+// the healthcheck of a container
+//
+//go:generate go run ../generator/generator.go HealthCheckInspectOptions
+type HealthCheckInspectOptions struct{}
+
+// HealthCheckLogsOptions describe the probes selected from the
+// healthcheck history of a container
+//
+//go:generate go run ../generator/generator.go HealthCheckLogsOptions
+type HealthCheckLogsOptions struct {
+	Follow *bool
+	Since  *string
+	Status *string
+	Until  *string
+}
+
 // MountOptions are optional options for mounting
 // containers
 //
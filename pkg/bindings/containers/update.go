@@ -3,6 +3,8 @@ package containers
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v4/pkg/bindings"
@@ -16,12 +18,52 @@ func Update(ctx context.Context, options *entities.ContainerUpdateOptions) (stri
 		return "", err
 	}
 
-	resources, err := jsoniter.MarshalToString(options.Specgen.ResourceLimits)
-	if err != nil {
-		return "", err
+	params := url.Values{}
+	if options.Restart != nil {
+		params.Set("restartPolicy", *options.Restart)
+	}
+	if options.HealthCmd != nil {
+		params.Set("healthCmd", *options.HealthCmd)
+	}
+	if options.HealthInterval != nil {
+		params.Set("healthInterval", *options.HealthInterval)
+	}
+	if options.HealthRetries != nil {
+		params.Set("healthRetries", strconv.FormatUint(uint64(*options.HealthRetries), 10))
+	}
+	if options.HealthStartPeriod != nil {
+		params.Set("healthStartPeriod", *options.HealthStartPeriod)
+	}
+	if options.HealthTimeout != nil {
+		params.Set("healthTimeout", *options.HealthTimeout)
+	}
+	if options.HealthOnFailure != nil {
+		params.Set("healthOnFailure", *options.HealthOnFailure)
+	}
+	for _, e := range options.Env {
+		params.Add("env", e)
+	}
+	for _, e := range options.UnsetEnv {
+		params.Add("unsetEnv", e)
+	}
+	for _, l := range options.Labels {
+		params.Add("labels", l)
+	}
+	for _, l := range options.UnsetLabels {
+		params.Add("unsetLabels", l)
+	}
+
+	var resources string
+	if options.Specgen != nil {
+		resources, err = jsoniter.MarshalToString(options.Specgen.ResourceLimits)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		resources = "null"
 	}
 	stringReader := strings.NewReader(resources)
-	response, err := conn.DoRequest(ctx, stringReader, http.MethodPost, "/containers/%s/update", nil, nil, options.NameOrID)
+	response, err := conn.DoRequest(ctx, stringReader, http.MethodPost, "/containers/%s/update", params, nil, options.NameOrID)
 	if err != nil {
 		return "", err
 	}
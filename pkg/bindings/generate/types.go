@@ -14,6 +14,10 @@ type KubeOptions struct {
 	Replicas *int32
 	// NoTrunc - don't truncate annotations to the Kubernetes maximum length of 63 characters
 	NoTrunc *bool
+	// ConfigMaps - move the environment variables of the containers into ConfigMaps
+	ConfigMaps *bool
+	// SecretData - generate Secrets including the data of the podman secrets used by the containers
+	SecretData *bool
 }
 
 // SystemdOptions are optional options for generating systemd files
@@ -91,3 +91,33 @@ func (o *KubeOptions) GetNoTrunc() bool {
 	}
 	return *o.NoTrunc
 }
+
+// WithConfigMaps set field ConfigMaps to given value
+func (o *KubeOptions) WithConfigMaps(value bool) *KubeOptions {
+	o.ConfigMaps = &value
+	return o
+}
+
+// GetConfigMaps returns value of field ConfigMaps
+func (o *KubeOptions) GetConfigMaps() bool {
+	if o.ConfigMaps == nil {
+		var z bool
+		return z
+	}
+	return *o.ConfigMaps
+}
+
+// WithSecretData set field SecretData to given value
+func (o *KubeOptions) WithSecretData(value bool) *KubeOptions {
+	o.SecretData = &value
+	return o
+}
+
+// GetSecretData returns value of field SecretData
+func (o *KubeOptions) GetSecretData() bool {
+	if o.SecretData == nil {
+		var z bool
+		return z
+	}
+	return *o.SecretData
+}
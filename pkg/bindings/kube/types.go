@@ -61,6 +61,12 @@ type PlayOptions struct {
 	// Wait - indicates whether to return after having created the pods
 	Wait             *bool
 	ServiceContainer *bool
+	// WaitReady - block until all containers are running and healthy
+	WaitReady *bool
+	// WaitReadyTimeout - maximum time in seconds to wait for the
+	// containers to become ready, 0 waits forever, the service defaults to
+	// 300 seconds
+	WaitReadyTimeout *uint
 }
 
 // ApplyOptions are optional options for applying kube YAML files to a k8s cluster
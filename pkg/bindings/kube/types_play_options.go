@@ -392,3 +392,33 @@ func (o *PlayOptions) GetServiceContainer() bool {
 	}
 	return *o.ServiceContainer
 }
+
+// WithWaitReady set field WaitReady to given value
+func (o *PlayOptions) WithWaitReady(value bool) *PlayOptions {
+	o.WaitReady = &value
+	return o
+}
+
+// GetWaitReady returns value of field WaitReady
+func (o *PlayOptions) GetWaitReady() bool {
+	if o.WaitReady == nil {
+		var z bool
+		return z
+	}
+	return *o.WaitReady
+}
+
+// WithWaitReadyTimeout set field WaitReadyTimeout to given value
+func (o *PlayOptions) WithWaitReadyTimeout(value uint) *PlayOptions {
+	o.WaitReadyTimeout = &value
+	return o
+}
+
+// GetWaitReadyTimeout returns value of field WaitReadyTimeout
+func (o *PlayOptions) GetWaitReadyTimeout() uint {
+	if o.WaitReadyTimeout == nil {
+		var z uint
+		return z
+	}
+	return *o.WaitReadyTimeout
+}
@@ -83,6 +83,27 @@ func Prune(ctx context.Context, options *PruneOptions) (*entities.SystemPruneRep
 	return &report, response.Process(&report)
 }
 
+// Check verifies the consistency of the libpod state and optionally repairs
+// it.
+func Check(ctx context.Context, options *CheckOptions) (*define.SystemCheckReport, error) {
+	var report define.SystemCheckReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/system/check", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
 func Version(ctx context.Context, options *VersionOptions) (*entities.SystemVersionReport, error) {
 	var (
 		component entities.ComponentVersion
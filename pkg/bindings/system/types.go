@@ -4,10 +4,11 @@ package system
 //
 //go:generate go run ../generator/generator.go EventsOptions
 type EventsOptions struct {
-	Filters map[string][]string
-	Since   *string
-	Stream  *bool
-	Until   *string
+	Filters     map[string][]string
+	Since       *string
+	Stream      *bool
+	Until       *string
+	AfterCursor *string
 }
 
 // PruneOptions are optional options for pruning
@@ -20,6 +21,14 @@ type PruneOptions struct {
 	External *bool
 }
 
+// CheckOptions are optional options for checking the libpod state
+//
+//go:generate go run ../generator/generator.go CheckOptions
+type CheckOptions struct {
+	Quick  *bool
+	Repair *bool
+}
+
 // VersionOptions are optional options for getting version info
 //
 //go:generate go run ../generator/generator.go VersionOptions
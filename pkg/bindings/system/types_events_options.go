@@ -76,3 +76,18 @@ func (o *EventsOptions) GetUntil() string {
 	}
 	return *o.Until
 }
+
+// WithAfterCursor set field AfterCursor to given value
+func (o *EventsOptions) WithAfterCursor(value string) *EventsOptions {
+	o.AfterCursor = &value
+	return o
+}
+
+// GetAfterCursor returns value of field AfterCursor
+func (o *EventsOptions) GetAfterCursor() string {
+	if o.AfterCursor == nil {
+		var z string
+		return z
+	}
+	return *o.AfterCursor
+}
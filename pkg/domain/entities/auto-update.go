@@ -1,6 +1,10 @@
 package entities
 
-import "github.com/containers/image/v5/types"
+import (
+	"time"
+
+	"github.com/containers/image/v5/types"
+)
 
 // AutoUpdateOptions are the options for running auto-update.
 type AutoUpdateOptions struct {
@@ -13,6 +17,10 @@ type AutoUpdateOptions struct {
 	// If restarting the service with the new image failed, restart it
 	// another time with the previous image.
 	Rollback bool
+	// How long to wait for the updated containers to pass their health
+	// checks before the update is considered failed.  Zero disables
+	// waiting.
+	HealthTimeout time.Duration
 	// Allow contacting registries over HTTP, or HTTPS with failed TLS
 	// verification. Note that this does not affect other TLS connections.
 	InsecureSkipTLSVerify types.OptionalBool
@@ -31,6 +39,19 @@ type AutoUpdateReport struct {
 	// SystemdUnit running a container configured for auto updates.
 	SystemdUnit string
 	// Indicates the update status: true, false, failed, pending (see
-	// DryRun).
+	// DryRun), rolled back.
 	Updated string
+	// Reason describes why the update failed or was rolled back.
+	Reason string
+	// Digest of the image *before* an update.
+	ImageDigest string
+	// Digest of the image to update to, if an update is available.
+	NewImageDigest string
+	// Name of the image to update to if it differs from ImageName, e.g.,
+	// a newer version with the semver policy.
+	NewImageName string
+	// Version of the image *before* an update with the semver policy.
+	ImageVersion string
+	// Version of the image to update to with the semver policy.
+	NewImageVersion string
 }
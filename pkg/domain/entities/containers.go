@@ -283,6 +283,11 @@ type ContainerLogsOptions struct {
 	Timestamps bool
 	// Show different colors in the logs.
 	Colors bool
+	// Order the lines of all containers by their timestamps.
+	Merge bool
+	// Go template written before every line instead of the container
+	// ID or name. Not supported on the remote client.
+	Prefix string
 	// Write the stdout to this Writer.
 	StdoutWriter io.Writer
 	// Write the stderr to this Writer.
@@ -512,7 +517,29 @@ type ContainerCloneOptions struct {
 }
 
 // ContainerUpdateOptions containers options for updating an existing containers cgroup configuration
+// and, for its next start, its restart policy, healthcheck, environment and labels.
+// Nil or empty fields are left unchanged.
 type ContainerUpdateOptions struct {
 	NameOrID string
 	Specgen  *specgen.SpecGenerator
+	// Restart is the new restart policy, e.g. on-failure:3.
+	Restart *string
+	// HealthCmd replaces the healthcheck command ("none" removes the healthcheck).
+	HealthCmd *string
+	// HealthInterval, HealthRetries, HealthStartPeriod and HealthTimeout
+	// change the respective settings of the healthcheck.
+	HealthInterval    *string
+	HealthRetries     *uint
+	HealthStartPeriod *string
+	HealthTimeout     *string
+	// HealthOnFailure is the new action to take once the container turns unhealthy.
+	HealthOnFailure *string
+	// Env are environment variables (key=value) to add or replace.
+	Env []string
+	// UnsetEnv are the names of environment variables to remove.
+	UnsetEnv []string
+	// Labels are labels (key=value) to add or replace.
+	Labels []string
+	// UnsetLabels are the names of labels to remove.
+	UnsetLabels []string
 }
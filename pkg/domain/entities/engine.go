@@ -42,6 +42,7 @@ type PodmanConfig struct {
 	EngineMode               EngineMode     // ABI or Tunneling mode
 	HooksDir                 []string
 	Identity                 string   // ssh identity for connecting to server
+	EventsWebhookURL         string   // endpoint the webhook events backend forwards events to
 	MaxWorks                 int      // maximum number of parallel threads
 	MemoryProfile            string   // Hidden: Should memory profile be taken
 	RegistriesConf           string   // allows for specifying a custom registries.conf
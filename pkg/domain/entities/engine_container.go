@@ -58,7 +58,10 @@ type ContainerEngine interface { //nolint:interfacebloat
 	GenerateSpec(ctx context.Context, opts *GenerateSpecOptions) (*GenerateSpecReport, error)
 	GenerateSystemd(ctx context.Context, nameOrID string, opts GenerateSystemdOptions) (*GenerateSystemdReport, error)
 	GenerateKube(ctx context.Context, nameOrIDs []string, opts GenerateKubeOptions) (*GenerateKubeReport, error)
+	SystemCheck(ctx context.Context, options SystemCheckOptions) (*define.SystemCheckReport, error)
 	SystemPrune(ctx context.Context, options SystemPruneOptions) (*SystemPruneReport, error)
+	HealthCheckInspect(ctx context.Context, nameOrID string, options HealthCheckInspectOptions) (*HealthCheckInspectReport, error)
+	HealthCheckLogs(ctx context.Context, nameOrID string, options HealthCheckLogsOptions) error
 	HealthCheckRun(ctx context.Context, nameOrID string, options HealthCheckOptions) (*define.HealthCheckResults, error)
 	Info(ctx context.Context) (*define.Info, error)
 	KubeApply(ctx context.Context, body io.Reader, opts ApplyOptions) error
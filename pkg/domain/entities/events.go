@@ -15,6 +15,8 @@ type Event struct {
 	// point and fork such Docker types.
 	dockerEvents.Message
 	HealthStatus string `json:",omitempty"`
+	// Cursor to resume reading events after this event.
+	Cursor string `json:",omitempty"`
 }
 
 // ConvertToLibpodEvent converts an entities event to a libpod one.
@@ -51,6 +53,7 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Time:              time.Unix(0, e.TimeNano),
 		Type:              t,
 		HealthStatus:      e.HealthStatus,
+		Cursor:            e.Cursor,
 		Details: libpodEvents.Details{
 			PodID:      podID,
 			Attributes: details,
@@ -88,5 +91,6 @@ func ConvertToEntitiesEvent(e libpodEvents.Event) *Event {
 	return &Event{
 		message,
 		e.HealthStatus,
+		e.Cursor,
 	}
 }
@@ -39,6 +39,10 @@ type GenerateKubeOptions struct {
 	Replicas int32
 	// UseLongAnnotations - don't truncate annotations to the Kubernetes maximum length of 63 characters
 	UseLongAnnotations bool
+	// ConfigMaps - move the environment variables of the containers into ConfigMaps
+	ConfigMaps bool
+	// SecretData - generate Secrets including the data of the podman secrets used by the containers
+	SecretData bool
 }
 
 type KubeGenerateOptions = GenerateKubeOptions
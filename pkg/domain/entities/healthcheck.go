@@ -1,3 +1,50 @@
 package entities
 
+import (
+	"time"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v4/libpod/define"
+)
+
 type HealthCheckOptions struct{}
+
+// HealthCheckInspectOptions are the options for inspecting the healthcheck of
+// a container.
+type HealthCheckInspectOptions struct{}
+
+// HealthCheckInspectReport describes the healthcheck of a container, its
+// current health status and all probes in its history.
+type HealthCheckInspectReport struct {
+	// ID of the container
+	ID string
+	// Name of the container
+	Name string
+	// Healthcheck is the healthcheck configuration
+	Healthcheck *manifest.Schema2HealthConfig `json:",omitempty"`
+	// OnFailureAction is the action taken once the container turns unhealthy
+	OnFailureAction string `json:",omitempty"`
+	// Status is the current health status
+	Status string
+	// FailingStreak is the number of consecutive failed probes
+	FailingStreak int
+	// History lists all recorded probes, oldest first
+	History []define.HealthCheckHistoryEntry
+	// OnFailureLog lists the last runs of the on-failure command
+	OnFailureLog []define.HealthCheckLog `json:",omitempty"`
+}
+
+// HealthCheckLogsOptions are the options for listing the healthcheck history
+// of a container.
+type HealthCheckLogsOptions struct {
+	// Since only lists probes started at or after this time
+	Since time.Time
+	// Until only lists probes started at or before this time
+	Until time.Time
+	// Status only lists probes which left the container in this status
+	Status string
+	// Follow lists new probes until the container stops running
+	Follow bool
+	// EntryChan receives the probes and is closed once all have been sent
+	EntryChan chan *define.HealthCheckHistoryEntry
+}
@@ -491,6 +491,14 @@ type FarmInspectReport struct {
 	OS                string
 	Arch              string
 	Variant           string
+	// CPUs is the number of CPUs of the node.
+	CPUs int
+	// MemTotal and MemFree are the total and the free memory of the node
+	// in bytes.
+	MemTotal int64
+	MemFree  int64
+	// RunningBuilds is the number of builds in progress on the node.
+	RunningBuilds int
 }
 
 // PullToFileOptions are the options for pulling the images from farm
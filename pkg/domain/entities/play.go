@@ -6,6 +6,10 @@ import (
 	"github.com/containers/image/v5/types"
 )
 
+// DefaultPlayKubeWaitReadyTimeout is the default time in seconds to wait for
+// the containers to become ready when playing kube YAML with WaitReady.
+const DefaultPlayKubeWaitReadyTimeout uint = 300
+
 // PlayKubeOptions controls playing kube YAML files.
 type PlayKubeOptions struct {
 	// Annotations - Annotations to add to Pods
@@ -75,6 +79,13 @@ type PlayKubeOptions struct {
 	PublishAllPorts bool
 	// Wait - indicates whether to return after having created the pods
 	Wait bool
+	// WaitReady - block until all containers of the pods are running and
+	// healthy, one of them fails or WaitReadyTimeout expires.
+	WaitReady bool
+	// WaitReadyTimeout - maximum time in seconds to wait for the
+	// containers to become ready, 0 waits forever.  The CLI and the REST
+	// API default to DefaultPlayKubeWaitReadyTimeout.
+	WaitReadyTimeout uint
 	// SystemContext - used when building the image
 	SystemContext *types.SystemContext
 }
@@ -92,6 +103,29 @@ type PlayKubePod struct {
 	// ContainerErrors - any errors that occurred while starting containers
 	// in the pod.
 	ContainerErrors []string
+	// ContainerStatuses - the final state of the containers in the pod,
+	// only set when waiting for the pod to become ready.
+	ContainerStatuses []PlayKubeContainerStatus
+}
+
+// PlayKubeContainerStatus describes the state of a container after waiting
+// for it to become ready.
+type PlayKubeContainerStatus struct {
+	// ID - ID of the container.
+	ID string
+	// Name - name of the container.
+	Name string
+	// State - state of the container, e.g. running or exited.
+	State string
+	// ExitCode - exit code of the container if it has exited.
+	ExitCode int32
+	// Health - healthcheck status of the container, empty if the
+	// container does not have a healthcheck.
+	Health string
+	// Ready - whether the container is running and healthy.
+	Ready bool
+	// ProbeOutput - output of the last failed healthcheck or startup probe.
+	ProbeOutput string
 }
 
 // PlayKubeVolume represents a single volume created by play kube.
@@ -100,6 +134,12 @@ type PlayKubeVolume struct {
 	Name string
 }
 
+// PlayKubeTimer represents a systemd timer created by play kube.
+type PlayKubeTimer struct {
+	// Name - Name of the systemd timer unit.
+	Name string
+}
+
 // PlayKubeReport contains the results of running play kube.
 type PlayKubeReport struct {
 	// Pods - pods created by play kube.
@@ -109,10 +149,15 @@ type PlayKubeReport struct {
 	PlayKubeTeardown
 	// Secrets - secrets created by play kube
 	Secrets []PlaySecret
+	// Timers - systemd timers created by play kube for CronJobs
+	Timers []PlayKubeTimer
 	// ServiceContainerID - ID of the service container if one is created
 	ServiceContainerID string
 	// If set, exit with the specified exit code.
 	ExitCode *int32
+	// NotReady - set if not all containers became ready when waiting
+	// for the pods to become ready.
+	NotReady bool
 }
 
 type KubePlayReport = PlayKubeReport
@@ -129,6 +174,14 @@ type PlayKubeTeardown struct {
 	RmReport       []*PodRmReport
 	VolumeRmReport []*VolumeRmReport
 	SecretRmReport []*SecretRmReport
+	TimerRmReport  []*PlayKubeTimerRmReport
+}
+
+// PlayKubeTimerRmReport contains the result of removing a systemd timer
+// created by play kube.
+type PlayKubeTimerRmReport struct {
+	Name string
+	Err  error
 }
 
 type PlaySecret struct {
@@ -133,6 +133,7 @@ type PodCreateOptions struct {
 	InfraConmonPidFile string            `json:"container_conmon_pidfile,omitempty"`
 	Ipc                string            `json:"ipc,omitempty"`
 	Labels             map[string]string `json:"labels,omitempty"`
+	LogQuota           int64             `json:"log_quota,omitempty"`
 	Name               string            `json:"name,omitempty"`
 	Net                *NetOptions       `json:"net,omitempty"`
 	Share              []string          `json:"share,omitempty"`
@@ -218,6 +219,8 @@ type ContainerCreateOptions struct {
 	HealthStartPeriod  string
 	HealthTimeout      string
 	HealthOnFailure    string
+	HealthOnFailureCmd string
+	HealthOnFailureCtr bool
 	Hostname           string `json:"hostname,omitempty"`
 	HTTPProxy          bool
 	HostUsers          []string
@@ -372,6 +375,7 @@ func ToPodSpecGen(s specgen.PodSpecGenerator, p *PodCreateOptions) (*specgen.Pod
 	s.UtsNs = out
 	s.Hostname = p.Hostname
 	s.ExitPolicy = p.ExitPolicy
+	s.LogQuota = p.LogQuota
 	s.Labels = p.Labels
 	s.Devices = p.Devices
 	s.SecurityOpt = p.SecurityOpt
@@ -556,6 +560,8 @@ func PodLogsOptionsToContainerLogsOptions(options PodLogsOptions) ContainerLogsO
 		Tail:         options.Tail,
 		Timestamps:   options.Timestamps,
 		Colors:       options.Colors,
+		Merge:        options.Merge,
+		Prefix:       options.Prefix,
 		StdoutWriter: options.StdoutWriter,
 		StderrWriter: options.StderrWriter,
 	}
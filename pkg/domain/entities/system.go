@@ -10,10 +10,39 @@ import (
 
 // ServiceOptions provides the input for starting an API and sidecar pprof services
 type ServiceOptions struct {
-	CorsHeaders string        // Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr   string        // Network address to bind pprof profiles service
-	Timeout     time.Duration // Duration of inactivity the service should wait before shutting down
-	URI         string        // Path to unix domain socket service should listen on
+	CorsHeaders     string        // Cross-Origin Resource Sharing (CORS) headers
+	PProfAddr       string        // Network address to bind pprof profiles service
+	Timeout         time.Duration // Duration of inactivity the service should wait before shutting down
+	URI             string        // Path to unix domain socket service should listen on
+	TLSCert         string        // Path to the certificate served on TCP listeners
+	TLSKey          string        // Path to the private key of TLSCert
+	TLSClientCA     string        // Path to the CA bundle used to verify client certificates
+	TLSNoClientAuth bool          // Serve TLS without verifying client certificates, TLSClientCA must be empty
+	EnableMetrics   bool          // Expose Prometheus metrics at /metrics
+	DrainTimeout    time.Duration // Time to wait for requests in flight when stopped by a signal, 0 to stop right away
+}
+
+// ServiceDrainReport describes the requests in flight when the API service
+// started draining.
+type ServiceDrainReport struct {
+	// Deadline until which the service waits for the requests to finish.
+	Deadline time.Time
+	// Requests in flight when the service started draining.
+	Requests []ServiceRequest
+}
+
+// ServiceRequest describes an API request in flight.
+type ServiceRequest struct {
+	Method  string
+	Path    string
+	Started time.Time
+}
+
+// SystemCheckOptions provides options to check the consistency of the
+// libpod state.
+type SystemCheckOptions struct {
+	Quick  bool
+	Repair bool
 }
 
 // SystemPruneOptions provides options to prune system.
@@ -38,6 +67,7 @@ type SystemPruneReport struct {
 // cli to migrate runtimes of containers
 type SystemMigrateOptions struct {
 	NewRuntime string
+	Database   string
 }
 
 // SystemDfOptions describes the options for getting df information
@@ -90,12 +90,13 @@ type DiffReport struct {
 }
 
 type EventsOptions struct {
-	FromStart bool
-	EventChan chan *events.Event
-	Filter    []string
-	Stream    bool
-	Since     string
-	Until     string
+	FromStart   bool
+	EventChan   chan *events.Event
+	Filter      []string
+	Stream      bool
+	Since       string
+	Until       string
+	AfterCursor string
 }
 
 // ContainerCreateResponse is the response struct for creating a container
@@ -131,6 +132,12 @@ type BuildReport struct {
 type FarmBuildOptions struct {
 	// Cleanup removes built images from farm nodes on success
 	Cleanup bool
+	// Push has the farm nodes push the images to the registry and pushes
+	// the manifest list referring to them, without copying the images to
+	// the local host
+	Push bool
+	// LocalCopy keeps a copy of the pushed manifest list in local storage
+	LocalCopy bool
 }
 
 type IDOrNameResponse struct {
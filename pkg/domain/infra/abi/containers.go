@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/containers/podman/v4/pkg/specgenutil"
 	"github.com/containers/podman/v4/pkg/util"
 	"github.com/containers/storage"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
 
@@ -832,7 +834,7 @@ func makeExecConfig(options entities.ExecOptions, rt *libpod.Runtime) (*libpod.E
 		return nil, fmt.Errorf("retrieving Libpod configuration to build exec exit command: %w", err)
 	}
 	// TODO: Add some ability to toggle syslog
-	exitCommandArgs, err := specgenutil.CreateExitCommandArgs(storageConfig, runtimeConfig, logrus.IsLevelEnabled(logrus.DebugLevel), false, true)
+	exitCommandArgs, err := specgenutil.CreateExitCommandArgs(storageConfig, runtimeConfig, rt.EventsWebhookURL(), logrus.IsLevelEnabled(logrus.DebugLevel), false, true)
 	if err != nil {
 		return nil, fmt.Errorf("constructing exit command for exec session: %w", err)
 	}
@@ -1247,8 +1249,15 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 		Timestamps: options.Timestamps,
 		Colors:     options.Colors,
 		UseName:    options.Names,
+		Merge:      options.Merge,
 		WaitGroup:  &wg,
 	}
+	if options.Prefix != "" {
+		logOpts.Prefix, err = logs.ParsePrefix(options.Prefix)
+		if err != nil {
+			return err
+		}
+	}
 
 	chSize := len(containers)
 	logChannel := make(chan *logs.LogLine, chSize)
@@ -1267,9 +1276,16 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, namesOrIds []strin
 		close(logChannel)
 	}()
 
-	for line := range logChannel {
+	write := func(line *logs.LogLine) {
 		line.Write(options.StdoutWriter, options.StderrWriter, logOpts)
 	}
+	if logOpts.Merge {
+		logs.Merge(logChannel, logOpts.Follow, write)
+		return nil
+	}
+	for line := range logChannel {
+		write(line)
+	}
 
 	return nil
 }
@@ -1750,13 +1766,15 @@ func (ic *ContainerEngine) ContainerClone(ctx context.Context, ctrCloneOpts enti
 
 // ContainerUpdate finds and updates the given container's cgroup config with the specified options
 func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *entities.ContainerUpdateOptions) (string, error) {
-	err := specgen.WeightDevices(updateOptions.Specgen)
-	if err != nil {
-		return "", err
-	}
-	err = specgen.FinishThrottleDevices(updateOptions.Specgen)
-	if err != nil {
-		return "", err
+	if updateOptions.Specgen != nil {
+		err := specgen.WeightDevices(updateOptions.Specgen)
+		if err != nil {
+			return "", err
+		}
+		err = specgen.FinishThrottleDevices(updateOptions.Specgen)
+		if err != nil {
+			return "", err
+		}
 	}
 	containers, err := getContainers(ic.Libpod, getContainersOptions{names: []string{updateOptions.NameOrID}})
 	if err != nil {
@@ -1766,8 +1784,132 @@ func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, updateOptions *e
 		return "", fmt.Errorf("container not found")
 	}
 
-	if err = containers[0].Update(updateOptions.Specgen.ResourceLimits); err != nil {
+	options, err := toLibpodUpdateOptions(containers[0].Container, updateOptions)
+	if err != nil {
+		return "", err
+	}
+	if err = containers[0].Update(options); err != nil {
 		return "", err
 	}
 	return containers[0].ID(), nil
 }
+
+// toLibpodUpdateOptions parses the specified update options for the container.
+func toLibpodUpdateOptions(ctr *libpod.Container, updateOptions *entities.ContainerUpdateOptions) (*libpod.ContainerUpdateOptions, error) {
+	options := new(libpod.ContainerUpdateOptions)
+	// The device helpers above always allocate the resources struct, only
+	// pass it down when a resource limit was actually requested.
+	if updateOptions.Specgen != nil && updateOptions.Specgen.ResourceLimits != nil &&
+		!reflect.DeepEqual(*updateOptions.Specgen.ResourceLimits, spec.LinuxResources{}) {
+		options.Resources = updateOptions.Specgen.ResourceLimits
+	}
+
+	if updateOptions.Restart != nil {
+		policy, retries, err := util.ParseRestartPolicy(*updateOptions.Restart)
+		if err != nil {
+			return nil, err
+		}
+		options.RestartPolicy = &policy
+		if policy == define.RestartPolicyOnFailure {
+			options.RestartRetries = &retries
+		}
+	}
+
+	var hc *manifest.Schema2HealthConfig
+	if updateOptions.HealthCmd != nil {
+		newHC, err := specgenutil.MakeHealthCheckFromCli(*updateOptions.HealthCmd, define.DefaultHealthCheckInterval, define.DefaultHealthCheckRetries, define.DefaultHealthCheckTimeout, define.DefaultHealthCheckStartPeriod, false)
+		if err != nil {
+			return nil, err
+		}
+		// Only replace the command and keep the other settings of the
+		// current healthcheck unless they are changed as well.
+		if current := ctr.HealthCheckConfig(); current != nil && newHC.Test[0] != define.HealthConfigTestNone {
+			newHC.Interval = current.Interval
+			newHC.StartPeriod = current.StartPeriod
+			if current.Retries > 0 {
+				newHC.Retries = current.Retries
+			}
+			if current.Timeout > 0 {
+				newHC.Timeout = current.Timeout
+			}
+		}
+		hc = newHC
+	} else if updateOptions.HealthInterval != nil || updateOptions.HealthRetries != nil || updateOptions.HealthTimeout != nil || updateOptions.HealthStartPeriod != nil {
+		current := ctr.HealthCheckConfig()
+		if current == nil {
+			return nil, fmt.Errorf("container %s has no healthcheck, use --health-cmd to set one: %w", ctr.ID(), define.ErrInvalidArg)
+		}
+		currentCopy := *current
+		hc = &currentCopy
+	}
+	if hc != nil {
+		if updateOptions.HealthInterval != nil {
+			interval := *updateOptions.HealthInterval
+			if interval == "disable" {
+				interval = "0"
+			}
+			d, err := time.ParseDuration(interval)
+			if err != nil {
+				return nil, fmt.Errorf("invalid healthcheck-interval: %w", err)
+			}
+			hc.Interval = d
+		}
+		if updateOptions.HealthRetries != nil {
+			if *updateOptions.HealthRetries < 1 {
+				return nil, errors.New("healthcheck-retries must be greater than 0")
+			}
+			hc.Retries = int(*updateOptions.HealthRetries)
+		}
+		if updateOptions.HealthTimeout != nil {
+			d, err := time.ParseDuration(*updateOptions.HealthTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid healthcheck-timeout: %w", err)
+			}
+			if d < time.Second {
+				return nil, errors.New("healthcheck-timeout must be at least 1 second")
+			}
+			hc.Timeout = d
+		}
+		if updateOptions.HealthStartPeriod != nil {
+			d, err := time.ParseDuration(*updateOptions.HealthStartPeriod)
+			if err != nil {
+				return nil, fmt.Errorf("invalid healthcheck-start-period: %w", err)
+			}
+			if d < 0 {
+				return nil, errors.New("healthcheck-start-period must be 0 seconds or greater")
+			}
+			hc.StartPeriod = d
+		}
+		options.HealthCheckConfig = hc
+	}
+
+	if updateOptions.HealthOnFailure != nil {
+		action, err := define.ParseHealthCheckOnFailureAction(*updateOptions.HealthOnFailure)
+		if err != nil {
+			return nil, err
+		}
+		options.HealthCheckOnFailureAction = &action
+	}
+
+	for _, e := range updateOptions.Env {
+		if key, _, ok := strings.Cut(e, "="); !ok || key == "" {
+			return nil, fmt.Errorf("environment variable %q must be in the form key=value: %w", e, define.ErrInvalidArg)
+		}
+	}
+	options.Env = updateOptions.Env
+	options.UnsetEnv = updateOptions.UnsetEnv
+
+	if len(updateOptions.Labels) > 0 {
+		options.Labels = make(map[string]string, len(updateOptions.Labels))
+		for _, l := range updateOptions.Labels {
+			key, value, _ := strings.Cut(l, "=")
+			if key == "" {
+				return nil, fmt.Errorf("invalid label %q: %w", l, define.ErrInvalidArg)
+			}
+			options.Labels[key] = value
+		}
+	}
+	options.UnsetLabels = updateOptions.UnsetLabels
+
+	return options, nil
+}
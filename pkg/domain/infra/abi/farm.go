@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
 
 	"github.com/containers/buildah/pkg/parse"
@@ -13,6 +14,7 @@ import (
 	istorage "github.com/containers/image/v5/storage"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/emulation"
+	"github.com/containers/storage/pkg/system"
 )
 
 // FarmNodeName returns the local engine's name.
@@ -45,16 +47,50 @@ func (ir *ImageEngine) fetchInfo(_ context.Context) (os, arch, variant string, n
 	return os, arch, variant, append([]string{}, nativePlatform), emulatedPlatforms, nil
 }
 
+// fetchLoad fills in the current load of the local engine.
+func (ir *ImageEngine) fetchLoad(report *entities.FarmInspectReport) error {
+	mi, err := system.ReadMemInfo()
+	if err != nil {
+		return fmt.Errorf("reading memory info: %w", err)
+	}
+	report.CPUs = runtime.NumCPU()
+	report.MemTotal = mi.MemTotal
+	report.MemFree = mi.MemFree
+
+	// Builds in progress keep their working containers in storage.
+	ctrs, err := ir.Libpod.StorageContainers()
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		isBuild, err := ir.Libpod.IsBuildahContainer(ctr.ID)
+		if err != nil {
+			return fmt.Errorf("determining buildah container for container %s: %w", ctr.ID, err)
+		}
+		if isBuild {
+			report.RunningBuilds++
+		}
+	}
+	return nil
+}
+
 // FarmNodeInspect returns information about the remote engines in the farm
 func (ir *ImageEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspectReport, error) {
 	ir.platforms.Do(func() {
 		ir.os, ir.arch, ir.variant, ir.nativePlatforms, ir.emulatedPlatforms, ir.platformsErr = ir.fetchInfo(ctx)
 	})
-	return &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
+	if ir.platformsErr != nil {
+		return nil, ir.platformsErr
+	}
+	report := &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
 		EmulatedPlatforms: ir.emulatedPlatforms,
 		OS:                ir.os,
 		Arch:              ir.arch,
-		Variant:           ir.variant}, ir.platformsErr
+		Variant:           ir.variant}
+	if err := ir.fetchLoad(report); err != nil {
+		return nil, err
+	}
+	return report, nil
 }
 
 // PullToFile pulls the image from the remote engine and saves it to a file,
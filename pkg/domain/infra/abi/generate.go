@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/containers/common/pkg/secrets"
 	"github.com/containers/podman/v4/libpod"
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	k8sAPI "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
+	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/containers/podman/v4/pkg/specgen"
 	generateUtils "github.com/containers/podman/v4/pkg/specgen/generate"
+	"github.com/containers/podman/v4/pkg/specgen/generate/kube"
 	"github.com/containers/podman/v4/pkg/systemd/generate"
 	"sigs.k8s.io/yaml"
 )
@@ -117,6 +120,11 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 		return nil, fmt.Errorf("--replicas has to be greater than or equal to 1. By default, --replicas is set to 1")
 	}
 
+	configObjects, err := ic.newKubeConfigObjects(options)
+	if err != nil {
+		return nil, err
+	}
+
 	defaultKubeNS := true
 	// Lookup for podman objects.
 	for _, nameOrID := range nameOrIDs {
@@ -194,7 +202,7 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 
 	// Generate kube pods and services from pods.
 	if len(pods) >= 1 {
-		out, svcs, err := getKubePods(ctx, pods, options)
+		out, svcs, err := getKubePods(ctx, pods, options, configObjects)
 		if err != nil {
 			return nil, err
 		}
@@ -211,6 +219,9 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 		if err != nil {
 			return nil, err
 		}
+		if err := configObjects.add(po); err != nil {
+			return nil, err
+		}
 		if len(po.Spec.Volumes) != 0 {
 			warning := `
 # NOTE: If you generated this yaml from an unprivileged and rootless podman container on an SELinux
@@ -265,8 +276,8 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 		}
 	}
 
-	// Content order is based on helm install order (secret, persistentVolumeClaim, service, pod/deployment).
-	content = append(content, typeContent...)
+	// Content order is based on helm install order (secret, configMap, persistentVolumeClaim, service, pod/deployment).
+	content = append(append(configObjects.yaml(), content...), typeContent...)
 
 	// Generate kube YAML file from all kube kinds.
 	k, err := generateKubeOutput(content)
@@ -278,7 +289,7 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 }
 
 // getKubePods returns kube pod or deployment and service YAML files from podman pods.
-func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.GenerateKubeOptions) ([][]byte, [][]byte, error) {
+func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.GenerateKubeOptions, configObjects *kubeConfigObjects) ([][]byte, [][]byte, error) {
 	out := [][]byte{}
 	svcs := [][]byte{}
 
@@ -287,6 +298,9 @@ func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.Gener
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := configObjects.add(po); err != nil {
+			return nil, nil, err
+		}
 
 		switch options.Type {
 		case define.K8sKindDeployment:
@@ -335,6 +349,136 @@ func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.Gener
 	return out, svcs, nil
 }
 
+// kubeConfigObjects collects the ConfigMaps and Secrets referenced by the
+// generated pods.
+type kubeConfigObjects struct {
+	options        entities.GenerateKubeOptions
+	secretsManager *secrets.SecretsManager
+	secretNames    map[string]bool
+	secrets        [][]byte
+	configMaps     [][]byte
+}
+
+func (ic *ContainerEngine) newKubeConfigObjects(options entities.GenerateKubeOptions) (*kubeConfigObjects, error) {
+	objects := &kubeConfigObjects{
+		options:     options,
+		secretNames: make(map[string]bool),
+	}
+	if options.SecretData {
+		secretsManager, err := ic.Libpod.SecretsManager()
+		if err != nil {
+			return nil, err
+		}
+		objects.secretsManager = secretsManager
+	}
+	return objects, nil
+}
+
+// add moves the environment variables of the pod's containers into ConfigMaps
+// and generates Secrets holding the data of the podman secrets referenced by
+// the containers, if requested.  Without SecretData, the secrets are only
+// referenced and must exist wherever the YAML is played.
+func (o *kubeConfigObjects) add(pod *k8sAPI.Pod) error {
+	for _, ctrs := range [][]k8sAPI.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range ctrs {
+			if o.options.ConfigMaps {
+				if err := o.addConfigMap(&ctrs[i]); err != nil {
+					return err
+				}
+			}
+			if !o.options.SecretData {
+				continue
+			}
+			for _, env := range ctrs[i].Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					if err := o.addSecret(env.ValueFrom.SecretKeyRef.Name); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if o.options.SecretData {
+		for _, vol := range pod.Spec.Volumes {
+			if vol.Secret != nil {
+				if err := o.addSecret(vol.Secret.SecretName); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addConfigMap moves the plain environment variables of the container into a
+// ConfigMap named after the container and references it via envFrom.
+func (o *kubeConfigObjects) addConfigMap(ctr *k8sAPI.Container) error {
+	data := make(map[string]string)
+	env := make([]k8sAPI.EnvVar, 0, len(ctr.Env))
+	for _, e := range ctr.Env {
+		if e.ValueFrom != nil {
+			env = append(env, e)
+			continue
+		}
+		data[e.Name] = e.Value
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	name := strings.ToLower(ctr.Name) + "-env"
+	b, err := generateKubeYAML(k8sAPI.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	o.configMaps = append(o.configMaps, b)
+
+	ctr.Env = env
+	if len(ctr.Env) == 0 {
+		ctr.Env = nil
+	}
+	ctr.EnvFrom = append(ctr.EnvFrom, k8sAPI.EnvFromSource{
+		ConfigMapRef: &k8sAPI.ConfigMapEnvSource{
+			LocalObjectReference: k8sAPI.LocalObjectReference{Name: name},
+		},
+	})
+	return nil
+}
+
+// addSecret generates a Secret with the data of the specified podman secret.
+// Data of secrets that were created by kube play is used as is, other
+// secrets are stored under a key named after the secret.
+func (o *kubeConfigObjects) addSecret(name string) error {
+	if o.secretNames[name] {
+		return nil
+	}
+	o.secretNames[name] = true
+
+	data, err := kube.K8sSecretFromSecretManager(name, o.secretsManager)
+	if err != nil {
+		return fmt.Errorf("looking up secret %q: %w", name, err)
+	}
+	b, err := generateKubeYAML(k8sAPI.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	o.secrets = append(o.secrets, b)
+	return nil
+}
+
+// yaml returns the YAML files of the collected Secrets and ConfigMaps.
+func (o *kubeConfigObjects) yaml() [][]byte {
+	return append(o.secrets, o.configMaps...)
+}
+
 // getKubePVCs returns kube persistent volume claim YAML files from podman volumes.
 func getKubePVCs(volumes []*libpod.Volume) ([][]byte, error) {
 	pvs := [][]byte{}
@@ -23,3 +23,53 @@ func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string,
 	}
 	return &report, nil
 }
+
+func (ic *ContainerEngine) HealthCheckInspect(ctx context.Context, nameOrID string, options entities.HealthCheckInspectOptions) (*entities.HealthCheckInspectReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ctr.Inspect(false)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(chan *define.HealthCheckHistoryEntry)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ctr.HealthCheckHistory(ctx, define.HealthCheckHistoryOptions{}, entries)
+	}()
+	history := []define.HealthCheckHistoryEntry{}
+	for entry := range entries {
+		history = append(history, *entry)
+	}
+	if err := <-errChan; err != nil {
+		return nil, err
+	}
+
+	return &entities.HealthCheckInspectReport{
+		ID:              data.ID,
+		Name:            data.Name,
+		Healthcheck:     data.Config.Healthcheck,
+		OnFailureAction: data.Config.HealthcheckOnFailureAction,
+		Status:          data.State.Health.Status,
+		FailingStreak:   data.State.Health.FailingStreak,
+		History:         history,
+		OnFailureLog:    data.State.Health.OnFailureLog,
+	}, nil
+}
+
+func (ic *ContainerEngine) HealthCheckLogs(ctx context.Context, nameOrID string, options entities.HealthCheckLogsOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		close(options.EntryChan)
+		return err
+	}
+	historyOptions := define.HealthCheckHistoryOptions{
+		Since:  options.Since,
+		Until:  options.Until,
+		Status: options.Status,
+		Follow: options.Follow,
+	}
+	return ctr.HealthCheckHistory(ctx, historyOptions, options.EntryChan)
+}
@@ -163,11 +163,11 @@ func (ic *ContainerEngine) NetworkCreate(ctx context.Context, network types.Netw
 
 // NetworkDisconnect removes a container from a given network
 func (ic *ContainerEngine) NetworkDisconnect(ctx context.Context, networkname string, options entities.NetworkDisconnectOptions) error {
-	return ic.Libpod.DisconnectContainerFromNetwork(options.Container, networkname, options.Force)
+	return ic.Libpod.DisconnectContainerFromNetwork(ctx, options.Container, networkname, options.Force)
 }
 
 func (ic *ContainerEngine) NetworkConnect(ctx context.Context, networkname string, options entities.NetworkConnectOptions) error {
-	return ic.Libpod.ConnectContainerToNetwork(options.Container, networkname, options.PerNetworkOptions)
+	return ic.Libpod.ConnectContainerToNetwork(ctx, options.Container, networkname, options.PerNetworkOptions)
 }
 
 // NetworkExists checks if the given network exists
@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	buildahDefine "github.com/containers/buildah/define"
 	bparse "github.com/containers/buildah/pkg/parse"
@@ -25,8 +27,10 @@ import (
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	v1apps "github.com/containers/podman/v4/pkg/k8s.io/api/apps/v1"
+	v1batch "github.com/containers/podman/v4/pkg/k8s.io/api/batch/v1"
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 	metav1 "github.com/containers/podman/v4/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/containers/podman/v4/pkg/rootless"
 	"github.com/containers/podman/v4/pkg/specgen"
 	"github.com/containers/podman/v4/pkg/specgen/generate"
 	"github.com/containers/podman/v4/pkg/specgen/generate/kube"
@@ -50,6 +54,10 @@ const sdNotifyAnnotation = "io.containers.sdnotify"
 // default network created/used by kube
 const kubeDefaultNetwork = "podman-default-kube-network"
 
+// playKubeReadyInterval is how often the state of the containers is checked
+// when waiting for the pods to become ready.
+const playKubeReadyInterval = 250 * time.Millisecond
+
 // createServiceContainer creates a container that can later on
 // be associated with the pods of a K8s yaml.  It will be started along with
 // the first pod.
@@ -211,7 +219,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		}
 
 		// TODO: create constants for the various "kinds" of yaml files.
-		if options.ServiceContainer && serviceContainer == nil && (kind == "Pod" || kind == "Deployment") {
+		if options.ServiceContainer && serviceContainer == nil && (kind == "Pod" || kind == "Deployment" || kind == "Job") {
 			ctr, err := ic.createServiceContainer(ctx, k8sName(content, "service"), options)
 			if err != nil {
 				return nil, err
@@ -297,6 +305,36 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			report.Pods = append(report.Pods, r.Pods...)
 			validKinds++
 			ranContainers = true
+		case "Job":
+			var jobYAML v1batch.Job
+
+			if err := yaml.Unmarshal(document, &jobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
+			}
+
+			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, serviceContainer)
+			if err != nil {
+				return nil, err
+			}
+			notifyProxies = append(notifyProxies, proxies...)
+
+			report.Pods = append(report.Pods, r.Pods...)
+			validKinds++
+			ranContainers = len(r.Pods) > 0 || ranContainers
+		case "CronJob":
+			var cronJobYAML v1batch.CronJob
+
+			if err := yaml.Unmarshal(document, &cronJobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube CronJob: %w", err)
+			}
+
+			r, err := ic.playKubeCronJob(ctx, &cronJobYAML, options, configMaps)
+			if err != nil {
+				return nil, err
+			}
+
+			report.Timers = append(report.Timers, r.Timers...)
+			validKinds++
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 
@@ -357,6 +395,12 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		return nil, fmt.Errorf("YAML document does not contain any supported kube kind")
 	}
 
+	if options.WaitReady && ranContainers {
+		if err := ic.playKubeWaitReady(ctx, report, time.Duration(options.WaitReadyTimeout)*time.Second); err != nil {
+			return nil, err
+		}
+	}
+
 	// If we started containers along with a service container, we are
 	// running inside a systemd unit and need to set the main PID.
 
@@ -398,6 +442,96 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	return report, nil
 }
 
+// playKubeWaitReady blocks until every container of the played pods is
+// running and, if it has a healthcheck, healthy.  It stops waiting early if a
+// container exits or turns unhealthy and once the timeout expires, a timeout
+// of 0 waits forever.  The final state of the containers is recorded in the
+// pod reports and report.NotReady is set if not all of them are ready.
+func (ic *ContainerEngine) playKubeWaitReady(ctx context.Context, report *entities.PlayKubeReport, timeout time.Duration) error {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	ticker := time.NewTicker(playKubeReadyInterval)
+	defer ticker.Stop()
+
+	for {
+		ready, failed := true, false
+		for i := range report.Pods {
+			statuses := make([]entities.PlayKubeContainerStatus, 0, len(report.Pods[i].Containers))
+			for _, id := range report.Pods[i].Containers {
+				status, err := ic.playKubeContainerStatus(id)
+				if err != nil {
+					return err
+				}
+				ready = ready && status.Ready
+				switch {
+				case status.Ready:
+				case status.State == define.ContainerStateStopped.String(), status.State == define.ContainerStateExited.String():
+					failed = true
+				case status.Health == define.HealthCheckUnhealthy:
+					failed = true
+				}
+				statuses = append(statuses, status)
+			}
+			report.Pods[i].ContainerStatuses = statuses
+		}
+		if ready || failed {
+			report.NotReady = !ready
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired:
+			report.NotReady = true
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// playKubeContainerStatus returns the readiness of the specified container.
+func (ic *ContainerEngine) playKubeContainerStatus(id string) (entities.PlayKubeContainerStatus, error) {
+	ctr, err := ic.Libpod.LookupContainer(id)
+	if err != nil {
+		return entities.PlayKubeContainerStatus{}, err
+	}
+	data, err := ctr.Inspect(false)
+	if err != nil {
+		return entities.PlayKubeContainerStatus{}, err
+	}
+
+	status := entities.PlayKubeContainerStatus{
+		ID:       ctr.ID(),
+		Name:     ctr.Name(),
+		State:    data.State.Status,
+		ExitCode: data.State.ExitCode,
+	}
+	if ctr.HasHealthCheck() {
+		status.Health = data.State.Health.Status
+		// A container without any healthcheck run reports no status yet.
+		if status.Health == "" {
+			status.Health = define.HealthCheckStarting
+		}
+		if log := data.State.Health.Log; len(log) > 0 && log[len(log)-1].ExitCode != 0 {
+			status.ProbeOutput = strings.TrimSpace(log[len(log)-1].Output)
+		}
+	}
+	switch {
+	case data.State.Running:
+		status.Ready = status.Health == "" || status.Health == define.HealthCheckHealthy
+	case status.State == define.ContainerStateExited.String() || status.State == define.ContainerStateStopped.String():
+		// Containers that are not restarted, e.g. the ones of a Job, are
+		// done once they exited successfully.
+		status.Ready = status.ExitCode == 0 && ctr.RestartPolicy() != define.RestartPolicyAlways
+	}
+	return status, nil
+}
+
 func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		daemonSetName string
@@ -452,6 +586,245 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	return &report, proxies, nil
 }
 
+func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1batch.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+	var (
+		report  entities.PlayKubeReport
+		proxies []*notifyproxy.NotifyProxy
+	)
+
+	jobName := jobYAML.ObjectMeta.Name
+	if jobName == "" {
+		return nil, nil, errors.New("job does not have a name")
+	}
+	if (jobYAML.Spec.Suspend != nil && *jobYAML.Spec.Suspend) || (jobYAML.Spec.Parallelism != nil && *jobYAML.Spec.Parallelism == 0) {
+		logrus.Infof("Job %s is suspended, not creating any pods", jobName)
+		return &report, nil, nil
+	}
+
+	podSpec := jobYAML.Spec.Template
+	switch podSpec.Spec.RestartPolicy {
+	case "":
+		podSpec.Spec.RestartPolicy = v1.RestartPolicyOnFailure
+	case v1.RestartPolicyOnFailure, v1.RestartPolicyNever:
+	default:
+		return nil, nil, fmt.Errorf("job %s has unsupported restartPolicy %q: must be OnFailure or Never", jobName, podSpec.Spec.RestartPolicy)
+	}
+
+	annotations := make(map[string]string, len(jobYAML.Annotations)+1)
+	for k, v := range jobYAML.Annotations {
+		annotations[k] = v
+	}
+	if _, ok := annotations[define.KubeRestartRetriesAnnotation]; !ok {
+		// Kubernetes defaults to 6 retries.
+		backoffLimit := int32(6)
+		if jobYAML.Spec.BackoffLimit != nil {
+			backoffLimit = *jobYAML.Spec.BackoffLimit
+		}
+		annotations[define.KubeRestartRetriesAnnotation] = strconv.Itoa(int(backoffLimit))
+	}
+	if podSpec.Spec.RestartPolicy == v1.RestartPolicyOnFailure && annotations[define.KubeRestartRetriesAnnotation] == "0" {
+		// Zero retries mean unlimited retries to Podman.
+		podSpec.Spec.RestartPolicy = v1.RestartPolicyNever
+	}
+
+	// The deadline of the job is applied to the containers of its pods.
+	// Podman enforces it as the timeout of each container, which starts
+	// over when a container is restarted, so it only bounds the whole job
+	// if its containers are not restarted.
+	if deadline := jobYAML.Spec.ActiveDeadlineSeconds; deadline != nil {
+		if podSpec.Spec.ActiveDeadlineSeconds == nil || *deadline < *podSpec.Spec.ActiveDeadlineSeconds {
+			podSpec.Spec.ActiveDeadlineSeconds = deadline
+		}
+	}
+	if podSpec.Spec.ActiveDeadlineSeconds != nil && podSpec.Spec.RestartPolicy == v1.RestartPolicyOnFailure {
+		return nil, nil, fmt.Errorf("job %s: activeDeadlineSeconds cannot be enforced across restarts of its containers, set restartPolicy to Never or backoffLimit to 0", jobName)
+	}
+
+	podNames := jobPodNames(jobName, &jobYAML.Spec)
+	if len(podNames) > 1 && (jobYAML.Spec.Parallelism == nil || int(*jobYAML.Spec.Parallelism) < len(podNames)) {
+		logrus.Warnf("Running all %d completions of job %s in parallel, limiting the parallelism is not supported by Podman", len(podNames), jobName)
+	}
+	for _, podName := range podNames {
+		podSpec := podSpec
+		podReport, podProxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, annotations, configMaps, serviceContainer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
+		}
+		report.Pods = append(report.Pods, podReport.Pods...)
+		proxies = append(proxies, podProxies...)
+	}
+
+	return &report, proxies, nil
+}
+
+// jobPodNames returns the names of the pods created for the specified job.
+// Each completion of the job runs in a separate pod.
+func jobPodNames(jobName string, spec *v1batch.JobSpec) []string {
+	if spec.Completions == nil || *spec.Completions <= 1 {
+		return []string{fmt.Sprintf("%s-pod", jobName)}
+	}
+	names := make([]string, 0, *spec.Completions)
+	for i := 0; i < int(*spec.Completions); i++ {
+		names = append(names, fmt.Sprintf("%s-pod-%d", jobName, i))
+	}
+	return names
+}
+
+// kubeCronJobUnitName returns the name of the transient systemd units running
+// the specified CronJob.
+func kubeCronJobUnitName(cronJobName string) string {
+	return "podman-kube-cronjob-" + cronJobName
+}
+
+// kubeCronJobPath returns the path of the YAML file with the Job that is
+// played on each run of the specified CronJob.
+func (ic *ContainerEngine) kubeCronJobPath(cronJobName string) (string, error) {
+	rtc, err := ic.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(rtc.Engine.StaticDir, "kube-cronjobs", cronJobName+".yaml"), nil
+}
+
+// playKubeCronJob stores the job template of the CronJob and creates a
+// transient systemd timer playing it on schedule.
+func (ic *ContainerEngine) playKubeCronJob(ctx context.Context, cronJobYAML *v1batch.CronJob, options entities.PlayKubeOptions, configMaps []v1.ConfigMap) (*entities.PlayKubeReport, error) {
+	var report entities.PlayKubeReport
+
+	cronJobName := cronJobYAML.ObjectMeta.Name
+	if cronJobName == "" {
+		return nil, errors.New("cronJob does not have a name")
+	}
+
+	if options.Replace {
+		if err := removeKubeCronJobTimer(cronJobName); err != nil {
+			return nil, err
+		}
+	}
+
+	if cronJobYAML.Spec.Suspend != nil && *cronJobYAML.Spec.Suspend {
+		logrus.Infof("CronJob %s is suspended, not creating a timer", cronJobName)
+		return &report, nil
+	}
+
+	calendar, err := cronToOnCalendar(cronJobYAML.Spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("cronJob %s: %w", cronJobName, err)
+	}
+	if cronJobYAML.Spec.TimeZone != nil && *cronJobYAML.Spec.TimeZone != "" {
+		calendar += " " + *cronJobYAML.Spec.TimeZone
+	}
+
+	// Store the job along with the ConfigMaps it may reference, so it
+	// can be played independently on each run.
+	var documents [][]byte
+	for _, cm := range configMaps {
+		cm.Kind = "ConfigMap"
+		cm.APIVersion = "v1"
+		doc, err := yaml.Marshal(cm)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, doc)
+	}
+	job := v1batch.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: cronJobYAML.Spec.JobTemplate.ObjectMeta,
+		Spec:       cronJobYAML.Spec.JobTemplate.Spec,
+	}
+	job.Name = cronJobName
+	doc, err := yaml.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	documents = append(documents, doc)
+
+	jobPath, err := ic.kubeCronJobPath(cronJobName)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(jobPath), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(jobPath, bytes.Join(documents, []byte("---\n")), 0o600); err != nil {
+		return nil, fmt.Errorf("writing job of cronJob %s: %w", cronJobName, err)
+	}
+
+	// The timer does not inherit the global options of this process, pass
+	// them on like the exit command of a container.
+	runtimeConfig, err := ic.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	podmanArgs, err := specgenutil.CreatePodmanArgs(ic.Libpod.StorageConfig(), runtimeConfig, ic.Libpod.EventsWebhookURL(), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get podman command for the timer of cronJob %s: %w", cronJobName, err)
+	}
+
+	unitName := kubeCronJobUnitName(cronJobName)
+	cmd := []string{}
+	if rootless.IsRootless() {
+		cmd = append(cmd, "--user")
+	}
+	if path := os.Getenv("PATH"); path != "" {
+		cmd = append(cmd, "--setenv=PATH="+path)
+	}
+	cmd = append(cmd, "--unit", unitName, "--on-calendar="+calendar)
+	if cronJobYAML.Spec.StartingDeadlineSeconds == nil {
+		// Catch up on runs missed while the system was down.
+		cmd = append(cmd, "--timer-property=Persistent=true")
+	}
+
+	playArgs := append(podmanArgs, "kube", "play")
+	switch cronJobYAML.Spec.ConcurrencyPolicy {
+	case v1batch.ForbidConcurrent:
+		// Keep the service active for as long as the job is running,
+		// so that systemd skips runs while the previous one is still
+		// active.
+		cmd = append(cmd, "--property=Type=notify", "--property=NotifyAccess=all", "--property=KillMode=mixed")
+		playArgs = append(playArgs, "--replace", "--service-container=true")
+	case "", v1batch.AllowConcurrent, v1batch.ReplaceConcurrent:
+		// Only one instance of the job's pods can exist, so a new run
+		// replaces the previous one.
+		playArgs = append(playArgs, "--replace")
+	default:
+		return nil, fmt.Errorf("cronJob %s has unsupported concurrencyPolicy %q", cronJobName, cronJobYAML.Spec.ConcurrencyPolicy)
+	}
+	cmd = append(cmd, playArgs...)
+	cmd = append(cmd, jobPath)
+
+	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
+	systemdRun := exec.CommandContext(ctx, "systemd-run", cmd...)
+	if output, err := systemdRun.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("creating systemd timer for cronJob %s: %s", cronJobName, strings.TrimSpace(string(output)))
+	}
+
+	report.Timers = append(report.Timers, entities.PlayKubeTimer{Name: unitName + ".timer"})
+	return &report, nil
+}
+
+// removeKubeCronJobTimer stops the transient systemd timer and service of the
+// specified CronJob.  Units that are not loaded are ignored.
+func removeKubeCronJobTimer(cronJobName string) error {
+	unitName := kubeCronJobUnitName(cronJobName)
+	for _, unit := range []string{unitName + ".timer", unitName + ".service"} {
+		args := []string{}
+		if rootless.IsRootless() {
+			args = append(args, "--user")
+		}
+		args = append(args, "stop", unit)
+		logrus.Debugf("stopping systemd unit: %s %s", "systemctl", args)
+		output, err := exec.Command("systemctl", args...).CombinedOutput()
+		if err != nil && !strings.Contains(string(output), "not loaded") {
+			return fmt.Errorf("stopping %s: %s", unit, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
 func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		writer      io.Writer
@@ -676,6 +1049,21 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	default: // Default to Always
 		podSpec.PodSpecGen.RestartPolicy = define.RestartPolicyAlways
 	}
+	if v, ok := annotations[define.KubeRestartRetriesAnnotation]; ok && podSpec.PodSpecGen.RestartPolicy == define.RestartPolicyOnFailure {
+		retries, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid value %q for annotation %s: %w", v, define.KubeRestartRetriesAnnotation, err)
+		}
+		restartRetries := uint(retries)
+		podSpec.PodSpecGen.RestartRetries = &restartRetries
+	}
+	// The deadline is enforced as the timeout of each container, which
+	// starts over when a container is restarted.
+	activeDeadlineSeconds := podYAML.Spec.ActiveDeadlineSeconds
+	if activeDeadlineSeconds != nil && podSpec.PodSpecGen.RestartPolicy != define.RestartPolicyNo {
+		logrus.Warnf("Ignoring activeDeadlineSeconds of pod %s, it cannot be enforced across restarts of its containers unless restartPolicy is Never", podName)
+		activeDeadlineSeconds = nil
+	}
 
 	if podOpt.Infra {
 		infraImage := util.DefaultContainerConfig().Engine.InfraImage
@@ -724,7 +1112,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		}
 	}
 	// Create the Pod
-	pod, err := generate.MakePod(&podSpec, ic.Libpod)
+	pod, err := generate.MakePod(ctx, &podSpec, ic.Libpod)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -887,6 +1275,9 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		if podYAML.Spec.TerminationGracePeriodSeconds != nil {
 			specgenOpts.TerminationGracePeriodSeconds = podYAML.Spec.TerminationGracePeriodSeconds
 		}
+		if activeDeadlineSeconds != nil {
+			specgenOpts.ActiveDeadlineSeconds = activeDeadlineSeconds
+		}
 
 		specGen, err := kube.ToSpecGen(ctx, &specgenOpts)
 		if err != nil {
@@ -917,6 +1308,11 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		if ctrNotifyMode != "" {
 			sdNotifyMode = ctrNotifyMode
 		}
+		// Running under systemd, a container with a readiness probe
+		// must turn healthy before the service is marked as ready.
+		if sdNotifyMode == "" && serviceContainer != nil && container.ReadinessProbe != nil && specGen.HealthConfig != nil {
+			sdNotifyMode = define.SdNotifyModeHealthy
+		}
 		if sdNotifyMode == "" { // Default to "ignore"
 			sdNotifyMode = define.SdNotifyModeIgnore
 		}
@@ -1372,7 +1768,7 @@ func sortKubeKinds(documentList [][]byte) ([][]byte, error) {
 		}
 
 		switch kind {
-		case "Pod", "Deployment", "DaemonSet":
+		case "Pod", "Deployment", "DaemonSet", "Job", "CronJob":
 			sortedDocumentList = append(sortedDocumentList, document)
 		default:
 			sortedDocumentList = append([][]byte{document}, sortedDocumentList...)
@@ -1429,9 +1825,10 @@ func getBuildFile(imageName string, cwd string) (string, error) {
 
 func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, options entities.PlayKubeDownOptions) (*entities.PlayKubeReport, error) {
 	var (
-		podNames    []string
-		volumeNames []string
-		secretNames []string
+		podNames     []string
+		volumeNames  []string
+		secretNames  []string
+		cronJobNames []string
 	)
 	reports := new(entities.PlayKubeReport)
 
@@ -1502,6 +1899,21 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 			}
 			podName := fmt.Sprintf("%s-pod", deploymentName)
 			podNames = append(podNames, podName)
+		case "Job":
+			var jobYAML v1batch.Job
+
+			if err := yaml.Unmarshal(document, &jobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
+			}
+			podNames = append(podNames, jobPodNames(jobYAML.Name, &jobYAML.Spec)...)
+		case "CronJob":
+			var cronJobYAML v1batch.CronJob
+
+			if err := yaml.Unmarshal(document, &cronJobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube CronJob: %w", err)
+			}
+			cronJobNames = append(cronJobNames, cronJobYAML.Name)
+			podNames = append(podNames, jobPodNames(cronJobYAML.Name, &cronJobYAML.Spec.JobTemplate.Spec)...)
 		case "PersistentVolumeClaim":
 			var pvcYAML v1.PersistentVolumeClaim
 			if err := yaml.Unmarshal(document, &pvcYAML); err != nil {
@@ -1519,6 +1931,19 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 		}
 	}
 
+	// Remove the timers first, so they cannot start new pods.
+	for _, name := range cronJobNames {
+		timerReport := &entities.PlayKubeTimerRmReport{Name: kubeCronJobUnitName(name) + ".timer"}
+		if err := removeKubeCronJobTimer(name); err != nil {
+			timerReport.Err = err
+		} else if jobPath, err := ic.kubeCronJobPath(name); err != nil {
+			timerReport.Err = err
+		} else if err := os.Remove(jobPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			timerReport.Err = err
+		}
+		reports.TimerRmReport = append(reports.TimerRmReport, timerReport)
+	}
+
 	// Get the service containers associated with the pods if any
 	serviceCtrIDs := []string{}
 	for _, name := range podNames {
@@ -1,6 +1,12 @@
 package abi
 
-import "github.com/containers/podman/v4/libpod/define"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v4/libpod/define"
+)
 
 // getSdNotifyMode returns the `sdNotifyAnnotation/$name` for the specified
 // name. If name is empty, it'll only look for `sdNotifyAnnotation`.
@@ -14,3 +20,171 @@ func getSdNotifyMode(annotations map[string]string, name string) (string, error)
 	}
 	return mode, define.ValidateSdNotifyMode(mode)
 }
+
+// cronField describes the valid range and names of a field in a cron
+// schedule.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronDayOfWeek  = cronField{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat", "sun"}}
+
+	// systemdWeekdays maps the cron day of week to systemd's notation.
+	systemdWeekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+	cronMacros = map[string]string{
+		"@yearly":   "*-01-01 00:00:00",
+		"@annually": "*-01-01 00:00:00",
+		"@monthly":  "*-*-01 00:00:00",
+		"@weekly":   "Sun *-*-* 00:00:00",
+		"@daily":    "*-*-* 00:00:00",
+		"@midnight": "*-*-* 00:00:00",
+		"@hourly":   "*-*-* *:00:00",
+	}
+)
+
+// parseValue parses a single value of the field which is either a number or,
+// if supported by the field, a name.
+func (f cronField) parseValue(value string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(name, value) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, value)
+	}
+	return v, nil
+}
+
+// expand parses the specified cron field and returns all values it matches.
+// A nil slice is returned for a wildcard.
+func (f cronField) expand(field string) ([]int, error) {
+	if field == "*" {
+		return nil, nil
+	}
+	seen := make(map[int]bool)
+	var values []int
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step %q in %s", stepStr, f.name)
+			}
+		}
+		start, end := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			first, last, _ := strings.Cut(rng, "-")
+			var err error
+			if start, err = f.parseValue(first); err != nil {
+				return nil, err
+			}
+			if end, err = f.parseValue(last); err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		default:
+			v, err := f.parseValue(rng)
+			if err != nil {
+				return nil, err
+			}
+			start = v
+			if !hasStep {
+				end = v
+			}
+		}
+		for v := start; v <= end; v += step {
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+	}
+	return values, nil
+}
+
+// cronToOnCalendar converts a schedule in cron format (e.g., of a Kubernetes
+// CronJob) to a systemd calendar event expression as used by the OnCalendar
+// setting of systemd timers.
+func cronToOnCalendar(schedule string) (string, error) {
+	schedule = strings.TrimSpace(schedule)
+	if calendar, ok := cronMacros[schedule]; ok {
+		return calendar, nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("invalid schedule %q: expected 5 fields but got %d", schedule, len(fields))
+	}
+
+	format := func(f cronField, field string, width int) (string, error) {
+		values, err := f.expand(field)
+		if err != nil {
+			return "", fmt.Errorf("invalid schedule %q: %w", schedule, err)
+		}
+		if values == nil {
+			return "*", nil
+		}
+		formatted := make([]string, 0, len(values))
+		for _, v := range values {
+			formatted = append(formatted, fmt.Sprintf("%0*d", width, v))
+		}
+		return strings.Join(formatted, ","), nil
+	}
+
+	minute, err := format(cronMinute, fields[0], 2)
+	if err != nil {
+		return "", err
+	}
+	hour, err := format(cronHour, fields[1], 2)
+	if err != nil {
+		return "", err
+	}
+	dayOfMonth, err := format(cronDayOfMonth, fields[2], 2)
+	if err != nil {
+		return "", err
+	}
+	month, err := format(cronMonth, fields[3], 2)
+	if err != nil {
+		return "", err
+	}
+
+	calendar := fmt.Sprintf("*-%s-%s %s:%s:00", month, dayOfMonth, hour, minute)
+
+	weekdays, err := cronDayOfWeek.expand(fields[4])
+	if err != nil {
+		return "", fmt.Errorf("invalid schedule %q: %w", schedule, err)
+	}
+	if weekdays != nil {
+		if dayOfMonth != "*" {
+			// cron runs the job if either field matches while systemd
+			// requires both to match.
+			return "", fmt.Errorf("invalid schedule %q: restricting both the day of month and the day of week is not supported", schedule)
+		}
+		names := make([]string, 0, len(weekdays))
+		seen := make(map[string]bool)
+		for _, d := range weekdays {
+			if !seen[systemdWeekdays[d]] {
+				seen[systemdWeekdays[d]] = true
+				names = append(names, systemdWeekdays[d])
+			}
+		}
+		calendar = strings.Join(names, ",") + " " + calendar
+	}
+	return calendar, nil
+}
@@ -36,3 +36,37 @@ func TestGetSdNotifyMode(t *testing.T) {
 		require.Equal(t, test.result, result, "%v", test)
 	}
 }
+
+func TestCronToOnCalendar(t *testing.T) {
+	tests := []struct {
+		schedule, result string
+		mustError        bool
+	}{
+		{"@hourly", "*-*-* *:00:00", false},
+		{"@weekly", "Sun *-*-* 00:00:00", false},
+		{"* * * * *", "*-*-* *:*:00", false},
+		{"30 2 * * *", "*-*-* 02:30:00", false},
+		{"*/15 * * * *", "*-*-* *:00,15,30,45:00", false},
+		{"0 9-17/4 * * *", "*-*-* 09,13,17:00:00", false},
+		{"0 0 1 jan,jul *", "*-01,07-01 00:00:00", false},
+		{"0 8 * * 1-5", "Mon,Tue,Wed,Thu,Fri *-*-* 08:00:00", false},
+		{"0 8 * * 0,7", "Sun *-*-* 08:00:00", false},
+		{"0 8 * * sat", "Sat *-*-* 08:00:00", false},
+		{"0 8 * *", "", true},
+		{"60 * * * *", "", true},
+		{"*/0 * * * *", "", true},
+		{"0 5-3 * * *", "", true},
+		{"0 0 1 * mon", "", true},
+		{"@reboot", "", true},
+	}
+
+	for _, test := range tests {
+		result, err := cronToOnCalendar(test.schedule)
+		if test.mustError {
+			require.Error(t, err, "%v", test)
+			continue
+		}
+		require.NoError(t, err, "%v", test)
+		require.Equal(t, test.result, result, "%v", test)
+	}
+}
@@ -304,7 +304,7 @@ func (ic *ContainerEngine) prunePodHelper(ctx context.Context) ([]*entities.PodP
 }
 
 func (ic *ContainerEngine) PodCreate(ctx context.Context, specg entities.PodSpec) (*entities.PodCreateReport, error) {
-	pod, err := generate.MakePod(&specg, ic.Libpod)
+	pod, err := generate.MakePod(ctx, &specg, ic.Libpod)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +354,7 @@ func (ic *ContainerEngine) PodClone(ctx context.Context, podClone entities.PodCl
 	}
 
 	podSpec := entities.PodSpec{PodSpecGen: *spec}
-	pod, err := generate.MakePod(&podSpec, ic.Libpod)
+	pod, err := generate.MakePod(ctx, &podSpec, ic.Libpod)
 	if err != nil {
 		return nil, err
 	}
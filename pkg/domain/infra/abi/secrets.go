@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/containers/common/pkg/secrets"
+	"github.com/containers/podman/v4/libpod/events"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/domain/utils"
 )
@@ -53,6 +54,7 @@ func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader
 	if err != nil {
 		return nil, err
 	}
+	ic.Libpod.NewSecretEvent(ctx, events.Create, secretID, name, options.Driver)
 
 	return &entities.SecretCreateReport{
 		ID: secretID,
@@ -165,10 +167,15 @@ func (ic *ContainerEngine) SecretRm(ctx context.Context, nameOrIDs []string, opt
 		}
 	}
 	for _, nameOrID := range toRemove {
+		// Look the secret up first to record its name in the event.
+		secret, _ := manager.Lookup(nameOrID)
 		deletedID, err := manager.Delete(nameOrID)
 		if options.Ignore && errors.Is(err, secrets.ErrNoSuchSecret) {
 			continue
 		}
+		if err == nil && secret != nil {
+			ic.Libpod.NewSecretEvent(ctx, events.Remove, deletedID, secret.Name, secret.Driver)
+		}
 		reports = append(reports, &entities.SecretRmReport{Err: err, ID: deletedID})
 	}
 
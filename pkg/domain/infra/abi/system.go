@@ -337,3 +337,12 @@ func (ic ContainerEngine) Locks(ctx context.Context) (*entities.LocksReport, err
 	report.LocksHeld = held
 	return &report, nil
 }
+
+// SystemCheck checks the libpod database, c/storage and the lock manager for
+// inconsistencies and optionally repairs them.
+func (ic *ContainerEngine) SystemCheck(ctx context.Context, options entities.SystemCheckOptions) (*define.SystemCheckReport, error) {
+	return ic.Libpod.SystemCheck(ctx, define.SystemCheckOptions{
+		Quick:  options.Quick,
+		Repair: options.Repair,
+	})
+}
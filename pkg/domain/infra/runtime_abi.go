@@ -61,7 +61,11 @@ func NewSystemEngine(setup entities.EngineSetup, facts *entities.PodmanConfig) (
 			if flagErr != nil {
 				return nil, flagErr
 			}
-			r, err = GetRuntimeMigrate(context.Background(), facts.FlagSet, facts, name)
+			database, flagErr := facts.FlagSet.GetString("database")
+			if flagErr != nil {
+				return nil, flagErr
+			}
+			r, err = GetRuntimeMigrate(context.Background(), facts.FlagSet, facts, name, database)
 		case entities.NoFDsMode:
 			r, err = GetRuntimeDisableFDs(context.Background(), facts.FlagSet, facts)
 		}
@@ -36,17 +36,19 @@ type engineOpts struct {
 	name     string
 	renumber bool
 	migrate  bool
+	database string
 	withFDS  bool
 	reset    bool
 	config   *entities.PodmanConfig
 }
 
 // GetRuntimeMigrate gets a libpod runtime that will perform a migration of existing containers
-func GetRuntimeMigrate(ctx context.Context, fs *flag.FlagSet, cfg *entities.PodmanConfig, newRuntime string) (*libpod.Runtime, error) {
+func GetRuntimeMigrate(ctx context.Context, fs *flag.FlagSet, cfg *entities.PodmanConfig, newRuntime, database string) (*libpod.Runtime, error) {
 	return getRuntime(ctx, fs, &engineOpts{
 		name:     newRuntime,
 		renumber: false,
 		migrate:  true,
+		database: database,
 		withFDS:  true,
 		reset:    false,
 		config:   cfg,
@@ -166,6 +168,9 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 		if opts.name != "" {
 			options = append(options, libpod.WithMigrateRuntime(opts.name))
 		}
+		if opts.database != "" {
+			options = append(options, libpod.WithMigrateDatabase(opts.database))
+		}
 	}
 
 	if opts.reset {
@@ -216,6 +221,9 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 	if fs.Changed("events-backend") {
 		options = append(options, libpod.WithEventsLogger(cfg.ContainersConf.Engine.EventsLogger))
 	}
+	if cfg.EventsWebhookURL != "" {
+		options = append(options, libpod.WithEventsWebhookURL(cfg.EventsWebhookURL))
+	}
 
 	if fs.Changed("volumepath") {
 		options = append(options, libpod.WithVolumePath(cfg.ContainersConf.Engine.VolumePath))
@@ -28,7 +28,7 @@ func (ic *ContainerEngine) Events(ctx context.Context, opts entities.EventsOptio
 		}
 		close(opts.EventChan)
 	}()
-	options := new(system.EventsOptions).WithFilters(filters).WithSince(opts.Since).WithStream(opts.Stream).WithUntil(opts.Until)
+	options := new(system.EventsOptions).WithFilters(filters).WithSince(opts.Since).WithStream(opts.Stream).WithUntil(opts.Until).WithAfterCursor(opts.AfterCursor)
 	return system.Events(ic.ClientCtx, binChan, nil, options)
 }
 
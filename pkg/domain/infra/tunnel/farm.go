@@ -7,6 +7,7 @@ import (
 	"os"
 
 	istorage "github.com/containers/image/v5/storage"
+	"github.com/containers/podman/v4/pkg/bindings/containers"
 	"github.com/containers/podman/v4/pkg/bindings/system"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 )
@@ -37,15 +38,46 @@ func (ir *ImageEngine) fetchInfo(_ context.Context) (os, arch, variant string, n
 	return engineInfo.Host.OS, engineInfo.Host.Arch, engineInfo.Host.Variant, []string{nativePlatform}, nil
 }
 
+// fetchLoad fills in the current load of the remote engine.
+func (ir *ImageEngine) fetchLoad(report *entities.FarmInspectReport) error {
+	engineInfo, err := system.Info(ir.ClientCtx, &system.InfoOptions{})
+	if err != nil {
+		return fmt.Errorf("retrieving host info from %q: %w", ir.NodeName, err)
+	}
+	report.CPUs = engineInfo.Host.CPUs
+	report.MemTotal = engineInfo.Host.MemTotal
+	report.MemFree = engineInfo.Host.MemFree
+
+	// Builds in progress keep their working containers in storage, which
+	// are listed as external containers.
+	ctrs, err := containers.List(ir.ClientCtx, new(containers.ListOptions).WithExternal(true))
+	if err != nil {
+		return fmt.Errorf("listing containers on %q: %w", ir.NodeName, err)
+	}
+	for _, ctr := range ctrs {
+		if len(ctr.Command) == 1 && ctr.Command[0] == "buildah" {
+			report.RunningBuilds++
+		}
+	}
+	return nil
+}
+
 // FarmNodeInspect returns information about the remote engines in the farm
 func (ir *ImageEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspectReport, error) {
 	ir.platforms.Do(func() {
 		ir.os, ir.arch, ir.variant, ir.nativePlatforms, ir.platformsErr = ir.fetchInfo(ctx)
 	})
-	return &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
+	if ir.platformsErr != nil {
+		return nil, ir.platformsErr
+	}
+	report := &entities.FarmInspectReport{NativePlatforms: ir.nativePlatforms,
 		OS:      ir.os,
 		Arch:    ir.arch,
-		Variant: ir.variant}, ir.platformsErr
+		Variant: ir.variant}
+	if err := ir.fetchLoad(report); err != nil {
+		return nil, err
+	}
+	return report, nil
 }
 
 // PullToFile pulls the image from the remote engine and saves it to a file,
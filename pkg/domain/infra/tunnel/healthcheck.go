@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"time"
 
 	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/bindings/containers"
@@ -11,3 +12,18 @@ import (
 func (ic *ContainerEngine) HealthCheckRun(ctx context.Context, nameOrID string, options entities.HealthCheckOptions) (*define.HealthCheckResults, error) {
 	return containers.RunHealthCheck(ic.ClientCtx, nameOrID, nil)
 }
+
+func (ic *ContainerEngine) HealthCheckInspect(ctx context.Context, nameOrID string, options entities.HealthCheckInspectOptions) (*entities.HealthCheckInspectReport, error) {
+	return containers.HealthCheckInspect(ic.ClientCtx, nameOrID, nil)
+}
+
+func (ic *ContainerEngine) HealthCheckLogs(ctx context.Context, nameOrID string, options entities.HealthCheckLogsOptions) error {
+	logsOptions := new(containers.HealthCheckLogsOptions).WithFollow(options.Follow).WithStatus(options.Status)
+	if !options.Since.IsZero() {
+		logsOptions.WithSince(options.Since.Format(time.RFC3339Nano))
+	}
+	if !options.Until.IsZero() {
+		logsOptions.WithUntil(options.Until.Format(time.RFC3339Nano))
+	}
+	return containers.HealthCheckLogs(ic.ClientCtx, nameOrID, logsOptions, options.EntryChan)
+}
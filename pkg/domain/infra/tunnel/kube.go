@@ -47,6 +47,7 @@ func (ic *ContainerEngine) GenerateSystemd(ctx context.Context, nameOrID string,
 // Note: Caller is responsible for closing returned Reader
 func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string, opts entities.GenerateKubeOptions) (*entities.GenerateKubeReport, error) {
 	options := new(generate.KubeOptions).WithService(opts.Service).WithType(opts.Type).WithReplicas(opts.Replicas).WithNoTrunc(opts.UseLongAnnotations).WithPodmanOnly(opts.PodmanOnly)
+	options.WithConfigMaps(opts.ConfigMaps).WithSecretData(opts.SecretData)
 	return generate.Kube(ic.ClientCtx, nameOrIDs, options)
 }
 
@@ -66,6 +67,9 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, opts en
 		options.WithAnnotations(opts.Annotations)
 	}
 	options.WithNoHosts(opts.NoHosts).WithUserns(opts.Userns)
+	if opts.WaitReady {
+		options.WithWaitReady(opts.WaitReady).WithWaitReadyTimeout(opts.WaitReadyTimeout)
+	}
 	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
 		options.WithSkipTLSVerify(s == types.OptionalBoolTrue)
 	}
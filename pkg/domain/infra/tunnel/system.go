@@ -17,6 +17,12 @@ func (ic *ContainerEngine) SetupRootless(_ context.Context, noMoveProcess bool)
 	panic(errors.New("rootless engine mode is not supported when tunneling"))
 }
 
+// SystemCheck checks the libpod state of the service for inconsistencies.
+func (ic *ContainerEngine) SystemCheck(ctx context.Context, opts entities.SystemCheckOptions) (*define.SystemCheckReport, error) {
+	options := new(system.CheckOptions).WithQuick(opts.Quick).WithRepair(opts.Repair)
+	return system.Check(ic.ClientCtx, options)
+}
+
 // SystemPrune prunes unused data from the system.
 func (ic *ContainerEngine) SystemPrune(ctx context.Context, opts entities.SystemPruneOptions) (*entities.SystemPruneReport, error) {
 	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithFilters(opts.Filters).WithExternal(opts.External)
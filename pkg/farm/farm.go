@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/containers/buildah/define"
 	lplatform "github.com/containers/common/libimage/platform"
@@ -18,6 +19,7 @@ import (
 	"github.com/containers/podman/v4/pkg/domain/infra"
 	"github.com/hashicorp/go-multierror"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 )
 
 // Farm represents a group of connections to builders.
@@ -29,7 +31,7 @@ type Farm struct {
 
 // Schedule is a description of where and how we'll do builds.
 type Schedule struct {
-	platformBuilders map[string]string // target->connection
+	platformBuilders map[string][]string // target->connections, in order of preference
 }
 
 func newFarmWithBuilders(_ context.Context, name string, destinations *map[string]config.Destination, localEngine entities.ImageEngine) (*Farm, error) {
@@ -229,10 +231,50 @@ func (f *Farm) EmulatedPlatforms(ctx context.Context) ([]string, error) {
 	return platforms, nil
 }
 
+// farmNode is a node of the farm along with what it reported about itself
+// when scheduling builds.
+type farmNode struct {
+	name     string
+	inspect  *entities.FarmInspectReport
+	assigned int // builds assigned to the node by the schedule
+}
+
+// load is the number of builds per CPU the node would run if it was assigned
+// one more build.
+func (n *farmNode) load() float64 {
+	cpus := n.inspect.CPUs
+	if cpus < 1 {
+		cpus = 1
+	}
+	return float64(n.inspect.RunningBuilds+n.assigned+1) / float64(cpus)
+}
+
+// sortNodes sorts nodes by their load, preferring the local node and then
+// nodes with more free memory when their loads are equal.
+func sortNodes(nodes []*farmNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if la, lb := a.load(), b.load(); la != lb {
+			return la < lb
+		}
+		if local := entities.LocalFarmImageBuilderName; (a.name == local) != (b.name == local) {
+			return a.name == local
+		}
+		if a.inspect.MemFree != b.inspect.MemFree {
+			return a.inspect.MemFree > b.inspect.MemFree
+		}
+		return a.name < b.name
+	})
+}
+
 // Schedule takes a list of platforms and returns a list of connections which
 // can be used to build for those platforms.  It always prefers native builders
 // over emulated builders, but will assign a builder which can use emulation
-// for a platform if no suitable native builder is available.
+// for a platform if no suitable native builder is available.  Among suitable
+// builders, it picks the one with the fewest builds per CPU, counting both
+// the builds already running on it and the ones assigned to it by the
+// schedule.  The other suitable builders are kept to retry the build on if it
+// fails.  Builders which cannot be reached are left out.
 //
 // If platforms is an empty list, all available native platforms will be
 // scheduled.
@@ -241,70 +283,88 @@ func (f *Farm) EmulatedPlatforms(ctx context.Context) ([]string, error) {
 // of, and factor those in when assigning builds to nodes in here.
 func (f *Farm) Schedule(ctx context.Context, platforms []string) (Schedule, error) {
 	var (
-		err       error
-		infoGroup multierror.Group
+		nodes     []*farmNode
+		infoGroup sync.WaitGroup
 		infoMutex sync.Mutex
 	)
-	// If we weren't given a list of target platforms, generate one.
-	if len(platforms) == 0 {
-		platforms, err = f.NativePlatforms(ctx)
-		if err != nil {
-			return Schedule{}, fmt.Errorf("reading list of available native platforms: %w", err)
-		}
-	}
-
-	platformBuilders := make(map[string]string)
-	native := make(map[string]string)
-	emulated := make(map[string]string)
-	var localPlatform string
-	// Make notes of which platforms we can build for natively, and which
-	// ones we can build for using emulation.
 	for name, engine := range f.builders {
 		name, engine := name, engine
-		infoGroup.Go(func() error {
+		infoGroup.Add(1)
+		go func() {
+			defer infoGroup.Done()
 			inspect, err := engine.FarmNodeInspect(ctx)
 			if err != nil {
-				return err
+				logrus.Warnf("Skipping farm node %q: %v", name, err)
+				return
 			}
 			infoMutex.Lock()
 			defer infoMutex.Unlock()
-			for _, n := range inspect.NativePlatforms {
-				if _, assigned := native[n]; !assigned {
-					native[n] = name
-				}
-				if name == entities.LocalFarmImageBuilderName {
-					localPlatform = n
-				}
-			}
-			for _, e := range inspect.EmulatedPlatforms {
-				if _, assigned := emulated[e]; !assigned {
-					emulated[e] = name
-				}
-			}
-			return nil
-		})
+			nodes = append(nodes, &farmNode{name: name, inspect: inspect})
+		}()
 	}
-	merr := infoGroup.Wait()
-	if merr != nil {
-		if err := merr.ErrorOrNil(); err != nil {
-			return Schedule{}, err
+	infoGroup.Wait()
+	if len(nodes) == 0 {
+		return Schedule{}, errors.New("no farm node available")
+	}
+
+	// Make notes of which platforms we can build for natively, and which
+	// ones we can build for using emulation.
+	native := make(map[string][]*farmNode)
+	emulated := make(map[string][]*farmNode)
+	for _, node := range nodes {
+		for _, n := range node.inspect.NativePlatforms {
+			native[n] = append(native[n], node)
+		}
+		for _, e := range node.inspect.EmulatedPlatforms {
+			emulated[e] = append(emulated[e], node)
+		}
+	}
+	// If we weren't given a list of target platforms, generate one.
+	if len(platforms) == 0 {
+		for platform := range native {
+			platforms = append(platforms, platform)
 		}
 	}
-	// Assign a build to the first node that could build it natively, and
-	// if there isn't one, the first one that can build it with the help of
-	// emulation, and if there aren't any, error out.
+	// candidates returns the nodes which can build for a platform in order
+	// of preference, native ones first.
+	candidates := func(platform string) []*farmNode {
+		nativeNodes := append([]*farmNode{}, native[platform]...)
+		sortNodes(nativeNodes)
+		emulatedNodes := make([]*farmNode, 0, len(emulated[platform]))
+		for _, node := range emulated[platform] {
+			if !slices.Contains(nativeNodes, node) {
+				emulatedNodes = append(emulatedNodes, node)
+			}
+		}
+		sortNodes(emulatedNodes)
+		return append(nativeNodes, emulatedNodes...)
+	}
 	for _, platform := range platforms {
-		if builder, ok := native[platform]; ok {
-			platformBuilders[platform] = builder
-		} else if builder, ok := emulated[platform]; ok {
-			platformBuilders[platform] = builder
-		} else {
+		if len(native[platform]) == 0 && len(emulated[platform]) == 0 {
 			return Schedule{}, fmt.Errorf("no builder capable of building for platform %q available", platform)
 		}
 	}
-	// If local is set, prioritize building on local
-	if localPlatform != "" {
-		platformBuilders[localPlatform] = entities.LocalFarmImageBuilderName
+	// Assign the platforms with the fewest suitable builders first, so
+	// that the others don't take up those builders.
+	platforms = append([]string{}, platforms...)
+	sort.SliceStable(platforms, func(i, j int) bool {
+		ci, cj := len(candidates(platforms[i])), len(candidates(platforms[j]))
+		if ci != cj {
+			return ci < cj
+		}
+		return platforms[i] < platforms[j]
+	})
+
+	platformBuilders := make(map[string][]string)
+	for _, platform := range platforms {
+		if _, ok := platformBuilders[platform]; ok {
+			continue
+		}
+		builders := candidates(platform)
+		builders[0].assigned++
+		for _, node := range builders {
+			platformBuilders[platform] = append(platformBuilders[platform], node.name)
+		}
 	}
 	schedule := Schedule{
 		platformBuilders: platformBuilders,
@@ -312,9 +372,33 @@ func (f *Farm) Schedule(ctx context.Context, platforms []string) (Schedule, erro
 	return schedule, nil
 }
 
+// String describes on which builder each platform will be built.
+func (s Schedule) String() string {
+	platforms := make([]string, 0, len(s.platformBuilders))
+	for platform := range s.platformBuilders {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	for i, platform := range platforms {
+		platforms[i] = platform + "@" + strings.Join(s.platformBuilders[platform], ",")
+	}
+	return strings.Join(platforms, " ")
+}
+
+// buildJob is a build for a single platform.
+type buildJob struct {
+	platform string
+	os       string
+	arch     string
+	variant  string
+}
+
 // Build runs a build using the specified targetplatform:service map.  If all
 // builds succeed, it copies the resulting images from the remote hosts to the
 // local service and builds a manifest list with the specified reference name.
+// A build which fails is retried on the next builder the schedule lists for
+// its platform.  If options.Push is set, the nodes push the images to the
+// registry instead and only the manifest list is pushed from the local host.
 func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.BuildOptions, reference string) error {
 	switch options.OutputFormat {
 	default:
@@ -325,18 +409,12 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	}
 
 	// Build the list of jobs.
-	var jobs sync.Map
-	type job struct {
-		platform string
-		os       string
-		arch     string
-		variant  string
-		builder  entities.ImageEngine
-	}
-	for platform, builderName := range schedule.platformBuilders { // prepare to build
-		builder, ok := f.builders[builderName]
-		if !ok {
-			return fmt.Errorf("unknown builder %q", builderName)
+	jobs := make(map[string]buildJob)
+	for platform, builderNames := range schedule.platformBuilders { // prepare to build
+		for _, builderName := range builderNames {
+			if _, ok := f.builders[builderName]; !ok {
+				return fmt.Errorf("unknown builder %q", builderName)
+			}
 		}
 		var rawOS, rawArch, rawVariant string
 		p := strings.Split(platform, "/")
@@ -350,13 +428,12 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 			rawVariant = p[2]
 		}
 		os, arch, variant := lplatform.Normalize(rawOS, rawArch, rawVariant)
-		jobs.Store(builderName, job{
+		jobs[platform] = buildJob{
 			platform: platform,
 			os:       os,
 			arch:     arch,
 			variant:  variant,
-			builder:  builder,
-		})
+		}
 	}
 
 	// Decide where the final result will be stored.
@@ -365,16 +442,23 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 		err                 error
 	)
 	listBuilderOptions := listBuilderOptions{
-		cleanup: options.Cleanup,
-		iidFile: options.IIDFile,
+		cleanup:   options.Cleanup,
+		iidFile:   options.IIDFile,
+		localCopy: options.LocalCopy,
 	}
-	if strings.HasPrefix(reference, "dir:") || f.localEngine == nil {
+	switch {
+	case options.Push:
+		manifestListBuilder, err = newRegistryManifestListBuilder(reference, f.localEngine, options.SystemContext, listBuilderOptions)
+		if err != nil {
+			return fmt.Errorf("preparing to push list: %w", err)
+		}
+	case strings.HasPrefix(reference, "dir:") || f.localEngine == nil:
 		location := strings.TrimPrefix(reference, "dir:")
 		manifestListBuilder, err = newFileManifestListBuilder(location, listBuilderOptions)
 		if err != nil {
 			return fmt.Errorf("preparing to build list: %w", err)
 		}
-	} else {
+	default:
 		manifestListBuilder = newLocalManifestListBuilder(reference, f.localEngine, listBuilderOptions)
 	}
 
@@ -383,68 +467,30 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 		buildResults sync.Map
 		buildGroup   multierror.Group
 	)
-	type buildResult struct {
-		report  entities.BuildReport
-		builder entities.ImageEngine
-	}
-	for platform, builder := range schedule.platformBuilders {
-		platform, builder := platform, builder
-		outReader, outWriter := io.Pipe()
-		errReader, errWriter := io.Pipe()
-		go func() {
-			defer outReader.Close()
-			reader := bufio.NewReader(outReader)
-			writer := options.Out
-			if writer == nil {
-				writer = os.Stdout
-			}
-			line, err := reader.ReadString('\n')
-			for err == nil {
-				line = strings.TrimSuffix(line, "\n")
-				fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
-				line, err = reader.ReadString('\n')
-			}
-		}()
-		go func() {
-			defer errReader.Close()
-			reader := bufio.NewReader(errReader)
-			writer := options.Err
-			if writer == nil {
-				writer = os.Stderr
-			}
-			line, err := reader.ReadString('\n')
-			for err == nil {
-				line = strings.TrimSuffix(line, "\n")
-				fmt.Fprintf(writer, "[%s@%s] %s\n", platform, builder, line)
-				line, err = reader.ReadString('\n')
-			}
-		}()
+	for platform, builderNames := range schedule.platformBuilders {
+		j, builderNames := jobs[platform], builderNames
 		buildGroup.Go(func() error {
-			var j job
-			defer outWriter.Close()
-			defer errWriter.Close()
-			c, ok := jobs.Load(builder)
-			if !ok {
-				return fmt.Errorf("unknown connection for %q (shouldn't happen)", builder)
-			}
-			if j, ok = c.(job); !ok {
-				return fmt.Errorf("unexpected connection type for %q (shouldn't happen)", builder)
-			}
-			buildOptions := options
-			buildOptions.Platforms = []struct{ OS, Arch, Variant string }{{j.os, j.arch, j.variant}}
-			buildOptions.Out = outWriter
-			buildOptions.Err = errWriter
-			fmt.Printf("Starting build for %v at %q\n", buildOptions.Platforms, builder)
-			buildReport, err := j.builder.Build(ctx, options.ContainerFiles, buildOptions)
-			if err != nil {
-				return fmt.Errorf("building for %q on %q: %w", j.platform, builder, err)
+			var merr *multierror.Error
+			for i, builderName := range builderNames {
+				builder := f.builders[builderName]
+				buildReport, err := f.buildOn(ctx, j, builderName, builder, options)
+				if err == nil {
+					buildResults.Store(j.platform, builtImage{
+						platform: j.platform,
+						report:   *buildReport,
+						builder:  builder,
+					})
+					return nil
+				}
+				merr = multierror.Append(merr, err)
+				if ctx.Err() != nil {
+					break
+				}
+				if i+1 < len(builderNames) {
+					fmt.Printf("Retrying build for %s at %q: %v\n", j.platform, builderNames[i+1], err)
+				}
 			}
-			fmt.Printf("finished build for %v at %q: built %s\n", buildOptions.Platforms, builder, buildReport.ID)
-			buildResults.Store(platform, buildResult{
-				report:  *buildReport,
-				builder: j.builder,
-			})
-			return nil
+			return merr.ErrorOrNil()
 		})
 	}
 	buildErrors := buildGroup.Wait()
@@ -453,14 +499,14 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	}
 
 	// Assemble the final result.
-	perArchBuilds := make(map[entities.BuildReport]entities.ImageEngine)
+	perArchBuilds := []builtImage{}
 	buildResults.Range(func(k, v any) bool {
-		result, ok := v.(buildResult)
+		result, ok := v.(builtImage)
 		if !ok {
 			fmt.Fprintf(os.Stderr, "report %v not a build result?", v)
 			return false
 		}
-		perArchBuilds[result.report] = result.builder
+		perArchBuilds = append(perArchBuilds, result)
 		return true
 	})
 	location, err := manifestListBuilder.build(ctx, perArchBuilds)
@@ -471,6 +517,58 @@ func (f *Farm) Build(ctx context.Context, schedule Schedule, options entities.Bu
 	return nil
 }
 
+// buildOn runs a build for a single platform on a builder, prefixing its
+// output with the platform and the builder's name.
+func (f *Farm) buildOn(ctx context.Context, j buildJob, builderName string, builder entities.ImageEngine, options entities.BuildOptions) (*entities.BuildReport, error) {
+	outReader, outWriter := io.Pipe()
+	errReader, errWriter := io.Pipe()
+	go func() {
+		defer outReader.Close()
+		reader := bufio.NewReader(outReader)
+		writer := options.Out
+		if writer == nil {
+			writer = os.Stdout
+		}
+		line, err := reader.ReadString('\n')
+		for err == nil {
+			line = strings.TrimSuffix(line, "\n")
+			fmt.Fprintf(writer, "[%s@%s] %s\n", j.platform, builderName, line)
+			line, err = reader.ReadString('\n')
+		}
+	}()
+	go func() {
+		defer errReader.Close()
+		reader := bufio.NewReader(errReader)
+		writer := options.Err
+		if writer == nil {
+			writer = os.Stderr
+		}
+		line, err := reader.ReadString('\n')
+		for err == nil {
+			line = strings.TrimSuffix(line, "\n")
+			fmt.Fprintf(writer, "[%s@%s] %s\n", j.platform, builderName, line)
+			line, err = reader.ReadString('\n')
+		}
+	}()
+	defer outWriter.Close()
+	defer errWriter.Close()
+
+	buildOptions := options
+	buildOptions.Platforms = []struct{ OS, Arch, Variant string }{{j.os, j.arch, j.variant}}
+	buildOptions.Out = outWriter
+	buildOptions.Err = errWriter
+	fmt.Printf("Starting build for %v at %q\n", buildOptions.Platforms, builderName)
+	start := time.Now()
+	buildReport, err := builder.Build(ctx, options.ContainerFiles, buildOptions)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Printf("failed build for %v at %q after %s\n", buildOptions.Platforms, builderName, elapsed)
+		return nil, fmt.Errorf("building for %q on %q: %w", j.platform, builderName, err)
+	}
+	fmt.Printf("finished build for %v at %q in %s: built %s\n", buildOptions.Platforms, builderName, elapsed, buildReport.ID)
+	return buildReport, nil
+}
+
 func getFarmDestinations(name string) (map[string]config.Destination, error) {
 	dest := make(map[string]config.Destination)
 	cfg, err := config.ReadCustomConfig()
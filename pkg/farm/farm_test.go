@@ -0,0 +1,86 @@
+package farm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEngine struct {
+	entities.ImageEngine
+	inspect *entities.FarmInspectReport
+	err     error
+}
+
+func (f *fakeEngine) FarmNodeInspect(ctx context.Context) (*entities.FarmInspectReport, error) {
+	return f.inspect, f.err
+}
+
+func node(cpus, running int, memFree int64, native []string, emulated ...string) entities.ImageEngine {
+	return &fakeEngine{inspect: &entities.FarmInspectReport{
+		NativePlatforms:   native,
+		EmulatedPlatforms: emulated,
+		CPUs:              cpus,
+		MemFree:           memFree,
+		RunningBuilds:     running,
+	}}
+}
+
+func TestSchedule(t *testing.T) {
+	amd64 := []string{"linux/amd64"}
+	arm64 := []string{"linux/arm64"}
+	f := &Farm{builders: map[string]entities.ImageEngine{
+		"busy":     node(4, 8, 1<<30, amd64, "linux/arm64"),
+		"idle":     node(4, 0, 1<<30, amd64),
+		"small":    node(1, 0, 1<<30, amd64),
+		"arm":      node(8, 0, 1<<30, arm64),
+		"emulator": node(16, 0, 1<<30, nil, "linux/arm64", "linux/s390x"),
+		"down":     &fakeEngine{err: errors.New("unreachable")},
+	}}
+
+	schedule, err := f.Schedule(context.Background(), []string{"linux/amd64", "linux/arm64", "linux/s390x"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		// Native builders first, least loaded first.
+		"linux/amd64": {"idle", "small", "busy"},
+		// Native builders are preferred over idle emulating ones.
+		"linux/arm64": {"arm", "emulator", "busy"},
+		"linux/s390x": {"emulator"},
+	}, schedule.platformBuilders)
+
+	_, err = f.Schedule(context.Background(), []string{"linux/ppc64le"})
+	assert.ErrorContains(t, err, `no builder capable of building for platform "linux/ppc64le" available`)
+
+	// Without platforms, all native platforms are scheduled.
+	schedule, err = f.Schedule(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "linux/amd64@idle,small,busy linux/arm64@arm,emulator,busy", schedule.String())
+}
+
+func TestScheduleLoad(t *testing.T) {
+	amd64 := []string{"linux/amd64"}
+	f := &Farm{builders: map[string]entities.ImageEngine{
+		"a":                                node(2, 0, 1<<30, amd64, "linux/arm64", "linux/s390x"),
+		"b":                                node(2, 0, 2<<30, amd64, "linux/arm64", "linux/s390x"),
+		entities.LocalFarmImageBuilderName: node(2, 1, 1<<30, amd64, "linux/arm64", "linux/s390x"),
+	}}
+	// Builds assigned by the schedule count towards the load, and nodes
+	// with more free memory are preferred when the load is equal.
+	schedule, err := f.Schedule(context.Background(), []string{"linux/amd64", "linux/arm64", "linux/s390x"})
+	require.NoError(t, err)
+	assert.Equal(t, "b", schedule.platformBuilders["linux/amd64"][0])
+	assert.Equal(t, "a", schedule.platformBuilders["linux/arm64"][0])
+	// The local node is preferred over another node with the same load.
+	assert.Equal(t, entities.LocalFarmImageBuilderName, schedule.platformBuilders["linux/s390x"][0])
+
+	// Unreachable nodes are left out, but not all of them.
+	f = &Farm{builders: map[string]entities.ImageEngine{
+		"down": &fakeEngine{err: errors.New("unreachable")},
+	}}
+	_, err = f.Schedule(context.Background(), amd64)
+	assert.ErrorContains(t, err, "no farm node available")
+}
@@ -2,32 +2,48 @@ package farm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	lmanifests "github.com/containers/common/libimage/manifests"
 	"github.com/containers/common/pkg/supplemented"
 	cp "github.com/containers/image/v5/copy"
+	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
 	"github.com/containers/image/v5/manifest"
 	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/transports"
 	"github.com/containers/image/v5/transports/alltransports"
 	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v4/libpod/define"
 	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/storage/pkg/stringid"
 	"github.com/hashicorp/go-multierror"
+	"github.com/opencontainers/go-digest"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 )
 
+// builtImage is an image built for a platform by a farm node.
+type builtImage struct {
+	platform string
+	report   entities.BuildReport
+	builder  entities.ImageEngine
+}
+
 type listBuilder interface {
-	build(ctx context.Context, images map[entities.BuildReport]entities.ImageEngine) (string, error)
+	build(ctx context.Context, images []builtImage) (string, error)
 }
 
 type listBuilderOptions struct {
-	cleanup bool
-	iidFile string
+	cleanup   bool
+	iidFile   string
+	localCopy bool
 }
 
 type listLocal struct {
@@ -48,7 +64,7 @@ func newLocalManifestListBuilder(listName string, localEngine entities.ImageEngi
 
 // Build retrieves images from the build reports and assembles them into a
 // manifest list in local container storage.
-func (l *listLocal) build(ctx context.Context, images map[entities.BuildReport]entities.ImageEngine) (string, error) {
+func (l *listLocal) build(ctx context.Context, images []builtImage) (string, error) {
 	manifest := l.listName
 	exists, err := l.localEngine.ManifestExists(ctx, l.listName)
 	if err != nil {
@@ -68,8 +84,8 @@ func (l *listLocal) build(ctx context.Context, images map[entities.BuildReport]e
 		refsMutex sync.Mutex
 	)
 	refs := []string{}
-	for image, engine := range images {
-		image, engine := image, engine
+	for _, built := range images {
+		image, engine := built.report, built.builder
 		pullOptions := entities.PullToLocalOptions{
 			ImageID:     image.ID,
 			SaveFormat:  image.SaveFormat,
@@ -96,11 +112,11 @@ func (l *listLocal) build(ctx context.Context, images map[entities.BuildReport]e
 
 	if l.options.cleanup {
 		var rmGroup multierror.Group
-		for image, engine := range images {
-			if engine.FarmNodeName(ctx) == entities.LocalFarmImageBuilderName {
+		for _, built := range images {
+			if built.builder.FarmNodeName(ctx) == entities.LocalFarmImageBuilderName {
 				continue
 			}
-			image, engine := image, engine
+			image, engine := built.report, built.builder
 			rmGroup.Go(func() error {
 				_, err := engine.Remove(ctx, []string{image.ID}, entities.ImageRemoveOptions{})
 				if len(err) > 0 {
@@ -157,7 +173,7 @@ func newFileManifestListBuilder(directory string, options listBuilderOptions) (l
 
 // Build retrieves images from the build reports and assembles them into a
 // manifest list in the configured directory.
-func (m *listFiles) build(ctx context.Context, images map[entities.BuildReport]entities.ImageEngine) (string, error) {
+func (m *listFiles) build(ctx context.Context, images []builtImage) (string, error) {
 	listFormat := v1.MediaTypeImageIndex
 	imageFormat := v1.MediaTypeImageManifest
 
@@ -187,8 +203,8 @@ func (m *listFiles) build(ctx context.Context, images map[entities.BuildReport]e
 		refsMutex  sync.Mutex
 	)
 	refs := make(map[entities.BuildReport]types.ImageReference)
-	for image, engine := range images {
-		image, engine := image, engine
+	for _, built := range images {
+		image, engine := built.report, built.builder
 		tempFile, err := os.CreateTemp(tempDir, "archive-*.tar")
 		if err != nil {
 			defer func() {
@@ -236,8 +252,8 @@ func (m *listFiles) build(ctx context.Context, images map[entities.BuildReport]e
 
 	if m.options.cleanup {
 		var rmGroup multierror.Group
-		for image, engine := range images {
-			image, engine := image, engine
+		for _, built := range images {
+			image, engine := built.report, built.builder
 			rmGroup.Go(func() error {
 				_, err := engine.Remove(ctx, []string{image.ID}, entities.ImageRemoveOptions{})
 				if len(err) > 0 {
@@ -295,3 +311,230 @@ func (m *listFiles) build(ctx context.Context, images map[entities.BuildReport]e
 
 	return "dir:" + m.directory, nil
 }
+
+type listRegistry struct {
+	reference   reference.NamedTagged
+	localEngine entities.ImageEngine // not nil -> keep a copy of the list in local storage
+	sys         *types.SystemContext
+	options     listBuilderOptions
+}
+
+// newRegistryManifestListBuilder returns a manifest list builder which has the
+// farm nodes push their images to a registry and pushes a manifest list
+// referring to them, without copying the images to the local host.
+func newRegistryManifestListBuilder(listName string, localEngine entities.ImageEngine, sys *types.SystemContext, options listBuilderOptions) (listBuilder, error) {
+	if strings.HasPrefix(listName, "dir:") {
+		return nil, fmt.Errorf("cannot push manifest list to %q, a registry is required", listName)
+	}
+	named, err := reference.ParseNormalizedNamed(listName)
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest list name %q: %w", listName, err)
+	}
+	if _, isDigested := named.(reference.Digested); isDigested {
+		return nil, fmt.Errorf("cannot push manifest list to %q: digests are not supported", listName)
+	}
+	tagged, ok := reference.TagNameOnly(named).(reference.NamedTagged)
+	if !ok {
+		return nil, fmt.Errorf("internal error: manifest list name %q has no tag", listName)
+	}
+	if !options.localCopy {
+		localEngine = nil
+	}
+	if localEngine == nil && options.iidFile != "" {
+		return nil, errors.New("pushing without a local copy of the manifest list doesn't produce an image ID, --iidfile not supported")
+	}
+	return &listRegistry{
+		reference:   tagged,
+		localEngine: localEngine,
+		sys:         sys,
+		options:     options,
+	}, nil
+}
+
+// pushOptions returns the options the farm nodes push their images with,
+// using the registry settings of the build.
+func (r *listRegistry) pushOptions(format string) entities.ImagePushOptions {
+	options := entities.ImagePushOptions{
+		Format: format,
+		Quiet:  true,
+	}
+	if r.sys != nil {
+		options.Authfile = r.sys.AuthFilePath
+		options.SkipTLSVerify = r.sys.DockerInsecureSkipTLSVerify
+		if r.sys.DockerAuthConfig != nil {
+			options.Username = r.sys.DockerAuthConfig.Username
+			options.Password = r.sys.DockerAuthConfig.Password
+		}
+	}
+	return options
+}
+
+// Build has the farm nodes push the images from the build reports to the
+// registry, then assembles a manifest list referring to them by digest and
+// pushes it.  The images are pushed to temporary tags, so the name of the list
+// is only updated once the list is complete, and the temporary tags are
+// deleted afterwards.
+func (r *listRegistry) build(ctx context.Context, images []builtImage) (string, error) {
+	listFormat := v1.MediaTypeImageIndex
+	pushFormat := "oci"
+	for _, image := range images {
+		if image.report.SaveFormat == define.V2s2Archive {
+			listFormat = manifest.DockerV2ListMediaType
+			pushFormat = "v2s2"
+		}
+	}
+
+	// Push the images from the farm nodes
+	var (
+		pushGroup multierror.Group
+		refsMutex sync.Mutex
+	)
+	refs := []types.ImageReference{}
+	temporaryTags := []reference.NamedTagged{}
+	defer func() {
+		// The list refers to the images by digest, the temporary tags are
+		// not needed anymore.
+		for _, tag := range temporaryTags {
+			if err := deleteTag(ctx, r.sys, tag); err != nil {
+				logrus.Warnf("Deleting temporary tag %q: %v", tag, err)
+			}
+		}
+	}()
+	buildID := stringid.GenerateRandomID()[:12]
+	for _, image := range images {
+		image := image
+		pushGroup.Go(func() error {
+			temporary, err := temporaryTag(r.reference, buildID, image.platform)
+			if err != nil {
+				return err
+			}
+			destination := temporary.String()
+			nodeName := image.builder.FarmNodeName(ctx)
+			fmt.Printf("Pushing image %s for %s from %q\n", image.report.ID, image.platform, nodeName)
+			report, err := image.builder.Push(ctx, image.report.ID, destination, r.pushOptions(pushFormat))
+			if err != nil {
+				return fmt.Errorf("pushing image %q from %q to %q: %w", image.report.ID, nodeName, destination, err)
+			}
+			refsMutex.Lock()
+			temporaryTags = append(temporaryTags, temporary)
+			refsMutex.Unlock()
+			imageDigest, err := digest.Parse(report.ManifestDigest)
+			if err != nil {
+				return fmt.Errorf("pushing image %q from %q: no valid digest: %w", image.report.ID, nodeName, err)
+			}
+			named, err := reference.WithDigest(reference.TrimNamed(r.reference), imageDigest)
+			if err != nil {
+				return err
+			}
+			ref, err := docker.NewReference(named)
+			if err != nil {
+				return err
+			}
+			refsMutex.Lock()
+			defer refsMutex.Unlock()
+			refs = append(refs, ref)
+			return nil
+		})
+	}
+	pushErrors := pushGroup.Wait()
+	if err := pushErrors.ErrorOrNil(); err != nil {
+		return "", fmt.Errorf("pushing: %w", err)
+	}
+
+	if r.options.cleanup {
+		var rmGroup multierror.Group
+		for _, built := range images {
+			image, engine := built.report, built.builder
+			rmGroup.Go(func() error {
+				_, err := engine.Remove(ctx, []string{image.ID}, entities.ImageRemoveOptions{})
+				if len(err) > 0 {
+					return err[0]
+				}
+				return nil
+			})
+		}
+		rmErrors := rmGroup.Wait()
+		if rmErrors != nil {
+			if err := rmErrors.ErrorOrNil(); err != nil {
+				return "", fmt.Errorf("removing intermediate images: %w", err)
+			}
+		}
+	}
+
+	// Create a manifest list referring to the pushed images
+	list := lmanifests.Create()
+	for _, ref := range refs {
+		if _, err := list.Add(ctx, r.sys, ref, false); err != nil {
+			return "", fmt.Errorf("adding image %q to list: %w", transports.ImageName(ref), err)
+		}
+	}
+	listBytes, err := list.Serialize(listFormat)
+	if err != nil {
+		return "", fmt.Errorf("serializing manifest list: %w", err)
+	}
+
+	// Push the list, the images it refers to are in the registry already
+	listRef, err := docker.NewReference(r.reference)
+	if err != nil {
+		return "", err
+	}
+	dest, err := listRef.NewImageDestination(ctx, r.sys)
+	if err != nil {
+		return "", fmt.Errorf("pushing manifest list to %q: %w", r.reference, err)
+	}
+	defer dest.Close()
+	if err := dest.PutManifest(ctx, listBytes, nil); err != nil {
+		return "", fmt.Errorf("pushing manifest list to %q: %w", r.reference, err)
+	}
+	if err := dest.Commit(ctx, nil); err != nil {
+		return "", fmt.Errorf("pushing manifest list to %q: %w", r.reference, err)
+	}
+	listDigest, err := manifest.Digest(listBytes)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Pushed list %s to %q\n", listDigest, r.reference)
+
+	if r.localEngine != nil {
+		if err := r.saveLocalCopy(ctx, refs); err != nil {
+			return "", err
+		}
+	}
+	return "docker://" + r.reference.String(), nil
+}
+
+// saveLocalCopy saves a manifest list referring to the pushed images in local
+// storage, without pulling the images.
+func (r *listRegistry) saveLocalCopy(ctx context.Context, refs []types.ImageReference) error {
+	listName := r.reference.String()
+	exists, err := r.localEngine.ManifestExists(ctx, listName)
+	if err != nil {
+		return err
+	}
+	if exists.Value {
+		// Clear the list in the event it already existed
+		if _, err := r.localEngine.ManifestListClear(ctx, listName); err != nil {
+			return fmt.Errorf("clearing list %q: %w", listName, err)
+		}
+	} else if _, err := r.localEngine.ManifestCreate(ctx, listName, []string{}, entities.ManifestCreateOptions{}); err != nil {
+		return fmt.Errorf("creating manifest list %q: %w", listName, err)
+	}
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, transports.ImageName(ref))
+	}
+	addOptions := entities.ManifestAddOptions{}
+	if r.sys != nil {
+		addOptions.Authfile = r.sys.AuthFilePath
+	}
+	listID, err := r.localEngine.ManifestAdd(ctx, listName, names, addOptions)
+	if err != nil {
+		return fmt.Errorf("adding images %q to list: %w", names, err)
+	}
+	if r.options.iidFile != "" {
+		if err := os.WriteFile(r.options.iidFile, []byte("sha256:"+listID), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package farm
+
+import (
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistryManifestListBuilder(t *testing.T) {
+	builder, err := newRegistryManifestListBuilder("quay.io/user/app", nil, nil, listBuilderOptions{})
+	require.NoError(t, err)
+	list := builder.(*listRegistry).reference
+	assert.Equal(t, "quay.io/user/app:latest", list.String())
+
+	options := builder.(*listRegistry).pushOptions("oci")
+	assert.Equal(t, entities.ImagePushOptions{Format: "oci", Quiet: true}, options)
+
+	sys := &types.SystemContext{
+		AuthFilePath:                "/tmp/auth.json",
+		DockerAuthConfig:            &types.DockerAuthConfig{Username: "user", Password: "secret"},
+		DockerInsecureSkipTLSVerify: types.OptionalBoolTrue,
+	}
+	builder, err = newRegistryManifestListBuilder("quay.io/user/app", nil, sys, listBuilderOptions{})
+	require.NoError(t, err)
+	options = builder.(*listRegistry).pushOptions("v2s2")
+	assert.Equal(t, entities.ImagePushOptions{
+		Authfile:      "/tmp/auth.json",
+		Username:      "user",
+		Password:      "secret",
+		Format:        "v2s2",
+		Quiet:         true,
+		SkipTLSVerify: types.OptionalBoolTrue,
+	}, options)
+
+	for _, tc := range []struct {
+		name    string
+		options listBuilderOptions
+		err     string
+	}{
+		{"dir:/tmp/app", listBuilderOptions{}, "a registry is required"},
+		{"quay.io/user/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", listBuilderOptions{}, "digests are not supported"},
+		{"quay.io/user/app:v1", listBuilderOptions{iidFile: "iid", localCopy: true}, "--iidfile not supported"},
+	} {
+		_, err := newRegistryManifestListBuilder(tc.name, nil, nil, tc.options)
+		assert.ErrorContains(t, err, tc.err, tc.name)
+	}
+}
@@ -0,0 +1,174 @@
+package farm
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/docker/config"
+	"github.com/containers/image/v5/pkg/tlsclientconfig"
+	"github.com/containers/image/v5/types"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+)
+
+// maxTagLength is the maximum length of a tag in a registry.
+const maxTagLength = 128
+
+// temporaryTag returns the tag a farm node pushes its image for the platform
+// to before the manifest list is pushed.  The tag is derived from the tag of
+// the list and the ID of the build, so it never refers to the final image.
+func temporaryTag(ref reference.NamedTagged, buildID, platform string) (reference.NamedTagged, error) {
+	suffix := fmt.Sprintf("-farm-%s-%s", buildID, strings.NewReplacer("/", "-", ":", "-").Replace(platform))
+	tag := ref.Tag()
+	if len(tag)+len(suffix) > maxTagLength {
+		tag = ""
+		if maxTagLength > len(suffix) {
+			tag = ref.Tag()[:maxTagLength-len(suffix)]
+		}
+	}
+	if tag == "" {
+		suffix = strings.TrimPrefix(suffix, "-")
+	}
+	return reference.WithTag(reference.TrimNamed(ref), tag+suffix)
+}
+
+// deleteTag removes the tag from its repository without deleting the image
+// it refers to, which the manifest list still refers to by digest.  Not all
+// registries support deleting tags.
+func deleteTag(ctx context.Context, sys *types.SystemContext, ref reference.NamedTagged) error {
+	registry := reference.Domain(ref)
+	host := registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	certDirs := []string{filepath.Join("/etc/containers/certs.d", registry), filepath.Join("/etc/docker/certs.d", registry)}
+	if sys != nil && sys.DockerCertPath != "" {
+		certDirs = []string{sys.DockerCertPath}
+	}
+	for _, dir := range certDirs {
+		if err := tlsclientconfig.SetupCertificates(dir, tlsConfig); err != nil {
+			return err
+		}
+	}
+	if sys != nil && sys.DockerInsecureSkipTLSVerify == types.OptionalBoolTrue {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	transport := tlsclientconfig.NewTransport()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport}
+
+	creds, err := config.GetCredentialsForRef(sys, ref)
+	if err != nil {
+		return err
+	}
+
+	tagURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, reference.Path(ref), ref.Tag())
+	resp, err := doDeleteTag(ctx, client, tagURL, "")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenges := challenge.ResponseChallenges(resp)
+		resp.Body.Close()
+		authorization, err := registryAuthorization(ctx, client, challenges, creds, reference.Path(ref))
+		if err != nil {
+			return err
+		}
+		if resp, err = doDeleteTag(ctx, client, tagURL, authorization); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		return nil
+	default:
+		return fmt.Errorf("deleting tag %s: registry responded with %s", ref, resp.Status)
+	}
+}
+
+func doDeleteTag(ctx context.Context, client *http.Client, tagURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, tagURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return client.Do(req)
+}
+
+// registryAuthorization returns the value of the Authorization header
+// answering one of the challenges of the registry.
+func registryAuthorization(ctx context.Context, client *http.Client, challenges []challenge.Challenge, creds types.DockerAuthConfig, repository string) (string, error) {
+	for _, c := range challenges {
+		switch strings.ToLower(c.Scheme) {
+		case "basic":
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds.Username+":"+creds.Password)), nil
+		case "bearer":
+			token, err := registryToken(ctx, client, c.Parameters, creds, repository)
+			if err != nil {
+				return "", err
+			}
+			return "Bearer " + token, nil
+		}
+	}
+	return "", fmt.Errorf("no supported authentication challenge in %v", challenges)
+}
+
+// registryToken requests a bearer token allowing to delete from the
+// repository from the token server of the registry.
+func registryToken(ctx context.Context, client *http.Client, params map[string]string, creds types.DockerAuthConfig, repository string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	// Like deleting images with containers/image, ask for all actions as
+	// registries disagree on the action needed for deleting.
+	query.Set("scope", fmt.Sprintf("repository:%s:*", repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if creds.Username != "" {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting token from %s: %s", realm.Host, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return "", fmt.Errorf("decoding token from %s: %w", realm.Host, err)
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", fmt.Errorf("no token received from %s", realm.Host)
+}
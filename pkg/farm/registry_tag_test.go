@@ -0,0 +1,80 @@
+package farm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemporaryTag(t *testing.T) {
+	named, err := reference.ParseNormalizedNamed("quay.io/user/app:v1")
+	require.NoError(t, err)
+	tag, err := temporaryTag(named.(reference.NamedTagged), "0123456789ab", "linux/arm64/v8")
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/user/app:v1-farm-0123456789ab-linux-arm64-v8", tag.String())
+
+	named, err = reference.ParseNormalizedNamed("quay.io/user/app:" + strings.Repeat("t", maxTagLength))
+	require.NoError(t, err)
+	tag, err = temporaryTag(named.(reference.NamedTagged), "0123456789ab", "linux/amd64")
+	require.NoError(t, err)
+	assert.Len(t, tag.Tag(), maxTagLength)
+	assert.True(t, strings.HasSuffix(tag.Tag(), "-farm-0123456789ab-linux-amd64"), tag.Tag())
+}
+
+func TestDeleteTag(t *testing.T) {
+	var deleted []string
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "registry", r.URL.Query().Get("service"))
+		assert.Equal(t, "repository:user/app:*", r.URL.Query().Get("scope"))
+		_, _ = w.Write([]byte(`{"token": "abc"}`))
+	})
+	mux.HandleFunc("/v2/user/app/manifests/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		tag := strings.TrimPrefix(r.URL.Path, "/v2/user/app/manifests/")
+		if tag == "unsupported" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		deleted = append(deleted, tag)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server = httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	sys := &types.SystemContext{
+		DockerAuthConfig:            &types.DockerAuthConfig{Username: "user", Password: "secret"},
+		DockerInsecureSkipTLSVerify: types.OptionalBoolTrue,
+		DockerCertPath:              t.TempDir(),
+	}
+	registry := strings.TrimPrefix(server.URL, "https://")
+	named, err := reference.ParseNormalizedNamed(registry + "/user/app:v1-farm-0123456789ab-linux-amd64")
+	require.NoError(t, err)
+	require.NoError(t, deleteTag(context.Background(), sys, named.(reference.NamedTagged)))
+	assert.Equal(t, []string{"v1-farm-0123456789ab-linux-amd64"}, deleted)
+
+	named, err = reference.ParseNormalizedNamed(registry + "/user/app:unsupported")
+	require.NoError(t, err)
+	assert.ErrorContains(t, deleteTag(context.Background(), sys, named.(reference.NamedTagged)), "405 Method Not Allowed")
+}
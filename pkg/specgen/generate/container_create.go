@@ -539,6 +539,12 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 		if s.LogConfiguration.Size > 0 {
 			options = append(options, libpod.WithMaxLogSize(s.LogConfiguration.Size))
 		}
+		if s.LogConfiguration.RateLimit > 0 || s.LogConfiguration.Burst > 0 {
+			options = append(options, libpod.WithLogRateLimit(s.LogConfiguration.RateLimit, s.LogConfiguration.Burst))
+		}
+		if s.LogConfiguration.MaxFiles > 0 || s.LogConfiguration.Compress {
+			options = append(options, libpod.WithLogRotation(s.LogConfiguration.MaxFiles, s.LogConfiguration.Compress))
+		}
 		if len(s.LogConfiguration.Options) > 0 && s.LogConfiguration.Options["tag"] != "" {
 			options = append(options, libpod.WithLogTag(s.LogConfiguration.Options["tag"]))
 		}
@@ -625,11 +631,21 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.ContainerHealthCheckConfig.HealthCheckOnFailureAction != define.HealthCheckOnFailureActionNone {
 		options = append(options, libpod.WithHealthCheckOnFailureAction(s.ContainerHealthCheckConfig.HealthCheckOnFailureAction))
 	}
+	if len(s.ContainerHealthCheckConfig.HealthCheckOnFailureCommand) > 0 {
+		options = append(options, libpod.WithHealthCheckOnFailureCommand(s.ContainerHealthCheckConfig.HealthCheckOnFailureCommand, s.ContainerHealthCheckConfig.HealthCheckOnFailureCommandInContainer))
+	}
 
 	if s.SdNotifyMode == define.SdNotifyModeHealthy && !healthCheckSet {
 		return nil, fmt.Errorf("%w: sdnotify policy %q requires a healthcheck to be set", define.ErrInvalidArg, s.SdNotifyMode)
 	}
 
+	if len(s.PostStartCommand) > 0 {
+		options = append(options, libpod.WithPostStartCommand(s.PostStartCommand))
+	}
+	if len(s.PreStopCommand) > 0 {
+		options = append(options, libpod.WithPreStopCommand(s.PreStopCommand))
+	}
+
 	if len(s.Secrets) != 0 {
 		manager, err := rt.SecretsManager()
 		if err != nil {
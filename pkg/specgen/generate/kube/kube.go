@@ -3,6 +3,7 @@
 package kube
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -179,6 +180,8 @@ type CtrSpecGenOptions struct {
 	PodSecurityContext *v1.PodSecurityContext
 	// TerminationGracePeriodSeconds is the grace period given to a container to stop before being forcefully killed
 	TerminationGracePeriodSeconds *int64
+	// ActiveDeadlineSeconds is the maximum time a container may run before being killed
+	ActiveDeadlineSeconds *int64
 }
 
 func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGenerator, error) {
@@ -258,10 +261,17 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure livenessProbe: %w", err)
 	}
+	err = setupReadinessProbe(s, opts.Container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure readinessProbe: %w", err)
+	}
 	err = setupStartupProbe(s, opts.Container, opts.RestartPolicy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure startupProbe: %w", err)
 	}
+	if err := setupLifecycleHooks(s, opts.Container); err != nil {
+		return nil, fmt.Errorf("failed to configure lifecycle hooks: %w", err)
+	}
 
 	// Since we prefix the container name with pod name to work-around the uniqueness requirement,
 	// the seccomp profile should reference the actual container name from the YAML
@@ -606,6 +616,11 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 		s.StopTimeout = &timeout
 	}
 
+	// Set the timeout if activeDeadlineSeconds is set in the kube yaml
+	if opts.ActiveDeadlineSeconds != nil && *opts.ActiveDeadlineSeconds > 0 {
+		s.Timeout = uint(*opts.ActiveDeadlineSeconds)
+	}
+
 	return s, nil
 }
 
@@ -646,19 +661,32 @@ func parseMountPath(mountPath string, readOnly bool, propagationMode *v1.MountPr
 
 func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*manifest.Schema2HealthConfig, error) {
 	var commandString string
-	failureCmd := "exit 1"
-	probeHandler := probe.Handler
-	host := "localhost" // Kubernetes default is host IP, but with Podman currently we run inside the container
-
 	// configure healthcheck on the basis of Handler Actions.
-	switch {
-	case probeHandler.Exec != nil:
+	if probe.Handler.Exec != nil {
 		// `makeHealthCheck` function can accept a json array as the command.
-		cmd, err := json.Marshal(probeHandler.Exec.Command)
+		cmd, err := json.Marshal(probe.Handler.Exec.Command)
 		if err != nil {
 			return nil, err
 		}
 		commandString = string(cmd)
+	} else {
+		var err error
+		commandString, err = handlerToShellCommand(probe.Handler, containerPorts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+}
+
+// handlerToShellCommand converts the HTTPGet and TCPSocket actions of a
+// handler into a shell command.
+func handlerToShellCommand(probeHandler v1.Handler, containerPorts []v1.ContainerPort) (string, error) {
+	var commandString string
+	failureCmd := "exit 1"
+	host := "localhost" // Kubernetes default is host IP, but with Podman currently we run inside the container
+
+	switch {
 	case probeHandler.HTTPGet != nil:
 		// set defaults as in https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#http-probes
 		uriScheme := v1.URISchemeHTTP
@@ -674,20 +702,60 @@ func probeToHealthConfig(probe *v1.Probe, containerPorts []v1.ContainerPort) (*m
 		}
 		portNum, err := getPortNumber(probeHandler.HTTPGet.Port, containerPorts)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		commandString = fmt.Sprintf("curl -f %s://%s:%d%s || %s", uriScheme, host, portNum, path, failureCmd)
 	case probeHandler.TCPSocket != nil:
 		portNum, err := getPortNumber(probeHandler.TCPSocket.Port, containerPorts)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		if probeHandler.TCPSocket.Host != "" {
 			host = probeHandler.TCPSocket.Host
 		}
 		commandString = fmt.Sprintf("nc -z -v %s %d || %s", host, portNum, failureCmd)
 	}
-	return makeHealthCheck(commandString, probe.PeriodSeconds, probe.FailureThreshold, probe.TimeoutSeconds, probe.InitialDelaySeconds)
+	return commandString, nil
+}
+
+// lifecycleHandlerToCommand converts a lifecycle handler into a command that
+// can be executed inside the container.
+func lifecycleHandlerToCommand(handler *v1.Handler, containerPorts []v1.ContainerPort) ([]string, error) {
+	if handler.Exec != nil {
+		if len(handler.Exec.Command) == 0 {
+			return nil, errors.New("exec handler requires a command")
+		}
+		return handler.Exec.Command, nil
+	}
+	commandString, err := handlerToShellCommand(*handler, containerPorts)
+	if err != nil {
+		return nil, err
+	}
+	if commandString == "" {
+		return nil, errors.New("handler requires one of exec, httpGet or tcpSocket")
+	}
+	return []string{"/bin/sh", "-c", commandString}, nil
+}
+
+func setupLifecycleHooks(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.Lifecycle == nil {
+		return nil
+	}
+	if containerYAML.Lifecycle.PostStart != nil {
+		cmd, err := lifecycleHandlerToCommand(containerYAML.Lifecycle.PostStart, containerYAML.Ports)
+		if err != nil {
+			return fmt.Errorf("postStart: %w", err)
+		}
+		s.PostStartCommand = cmd
+	}
+	if containerYAML.Lifecycle.PreStop != nil {
+		cmd, err := lifecycleHandlerToCommand(containerYAML.Lifecycle.PreStop, containerYAML.Ports)
+		if err != nil {
+			return fmt.Errorf("preStop: %w", err)
+		}
+		s.PreStopCommand = cmd
+	}
+	return nil
 }
 
 func getPortNumber(port intstr.IntOrString, containerPorts []v1.ContainerPort) (int, error) {
@@ -724,6 +792,29 @@ func setupLivenessProbe(s *specgen.SpecGenerator, containerYAML v1.Container, re
 	return nil
 }
 
+// setupReadinessProbe uses the readiness probe as the container's healthcheck
+// unless a liveness probe is already set.  Contrary to the liveness probe, a
+// failing readiness probe never restarts the container.
+func setupReadinessProbe(s *specgen.SpecGenerator, containerYAML v1.Container) error {
+	if containerYAML.ReadinessProbe == nil {
+		return nil
+	}
+	emptyHandler := v1.Handler{}
+	if containerYAML.ReadinessProbe.Handler == emptyHandler {
+		return nil
+	}
+	if s.HealthConfig != nil {
+		logrus.Debugf("Container %s has a livenessProbe, using it to determine readiness", containerYAML.Name)
+		return nil
+	}
+	healthConfig, err := probeToHealthConfig(containerYAML.ReadinessProbe, containerYAML.Ports)
+	if err != nil {
+		return err
+	}
+	s.HealthConfig = healthConfig
+	return nil
+}
+
 func setupStartupProbe(s *specgen.SpecGenerator, containerYAML v1.Container, restartPolicy string) error {
 	if containerYAML.StartupProbe == nil {
 		return nil
@@ -897,31 +988,39 @@ func quantityToInt64(quantity *resource.Quantity) (int64, error) {
 	return 0, fmt.Errorf("quantity cannot be represented as int64: %v", quantity)
 }
 
-// read a k8s secret in JSON/YAML format from the secret manager
-// k8s secret is stored as YAML, we have to read data as JSON for backward compatibility
-func k8sSecretFromSecretManager(name string, secretsManager *secrets.SecretsManager) (map[string][]byte, error) {
+// kubeSecretFieldRegex matches the top-level fields of a k8s secret in YAML format.
+var kubeSecretFieldRegex = regexp.MustCompile(`(?m)^(apiVersion|kind|data|stringData)\s*:`)
+
+// K8sSecretFromSecretManager reads a k8s secret in JSON/YAML format from the secret manager.
+// k8s secret is stored as YAML, we have to read data as JSON for backward compatibility.
+// A podman secret that does not look like a JSON object or a k8s secret is
+// treated as a k8s secret with a single key, named after the secret, so that
+// secrets referenced by the YAML generated by podman kube generate can be used.
+func K8sSecretFromSecretManager(name string, secretsManager *secrets.SecretsManager) (map[string][]byte, error) {
 	_, inputSecret, err := secretsManager.LookupSecretData(name)
 	if err != nil {
 		return nil, err
 	}
 
 	var secrets map[string][]byte
-	if err := json.Unmarshal(inputSecret, &secrets); err != nil {
-		secrets = make(map[string][]byte)
-		var secret v1.Secret
-		if err := yaml.Unmarshal(inputSecret, &secret); err != nil {
-			return nil, fmt.Errorf("secret %v is not valid JSON/YAML: %v", name, err)
-		}
-
-		for key, val := range secret.Data {
-			secrets[key] = val
-		}
-
-		for key, val := range secret.StringData {
-			secrets[key] = []byte(val)
-		}
+	if err := json.Unmarshal(inputSecret, &secrets); err == nil {
+		return secrets, nil
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(inputSecret), []byte("{")) && !kubeSecretFieldRegex.Match(inputSecret) {
+		return map[string][]byte{name: inputSecret}, nil
 	}
 
+	var secret v1.Secret
+	if err := yaml.Unmarshal(inputSecret, &secret); err != nil {
+		return nil, fmt.Errorf("secret %v is not valid JSON/YAML: %w", name, err)
+	}
+	secrets = make(map[string][]byte)
+	for key, val := range secret.Data {
+		secrets[key] = val
+	}
+	for key, val := range secret.StringData {
+		secrets[key] = []byte(val)
+	}
 	return secrets, nil
 }
 
@@ -948,7 +1047,7 @@ func envVarsFrom(envFrom v1.EnvFromSource, opts *CtrSpecGenOptions) (map[string]
 
 	if envFrom.SecretRef != nil {
 		secRef := envFrom.SecretRef
-		secret, err := k8sSecretFromSecretManager(secRef.Name, opts.SecretsManager)
+		secret, err := K8sSecretFromSecretManager(secRef.Name, opts.SecretsManager)
 		if err == nil {
 			for k, v := range secret {
 				envs[k] = string(v)
@@ -986,7 +1085,7 @@ func envVarValue(env v1.EnvVar, opts *CtrSpecGenOptions) (*string, error) {
 
 		if env.ValueFrom.SecretKeyRef != nil {
 			secKeyRef := env.ValueFrom.SecretKeyRef
-			secret, err := k8sSecretFromSecretManager(secKeyRef.Name, opts.SecretsManager)
+			secret, err := K8sSecretFromSecretManager(secKeyRef.Name, opts.SecretsManager)
 			if err == nil {
 				if val, ok := secret[secKeyRef.Key]; ok {
 					value := string(val)
@@ -40,6 +40,14 @@ func createSecrets(t *testing.T, d string) *secrets.SecretsManager {
 		assert.NoError(t, err)
 	}
 
+	// a podman secret that does not hold a kube secret
+	_, err = secretsManager.Store("plain", []byte("hunter2"), driver, storeOpts)
+	assert.NoError(t, err)
+
+	// a kube secret that cannot be parsed
+	_, err = secretsManager.Store("malformed", []byte("kind: Secret\ndata: [\n"), driver, storeOpts)
+	assert.NoError(t, err)
+
 	return secretsManager
 }
 
@@ -613,6 +621,63 @@ func TestEnvVarValue(t *testing.T) {
 			true,
 			"foo",
 		},
+		{
+			"PlainSecretExists",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "plain",
+						},
+						Key: "plain",
+					},
+				},
+			},
+			CtrSpecGenOptions{
+				SecretsManager: secretsManager,
+			},
+			true,
+			"hunter2",
+		},
+		{
+			"ContainerKeyDoesNotExistInPlainSecret",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "plain",
+						},
+						Key: "myvar",
+					},
+				},
+			},
+			CtrSpecGenOptions{
+				SecretsManager: secretsManager,
+			},
+			false,
+			nilString,
+		},
+		{
+			"MalformedSecret",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "malformed",
+						},
+						Key: "myvar",
+					},
+				},
+			},
+			CtrSpecGenOptions{
+				SecretsManager: secretsManager,
+			},
+			false,
+			nilString,
+		},
 		{
 			"ContainerKeyDoesNotExistInSecret",
 			v1.EnvVar{
@@ -1385,3 +1450,137 @@ func TestTCPLivenessProbe(t *testing.T) {
 		})
 	}
 }
+
+func TestReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name            string
+		specGenerator   specgen.SpecGenerator
+		container       v1.Container
+		expectedCommand string
+	}{
+		{
+			"ReadinessProbeSetsHealthcheck",
+			specgen.SpecGenerator{},
+			v1.Container{
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/ready"},
+						},
+					},
+				},
+			},
+			"/ready",
+		},
+		{
+			"LivenessProbeTakesPrecedence",
+			specgen.SpecGenerator{},
+			v1.Container{
+				LivenessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/alive"},
+						},
+					},
+				},
+				ReadinessProbe: &v1.Probe{
+					Handler: v1.Handler{
+						Exec: &v1.ExecAction{
+							Command: []string{"cat", "/ready"},
+						},
+					},
+				},
+			},
+			"/alive",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := setupLivenessProbe(&test.specGenerator, test.container, "always")
+			assert.NoError(t, err)
+			err = setupReadinessProbe(&test.specGenerator, test.container)
+			assert.NoError(t, err)
+			assert.Contains(t, test.specGenerator.ContainerHealthCheckConfig.HealthConfig.Test, test.expectedCommand)
+		})
+	}
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	tests := []struct {
+		name              string
+		container         v1.Container
+		succeed           bool
+		expectedPostStart []string
+		expectedPreStop   []string
+	}{
+		{
+			"ExecHooks",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PostStart: &v1.Handler{
+						Exec: &v1.ExecAction{Command: []string{"touch", "/started"}},
+					},
+					PreStop: &v1.Handler{
+						Exec: &v1.ExecAction{Command: []string{"touch", "/stopping"}},
+					},
+				},
+			},
+			true,
+			[]string{"touch", "/started"},
+			[]string{"touch", "/stopping"},
+		},
+		{
+			"HTTPGetHook",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PreStop: &v1.Handler{
+						HTTPGet: &v1.HTTPGetAction{
+							Path: "/shutdown",
+							Port: intstr.FromInt(8080),
+						},
+					},
+				},
+			},
+			true,
+			nil,
+			[]string{"/bin/sh", "-c", "curl -f http://localhost:8080/shutdown || exit 1"},
+		},
+		{
+			"EmptyHandler",
+			v1.Container{
+				Lifecycle: &v1.Lifecycle{
+					PostStart: &v1.Handler{},
+				},
+			},
+			false,
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			s := specgen.SpecGenerator{}
+			err := setupLifecycleHooks(&s, test.container)
+			assert.Equal(t, err == nil, test.succeed)
+			if err == nil {
+				assert.Equal(t, test.expectedPostStart, s.PostStartCommand)
+				assert.Equal(t, test.expectedPreStop, s.PreStopCommand)
+			}
+		})
+	}
+}
+
+func TestK8sSecretFromSecretManagerMalformed(t *testing.T) {
+	secretsManager := createSecrets(t, t.TempDir())
+
+	_, err := K8sSecretFromSecretManager("malformed", secretsManager)
+	assert.ErrorContains(t, err, "secret malformed is not valid JSON/YAML")
+
+	secret, err := K8sSecretFromSecretManager("plain", secretsManager)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"plain": []byte("hunter2")}, secret)
+}
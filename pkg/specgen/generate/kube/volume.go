@@ -13,7 +13,6 @@ import (
 	v1 "github.com/containers/podman/v4/pkg/k8s.io/api/core/v1"
 
 	"github.com/sirupsen/logrus"
-	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -151,8 +150,7 @@ func VolumeFromSecret(secretSource *v1.SecretVolumeSource, secretsManager *secre
 		kv.DefaultMode = *secretSource.DefaultMode
 	}
 
-	// returns a byte array of a kube secret data, meaning this needs to go into a string map
-	_, secretByte, err := secretsManager.LookupSecretData(secretSource.SecretName)
+	secret, err := K8sSecretFromSecretManager(secretSource.SecretName, secretsManager)
 	if err != nil {
 		if errors.Is(err, secrets.ErrNoSuchSecret) && secretSource.Optional != nil && *secretSource.Optional {
 			kv.Optional = true
@@ -161,31 +159,18 @@ func VolumeFromSecret(secretSource *v1.SecretVolumeSource, secretsManager *secre
 		return nil, err
 	}
 
-	secret := &v1.Secret{}
-
-	err = yaml.Unmarshal(secretByte, secret)
-	if err != nil {
-		return nil, err
-	}
-
 	// If there are Items specified in the volumeSource, that overwrites the Data from the Secret
 	if len(secretSource.Items) > 0 {
 		for _, item := range secretSource.Items {
-			if val, ok := secret.Data[item.Key]; ok {
+			if val, ok := secret[item.Key]; ok {
 				kv.Items[item.Path] = val
-			} else if val, ok := secret.StringData[item.Key]; ok {
-				kv.Items[item.Path] = []byte(val)
 			}
 		}
 	} else {
 		// add key: value pairs to the items array
-		for key, entry := range secret.Data {
+		for key, entry := range secret {
 			kv.Items[key] = entry
 		}
-
-		for key, entry := range secret.StringData {
-			kv.Items[key] = []byte(entry)
-		}
 	}
 
 	return kv, nil
@@ -20,7 +20,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func MakePod(p *entities.PodSpec, rt *libpod.Runtime) (_ *libpod.Pod, finalErr error) {
+func MakePod(ctx context.Context, p *entities.PodSpec, rt *libpod.Runtime) (_ *libpod.Pod, finalErr error) {
 	var createdPod *libpod.Pod
 	defer func() {
 		if finalErr != nil && createdPod != nil {
@@ -65,7 +65,7 @@ func MakePod(p *entities.PodSpec, rt *libpod.Runtime) (_ *libpod.Pod, finalErr e
 		return nil, err
 	}
 
-	pod, err := rt.NewPod(context.Background(), p.PodSpecGen, options...)
+	pod, err := rt.NewPod(ctx, p.PodSpecGen, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +75,7 @@ func MakePod(p *entities.PodSpec, rt *libpod.Runtime) (_ *libpod.Pod, finalErr e
 		if p.PodSpecGen.InfraContainerSpec.Name == "" {
 			p.PodSpecGen.InfraContainerSpec.Name = pod.ID()[:12] + "-infra"
 		}
-		_, err = CompleteSpec(context.Background(), rt, p.PodSpecGen.InfraContainerSpec)
+		_, err = CompleteSpec(ctx, rt, p.PodSpecGen.InfraContainerSpec)
 		if err != nil {
 			return nil, err
 		}
@@ -85,7 +85,7 @@ func MakePod(p *entities.PodSpec, rt *libpod.Runtime) (_ *libpod.Pod, finalErr e
 		// make sure of that here.
 		p.PodSpecGen.InfraContainerSpec.ResourceLimits = nil
 		p.PodSpecGen.InfraContainerSpec.WeightDevice = nil
-		rtSpec, spec, opts, err := MakeContainer(context.Background(), rt, p.PodSpecGen.InfraContainerSpec, false, nil)
+		rtSpec, spec, opts, err := MakeContainer(ctx, rt, p.PodSpecGen.InfraContainerSpec, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -93,17 +93,17 @@ func MakePod(p *entities.PodSpec, rt *libpod.Runtime) (_ *libpod.Pod, finalErr e
 		spec.Pod = pod.ID()
 		opts = append(opts, rt.WithPod(pod))
 		spec.CgroupParent = pod.CgroupParent()
-		infraCtr, err := ExecuteCreate(context.Background(), rt, rtSpec, spec, true, opts...)
+		infraCtr, err := ExecuteCreate(ctx, rt, rtSpec, spec, true, opts...)
 		if err != nil {
 			return nil, err
 		}
-		pod, err = rt.AddInfra(context.Background(), pod, infraCtr)
+		pod, err = rt.AddInfra(ctx, pod, infraCtr)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		// SavePod is used to save the pod state and trigger a create event even if infra is not created
-		err := rt.SavePod(pod)
+		err := rt.SavePod(ctx, pod)
 		if err != nil {
 			return nil, err
 		}
@@ -157,6 +157,10 @@ func createPodOptions(p *specgen.PodSpecGenerator) ([]libpod.PodCreateOption, er
 		options = append(options, libpod.WithPodResources(*p.ResourceLimits))
 	}
 
+	if p.LogQuota > 0 {
+		options = append(options, libpod.WithPodLogQuota(p.LogQuota))
+	}
+
 	options = append(options, libpod.WithPodExitPolicy(p.ExitPolicy))
 	options = append(options, libpod.WithPodRestartPolicy(p.RestartPolicy))
 	if p.RestartRetries != nil {
@@ -22,6 +22,11 @@ type PodBasicConfig struct {
 	Hostname string `json:"hostname,omitempty"`
 	// ExitPolicy determines the pod's exit and stop behaviour.
 	ExitPolicy string `json:"exit_policy,omitempty"`
+	// LogQuota is the maximum size in bytes of the log files of all
+	// containers in the pod on disk, shared by them. Further output is
+	// dropped.
+	// Optional.
+	LogQuota int64 `json:"log_quota,omitempty"`
 	// Labels are key-value pairs that are used to add metadata to pods.
 	// Optional.
 	Labels map[string]string `json:"labels,omitempty"`
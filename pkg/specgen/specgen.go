@@ -26,6 +26,21 @@ type LogConfig struct {
 	// Size is the maximum size of the log file
 	// Optional.
 	Size int64 `json:"size,omitempty"`
+	// RateLimit is the number of bytes per second the container may log,
+	// further output is dropped.
+	// Optional.
+	RateLimit int64 `json:"rate_limit,omitempty"`
+	// Burst is the number of bytes the container may log at once when
+	// RateLimit is set. Defaults to RateLimit.
+	// Optional.
+	Burst int64 `json:"burst,omitempty"`
+	// MaxFiles is the number of rotated log files kept when the log file
+	// reaches Size. If 0, the log file is truncated.
+	// Optional.
+	MaxFiles uint `json:"max_files,omitempty"`
+	// Compress compresses rotated log files with gzip.
+	// Optional.
+	Compress bool `json:"compress,omitempty"`
 	// A set of options to accompany the log driver.
 	// Optional.
 	Options map[string]string `json:"options,omitempty"`
@@ -225,6 +240,14 @@ type ContainerBasicConfig struct {
 	PasswdEntry string `json:"passwd_entry,omitempty"`
 	// GroupEntry specifies arbitrary data to append to a file.
 	GroupEntry string `json:"group_entry,omitempty"`
+	// PostStartCommand is executed in the container right after it has
+	// been started. If it fails, the container is stopped.
+	// Optional.
+	PostStartCommand []string `json:"post_start_command,omitempty"`
+	// PreStopCommand is executed in the container right before it is
+	// being stopped.
+	// Optional.
+	PreStopCommand []string `json:"pre_stop_command,omitempty"`
 }
 
 // ContainerStorageConfig contains information on the storage configuration of a
@@ -567,6 +590,15 @@ type ContainerResourceConfig struct {
 type ContainerHealthCheckConfig struct {
 	HealthConfig               *manifest.Schema2HealthConfig     `json:"healthconfig,omitempty"`
 	HealthCheckOnFailureAction define.HealthCheckOnFailureAction `json:"health_check_on_failure_action,omitempty"`
+	// HealthCheckOnFailureCommand is run once the container turns
+	// unhealthy, before the on-failure action is taken.
+	// Requires that HealthCheckOnFailureAction be set.
+	// Optional.
+	HealthCheckOnFailureCommand []string `json:"health_check_on_failure_command,omitempty"`
+	// HealthCheckOnFailureCommandInContainer runs the on-failure command
+	// inside the container instead of on the host.
+	// Optional.
+	HealthCheckOnFailureCommandInContainer bool `json:"health_check_on_failure_command_in_container,omitempty"`
 	// Startup healthcheck for a container.
 	// Requires that HealthConfig be set.
 	// Optional.
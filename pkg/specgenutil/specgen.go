@@ -350,7 +350,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		if c.NoHealthCheck {
 			return errors.New("cannot specify both --no-healthcheck and --health-cmd")
 		}
-		s.HealthConfig, err = makeHealthCheckFromCli(c.HealthCmd, c.HealthInterval, c.HealthRetries, c.HealthTimeout, c.HealthStartPeriod, false)
+		s.HealthConfig, err = MakeHealthCheckFromCli(c.HealthCmd, c.HealthInterval, c.HealthRetries, c.HealthTimeout, c.HealthStartPeriod, false)
 		if err != nil {
 			return err
 		}
@@ -365,6 +365,10 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		return err
 	}
 	s.HealthCheckOnFailureAction = onFailureAction
+	if c.HealthOnFailureCmd != "" {
+		s.HealthCheckOnFailureCommand = []string{"/bin/sh", "-c", c.HealthOnFailureCmd}
+		s.HealthCheckOnFailureCommandInContainer = c.HealthOnFailureCtr
+	}
 
 	if c.StartupHCCmd != "" {
 		if c.NoHealthCheck {
@@ -373,7 +377,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		// The hardcoded "1s" will be discarded, as the startup
 		// healthcheck does not have a period. So just hardcode
 		// something that parses correctly.
-		tmpHcConfig, err := makeHealthCheckFromCli(c.StartupHCCmd, c.StartupHCInterval, c.StartupHCRetries, c.StartupHCTimeout, "1s", true)
+		tmpHcConfig, err := MakeHealthCheckFromCli(c.StartupHCCmd, c.StartupHCInterval, c.StartupHCRetries, c.StartupHCTimeout, "1s", true)
 		if err != nil {
 			return err
 		}
@@ -828,6 +832,30 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 				return err
 			}
 			s.LogConfiguration.Size = logSize
+		case "rate":
+			rate, err := units.FromHumanSize(split[1])
+			if err != nil {
+				return err
+			}
+			s.LogConfiguration.RateLimit = rate
+		case "burst":
+			burst, err := units.FromHumanSize(split[1])
+			if err != nil {
+				return err
+			}
+			s.LogConfiguration.Burst = burst
+		case "max-file":
+			maxFiles, err := strconv.ParseUint(split[1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid max-file log option %q: %w", split[1], err)
+			}
+			s.LogConfiguration.MaxFiles = uint(maxFiles)
+		case "compress":
+			compress, err := strconv.ParseBool(split[1])
+			if err != nil {
+				return fmt.Errorf("invalid compress log option %q: %w", split[1], err)
+			}
+			s.LogConfiguration.Compress = compress
 		default:
 			logOpts[split[0]] = split[1]
 		}
@@ -929,7 +957,9 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	return nil
 }
 
-func makeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, startPeriod string, isStartup bool) (*manifest.Schema2HealthConfig, error) {
+// MakeHealthCheckFromCli creates a healthcheck config from the values of the
+// --health-* command line options.
+func MakeHealthCheckFromCli(inCmd, interval string, retries uint, timeout, startPeriod string, isStartup bool) (*manifest.Schema2HealthConfig, error) {
 	cmdArr := []string{}
 	isArr := true
 	err := json.Unmarshal([]byte(inCmd), &cmdArr) // array unmarshalling
@@ -7,9 +7,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/common/pkg/machine"
 	"github.com/containers/podman/v4/pkg/domain/entities"
 	"github.com/containers/podman/v4/pkg/specgen"
+	storageTypes "github.com/containers/storage/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -216,3 +218,22 @@ func TestGenRlimits(t *testing.T) {
 	_, err = GenRlimits([]string{"nofile=bar:buzz"})
 	assert.Error(t, err, "err is not nil")
 }
+
+func TestCreateExitCommandArgs(t *testing.T) {
+	storageConfig := storageTypes.StoreOptions{GraphRoot: "/root", RunRoot: "/runroot", GraphDriverName: "overlay"}
+	conf := &config.Config{}
+	conf.Engine.DBBackend = "sqlite"
+	conf.Engine.EventsLogger = "webhook"
+
+	podmanArgs, err := CreatePodmanArgs(storageConfig, conf, "unix:///run/events.sock", false)
+	assert.NoError(t, err)
+	args := strings.Join(podmanArgs, " ")
+	for _, opt := range []string{"--root /root", "--runroot /runroot", "--storage-driver overlay", "--db-backend sqlite", "--events-backend webhook", "--events-webhook-url unix:///run/events.sock"} {
+		assert.Contains(t, args, opt)
+	}
+	assert.NotContains(t, podmanArgs, "--syslog")
+
+	exitArgs, err := CreateExitCommandArgs(storageConfig, conf, "unix:///run/events.sock", true, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, append(podmanArgs, "--syslog", "container", "cleanup", "--rm", "--exec"), exitArgs)
+}
@@ -261,13 +261,36 @@ func parseAndValidatePort(port string) (uint16, error) {
 	return uint16(num), nil
 }
 
-func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog, rm, exec bool) ([]string, error) {
+func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, eventsWebhookURL string, syslog, rm, exec bool) ([]string, error) {
 	// We need a cleanup process for containers in the current model.
 	// But we can't assume that the caller is Podman - it could be another
 	// user of the API.
 	// As such, provide a way to specify a path to Podman, so we can
 	// still invoke a cleanup process.
+	command, err := CreatePodmanArgs(storageConfig, config, eventsWebhookURL, syslog)
+	if err != nil {
+		return nil, err
+	}
+
+	command = append(command, []string{"container", "cleanup"}...)
+
+	if rm {
+		command = append(command, "--rm")
+	}
+
+	// This has to be absolutely last, to ensure that the exec session ID
+	// will be added after it by Libpod.
+	if exec {
+		command = append(command, "--exec")
+	}
 
+	return command, nil
+}
+
+// CreatePodmanArgs returns the path to Podman followed by the global options
+// making a Podman process started later, e.g. by conmon or a systemd timer,
+// use the same storage and configuration as the current one.
+func CreatePodmanArgs(storageConfig storageTypes.StoreOptions, config *config.Config, eventsWebhookURL string, syslog bool) ([]string, error) {
 	podmanPath, err := os.Executable()
 	if err != nil {
 		return nil, err
@@ -300,6 +323,9 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 	if config.Engine.EventsLogger != "" {
 		command = append(command, []string{"--events-backend", config.Engine.EventsLogger}...)
 	}
+	if eventsWebhookURL != "" {
+		command = append(command, []string{"--events-webhook-url", eventsWebhookURL}...)
+	}
 
 	if syslog {
 		command = append(command, "--syslog")
@@ -311,17 +337,5 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 		command = append(command, "--module", module)
 	}
 
-	command = append(command, []string{"container", "cleanup"}...)
-
-	if rm {
-		command = append(command, "--rm")
-	}
-
-	// This has to be absolutely last, to ensure that the exec session ID
-	// will be added after it by Libpod.
-	if exec {
-		command = append(command, "--exec")
-	}
-
 	return command, nil
 }
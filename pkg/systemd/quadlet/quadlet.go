@@ -12,6 +12,7 @@ import (
 	"github.com/containers/podman/v4/pkg/specgenutilexternal"
 	"github.com/containers/podman/v4/pkg/systemd/parser"
 	"github.com/containers/storage/pkg/regexp"
+	"github.com/docker/go-units"
 )
 
 const (
@@ -25,6 +26,7 @@ const (
 	UnitDirDistro = "/usr/share/containers/systemd"
 
 	// Names of commonly used systemd/quadlet group names
+	BuildGroup      = "Build"
 	ContainerGroup  = "Container"
 	InstallGroup    = "Install"
 	KubeGroup       = "Kube"
@@ -34,6 +36,7 @@ const (
 	UnitGroup       = "Unit"
 	VolumeGroup     = "Volume"
 	ImageGroup      = "Image"
+	XBuildGroup     = "X-Build"
 	XContainerGroup = "X-Container"
 	XKubeGroup      = "X-Kube"
 	XNetworkGroup   = "X-Network"
@@ -51,12 +54,19 @@ const (
 const (
 	KeyAddCapability         = "AddCapability"
 	KeyAddDevice             = "AddDevice"
+	KeyAddHost               = "AddHost"
 	KeyAllTags               = "AllTags"
 	KeyAnnotation            = "Annotation"
 	KeyArch                  = "Arch"
 	KeyAuthFile              = "AuthFile"
 	KeyAutoUpdate            = "AutoUpdate"
+	KeyBlkioWeight           = "BlkioWeight"
+	KeyBuildArg              = "BuildArg"
 	KeyCertDir               = "CertDir"
+	KeyCPUs                  = "CPUs"
+	KeyCPUSetCPUs            = "CPUSetCPUs"
+	KeyCPUSetMems            = "CPUSetMems"
+	KeyCPUShares             = "CPUShares"
 	KeyCreds                 = "Creds"
 	KeyDecryptionKey         = "DecryptionKey"
 	KeyConfigMap             = "ConfigMap"
@@ -75,7 +85,10 @@ const (
 	KeyEntrypoint            = "Entrypoint"
 	KeyExec                  = "Exec"
 	KeyExitCodePropagation   = "ExitCodePropagation"
+	KeyExitPolicy            = "ExitPolicy"
 	KeyExposeHostPort        = "ExposeHostPort"
+	KeyFile                  = "File"
+	KeyForceRM               = "ForceRM"
 	KeyGIDMap                = "GIDMap"
 	KeyGlobalArgs            = "GlobalArgs"
 	KeyGroup                 = "Group"
@@ -95,10 +108,13 @@ const (
 	KeyIP6                   = "IP6"
 	KeyImage                 = "Image"
 	KeyImageTag              = "ImageTag"
+	KeyInfraImage            = "InfraImage"
 	KeyKubeDownForce         = "KubeDownForce"
 	KeyLabel                 = "Label"
 	KeyLogDriver             = "LogDriver"
 	KeyMask                  = "Mask"
+	KeyMemory                = "Memory"
+	KeyMemorySwap            = "MemorySwap"
 	KeyMount                 = "Mount"
 	KeyNetwork               = "Network"
 	KeyNetworkDisableDNS     = "DisableDNS"
@@ -142,6 +158,7 @@ const (
 	KeySubGIDMap             = "SubGIDMap"
 	KeySubUIDMap             = "SubUIDMap"
 	KeySysctl                = "Sysctl"
+	KeyTarget                = "Target"
 	KeyTimezone              = "Timezone"
 	KeyTLSVerify             = "TLSVerify"
 	KeyTmpfs                 = "Tmpfs"
@@ -166,6 +183,9 @@ type PodInfo struct {
 
 var (
 	validPortRange = regexp.Delayed(`\d+(-\d+)?(/udp|/tcp)?$`)
+	validCPUs      = regexp.Delayed(`^(\d+(\.\d*)?|\.\d+)$`)
+	validCPUSet    = regexp.Delayed(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+	validUint      = regexp.Delayed(`^\d+$`)
 
 	// Supported keys in "Container" group
 	supportedContainerKeys = map[string]bool{
@@ -322,13 +342,63 @@ var (
 		KeyVariant:              true,
 	}
 
+	// Supported keys in "Build" group
+	supportedBuildKeys = map[string]bool{
+		KeyAnnotation:           true,
+		KeyArch:                 true,
+		KeyAuthFile:             true,
+		KeyBuildArg:             true,
+		KeyContainersConfModule: true,
+		KeyDNS:                  true,
+		KeyDNSOption:            true,
+		KeyDNSSearch:            true,
+		KeyEnvironment:          true,
+		KeyFile:                 true,
+		KeyForceRM:              true,
+		KeyGlobalArgs:           true,
+		KeyImageTag:             true,
+		KeyLabel:                true,
+		KeyNetwork:              true,
+		KeyPodmanArgs:           true,
+		KeyPull:                 true,
+		KeySecret:               true,
+		KeySetWorkingDirectory:  true,
+		KeyTarget:               true,
+		KeyTLSVerify:            true,
+		KeyVariant:              true,
+		KeyVolume:               true,
+	}
+
+	// Supported keys in "Pod" group
 	supportedPodKeys = map[string]bool{
+		KeyAddHost:              true,
+		KeyBlkioWeight:          true,
 		KeyContainersConfModule: true,
+		KeyCPUs:                 true,
+		KeyCPUSetCPUs:           true,
+		KeyCPUSetMems:           true,
+		KeyCPUShares:            true,
+		KeyDNS:                  true,
+		KeyDNSOption:            true,
+		KeyDNSSearch:            true,
+		KeyExitPolicy:           true,
+		KeyGIDMap:               true,
 		KeyGlobalArgs:           true,
+		KeyHostName:             true,
+		KeyInfraImage:           true,
+		KeyIP:                   true,
+		KeyIP6:                  true,
+		KeyMemory:               true,
+		KeyMemorySwap:           true,
 		KeyNetwork:              true,
 		KeyPodName:              true,
 		KeyPodmanArgs:           true,
 		KeyPublishPort:          true,
+		KeyShmSize:              true,
+		KeySubGIDMap:            true,
+		KeySubUIDMap:            true,
+		KeyUIDMap:               true,
+		KeyUserNS:               true,
 		KeyVolume:               true,
 	}
 )
@@ -601,20 +671,7 @@ func ConvertContainer(container *parser.UnitFile, names map[string]string, isUse
 		podman.add("--security-opt", fmt.Sprintf("seccomp=%s", seccompProfile))
 	}
 
-	dns := container.LookupAll(ContainerGroup, KeyDNS)
-	for _, ipAddr := range dns {
-		podman.addf("--dns=%s", ipAddr)
-	}
-
-	dnsOptions := container.LookupAll(ContainerGroup, KeyDNSOption)
-	for _, dnsOption := range dnsOptions {
-		podman.addf("--dns-option=%s", dnsOption)
-	}
-
-	dnsSearches := container.LookupAll(ContainerGroup, KeyDNSSearch)
-	for _, dnsSearch := range dnsSearches {
-		podman.addf("--dns-search=%s", dnsSearch)
-	}
+	handleDNS(container, ContainerGroup, podman)
 
 	dropCaps := container.LookupAllStrv(ContainerGroup, KeyDropCapability)
 
@@ -1158,8 +1215,7 @@ func ConvertKube(kube *parser.UnitFile, names map[string]string, isUser bool) (*
 	execStop.add(yamlPath)
 	service.AddCmdline(ServiceGroup, "ExecStopPost", execStop.Args)
 
-	err = handleSetWorkingDirectory(kube, service)
-	if err != nil {
+	if _, err := handleSetWorkingDirectory(kube, service, KubeGroup); err != nil {
 		return nil, err
 	}
 
@@ -1236,11 +1292,143 @@ func ConvertImage(image *parser.UnitFile) (*parser.UnitFile, string, error) {
 	return service, imageName, nil
 }
 
+// Convert a quadlet build file (unit file with a Build group) to a systemd
+// service file (unit file with Service group) based on the options in the
+// Build group.
+// The original Build group is kept around as X-Build.
+// Also returns the canonical image name, taken from the first ImageTag key.
+func ConvertBuild(build *parser.UnitFile, names map[string]string) (*parser.UnitFile, string, error) {
+	service := build.Dup()
+	service.Filename = replaceExtension(build.Filename, ".service", "", "-build")
+
+	if build.Path != "" {
+		service.Add(UnitGroup, "SourcePath", build.Path)
+	}
+
+	if err := checkForUnknownKeys(build, BuildGroup, supportedBuildKeys); err != nil {
+		return nil, "", err
+	}
+
+	imageTags := build.LookupAll(BuildGroup, KeyImageTag)
+	if len(imageTags) == 0 || len(imageTags[0]) == 0 {
+		return nil, "", fmt.Errorf("no ImageTag key specified")
+	}
+
+	/* Rename old Build group to X-Build so that systemd ignores it */
+	service.RenameGroup(BuildGroup, XBuildGroup)
+
+	// Need the containers filesystem mounted to start podman
+	service.Add(UnitGroup, "RequiresMountsFor", "%t/containers")
+
+	podman := createBasePodmanCommand(build, BuildGroup)
+
+	podman.add("build")
+
+	stringKeys := map[string]string{
+		KeyArch:     "--arch",
+		KeyAuthFile: "--authfile",
+		KeyPull:     "--pull",
+		KeyTarget:   "--target",
+		KeyVariant:  "--variant",
+	}
+
+	boolKeys := map[string]string{
+		KeyForceRM:   "--force-rm",
+		KeyTLSVerify: "--tls-verify",
+	}
+
+	for key, flag := range stringKeys {
+		lookupAndAddString(build, BuildGroup, key, flag, podman)
+	}
+
+	for key, flag := range boolKeys {
+		lookupAndAddBoolean(build, BuildGroup, key, flag, podman)
+	}
+
+	for _, imageTag := range imageTags {
+		podman.addf("--tag=%s", imageTag)
+	}
+
+	handleDNS(build, BuildGroup, podman)
+
+	buildArgs := build.LookupAllKeyVal(BuildGroup, KeyBuildArg)
+	if len(buildArgs) > 0 {
+		podman.addKeys("--build-arg", buildArgs)
+	}
+
+	podmanEnv := build.LookupAllKeyVal(BuildGroup, KeyEnvironment)
+	podman.addEnv(podmanEnv)
+
+	labels := build.LookupAllKeyVal(BuildGroup, KeyLabel)
+	podman.addLabels(labels)
+
+	annotations := build.LookupAllKeyVal(BuildGroup, KeyAnnotation)
+	podman.addAnnotations(annotations)
+
+	addNetworks(build, BuildGroup, service, names, podman)
+
+	secrets := build.LookupAllArgs(BuildGroup, KeySecret)
+	for _, secret := range secrets {
+		podman.add("--secret", secret)
+	}
+
+	if err := addVolumes(build, service, BuildGroup, names, podman); err != nil {
+		return nil, "", err
+	}
+
+	// Building needs either a Containerfile given via the File key, or a
+	// context directory given via SetWorkingDirectory or WorkingDirectory.
+	context, err := handleSetWorkingDirectory(build, service, BuildGroup)
+	if err != nil {
+		return nil, "", err
+	}
+
+	workingDirectory, _ := service.Lookup(ServiceGroup, ServiceKeyWorkingDirectory)
+	filePath, _ := build.Lookup(BuildGroup, KeyFile)
+	if len(workingDirectory) == 0 && len(filePath) == 0 && len(context) == 0 {
+		return nil, "", fmt.Errorf("neither SetWorkingDirectory, nor File key specified")
+	}
+
+	if len(filePath) > 0 {
+		// The working directory is the one containing the File, so the
+		// path relative to the unit file would no longer resolve
+		if setWorkingDirectory, _ := build.Lookup(BuildGroup, KeySetWorkingDirectory); strings.EqualFold(setWorkingDirectory, "file") {
+			if filePath, err = getAbsolutePath(build, filePath); err != nil {
+				return nil, "", err
+			}
+		}
+		podman.addf("--file=%s", filePath)
+	}
+
+	handlePodmanArgs(build, BuildGroup, podman)
+
+	// The build context must be the last argument
+	switch {
+	case len(context) > 0:
+		podman.add(context)
+	case len(workingDirectory) > 0:
+		podman.add(workingDirectory)
+	case !filepath.IsAbs(filePath) && !isURL(filePath):
+		return nil, "", fmt.Errorf("relative path in File key requires SetWorkingDirectory key to be set")
+	}
+
+	service.AddCmdline(ServiceGroup, "ExecStart", podman.Args)
+
+	service.Setv(ServiceGroup,
+		"Type", "oneshot",
+		"RemainAfterExit", "yes",
+
+		// The default syslog identifier is the exec basename (podman) which isn't very useful here
+		"SyslogIdentifier", "%N")
+
+	return service, imageTags[0], nil
+}
+
 func GetPodServiceName(podUnit *parser.UnitFile) string {
 	return replaceExtension(podUnit.Filename, "", "", "-pod")
 }
 
-func ConvertPod(podUnit *parser.UnitFile, name string, podsInfoMap map[string]*PodInfo, names map[string]string) (*parser.UnitFile, error) {
+func ConvertPod(podUnit *parser.UnitFile, name string, podsInfoMap map[string]*PodInfo, names map[string]string, isUser bool) (*parser.UnitFile, error) {
 	podInfo, ok := podsInfoMap[podUnit.Filename]
 	if !ok {
 		return nil, fmt.Errorf("internal error while processing pod %s", podUnit.Filename)
@@ -1300,21 +1488,51 @@ func ConvertPod(podUnit *parser.UnitFile, name string, podsInfoMap map[string]*P
 	)
 	service.AddCmdline(ServiceGroup, "ExecStopPost", execStopPost.Args)
 
+	exitPolicy, ok := podUnit.Lookup(PodGroup, KeyExitPolicy)
+	if !ok || len(exitPolicy) == 0 {
+		exitPolicy = "stop"
+	}
+	if exitPolicy != "stop" && exitPolicy != "continue" {
+		return nil, fmt.Errorf("invalid ExitPolicy '%s'", exitPolicy)
+	}
+
 	execStartPre := createBasePodmanCommand(podUnit, PodGroup)
 	execStartPre.add("pod", "create")
 	execStartPre.add(
 		"--infra-conmon-pidfile=%t/%N.pid",
 		"--pod-id-file=%t/%N.pod-id",
-		"--exit-policy=stop",
+		fmt.Sprintf("--exit-policy=%s", exitPolicy),
 		"--replace",
 	)
 
+	if infraImage, ok := podUnit.Lookup(PodGroup, KeyInfraImage); ok && len(infraImage) > 0 {
+		execStartPre.addf("--infra-image=%s", infraImage)
+	}
+
 	if err := handlePublishPorts(podUnit, PodGroup, execStartPre); err != nil {
 		return nil, err
 	}
 
 	addNetworks(podUnit, PodGroup, service, names, execStartPre)
 
+	handleNetworkOptions(podUnit, PodGroup, execStartPre)
+
+	if hostname, ok := podUnit.Lookup(PodGroup, KeyHostName); ok && len(hostname) > 0 {
+		execStartPre.add("--hostname", hostname)
+	}
+
+	if err := handleUserMappings(podUnit, PodGroup, execStartPre, isUser, true); err != nil {
+		return nil, err
+	}
+
+	if shmSize, ok := podUnit.Lookup(PodGroup, KeyShmSize); ok && len(shmSize) > 0 {
+		execStartPre.addf("--shm-size=%s", shmSize)
+	}
+
+	if err := handleResources(podUnit, PodGroup, execStartPre); err != nil {
+		return nil, err
+	}
+
 	if err := addVolumes(podUnit, service, PodGroup, names, execStartPre); err != nil {
 		return nil, err
 	}
@@ -1335,6 +1553,81 @@ func ConvertPod(podUnit *parser.UnitFile, name string, podsInfoMap map[string]*P
 	return service, nil
 }
 
+func handleDNS(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) {
+	dns := unitFile.LookupAll(groupName, KeyDNS)
+	for _, ipAddr := range dns {
+		podman.addf("--dns=%s", ipAddr)
+	}
+
+	dnsOptions := unitFile.LookupAll(groupName, KeyDNSOption)
+	for _, dnsOption := range dnsOptions {
+		podman.addf("--dns-option=%s", dnsOption)
+	}
+
+	dnsSearches := unitFile.LookupAll(groupName, KeyDNSSearch)
+	for _, dnsSearch := range dnsSearches {
+		podman.addf("--dns-search=%s", dnsSearch)
+	}
+}
+
+func handleNetworkOptions(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) {
+	handleDNS(unitFile, groupName, podman)
+
+	addHosts := unitFile.LookupAllStrv(groupName, KeyAddHost)
+	for _, addHost := range addHosts {
+		podman.addf("--add-host=%s", addHost)
+	}
+
+	ip, ok := unitFile.Lookup(groupName, KeyIP)
+	if ok && len(ip) > 0 {
+		podman.add("--ip", ip)
+	}
+
+	ip6, ok := unitFile.Lookup(groupName, KeyIP6)
+	if ok && len(ip6) > 0 {
+		podman.add("--ip6", ip6)
+	}
+}
+
+func handleResources(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) error {
+	keyArgMap := []struct {
+		key   string
+		flag  string
+		valid func(string) bool
+	}{
+		{KeyCPUs, "--cpus", validCPUs.MatchString},
+		{KeyCPUSetCPUs, "--cpuset-cpus", validCPUSet.MatchString},
+		{KeyCPUSetMems, "--cpuset-mems", validCPUSet.MatchString},
+		{KeyCPUShares, "--cpu-shares", validUint.MatchString},
+		{KeyMemory, "--memory", validMemory},
+		{KeyMemorySwap, "--memory-swap", validMemory},
+		{KeyBlkioWeight, "--blkio-weight", validUint.MatchString},
+	}
+
+	for _, keyArg := range keyArgMap {
+		val, found := unitFile.Lookup(groupName, keyArg.key)
+		if !found || len(val) == 0 {
+			continue
+		}
+		if !keyArg.valid(val) {
+			return fmt.Errorf("invalid %s '%s'", keyArg.key, val)
+		}
+		podman.addf("%s=%s", keyArg.flag, val)
+	}
+
+	return nil
+}
+
+// validMemory returns whether the memory size is accepted by podman, e.g., by
+// --memory.  -1 stands for unlimited.
+func validMemory(size string) bool {
+	if size == "-1" {
+		return true
+	}
+	_, err := units.RAMInBytes(size)
+	return err == nil
+}
+
 func handleUser(unitFile *parser.UnitFile, groupName string, podman *PodmanCmdline) error {
 	user, hasUser := unitFile.Lookup(groupName, KeyUser)
 	okUser := hasUser && len(user) > 0
@@ -1658,39 +1951,74 @@ func handlePodmanArgs(unitFile *parser.UnitFile, groupName string, podman *Podma
 	}
 }
 
-func handleSetWorkingDirectory(kube, serviceUnitFile *parser.UnitFile) error {
+// handleSetWorkingDirectory sets the WorkingDirectory of the service based on
+// the SetWorkingDirectory key. For .build files the key may also point to an
+// absolute path or URL, in which case it is returned as the build context.
+func handleSetWorkingDirectory(quadletUnitFile, serviceUnitFile *parser.UnitFile, groupName string) (string, error) {
 	// If WorkingDirectory is already set in the Service section do not change it
-	workingDir, ok := kube.Lookup(ServiceGroup, ServiceKeyWorkingDirectory)
+	workingDir, ok := quadletUnitFile.Lookup(ServiceGroup, ServiceKeyWorkingDirectory)
 	if ok && len(workingDir) > 0 {
-		return nil
+		return "", nil
 	}
 
-	setWorkingDirectory, ok := kube.Lookup(KubeGroup, KeySetWorkingDirectory)
+	setWorkingDirectory, ok := quadletUnitFile.Lookup(groupName, KeySetWorkingDirectory)
 	if !ok || len(setWorkingDirectory) == 0 {
-		return nil
+		return "", nil
 	}
 
 	var relativeToFile string
 	switch strings.ToLower(setWorkingDirectory) {
 	case "yaml":
-		relativeToFile, ok = kube.Lookup(KubeGroup, KeyYaml)
+		if groupName != KubeGroup {
+			return "", fmt.Errorf("SetWorkingDirectory=%s is only supported in .kube files", setWorkingDirectory)
+		}
+		relativeToFile, ok = quadletUnitFile.Lookup(groupName, KeyYaml)
+		if !ok {
+			return "", fmt.Errorf("no Yaml key specified")
+		}
+	case "file":
+		if groupName != BuildGroup {
+			return "", fmt.Errorf("SetWorkingDirectory=%s is only supported in .build files", setWorkingDirectory)
+		}
+		relativeToFile, ok = quadletUnitFile.Lookup(groupName, KeyFile)
 		if !ok {
-			return fmt.Errorf("no Yaml key specified")
+			return "", fmt.Errorf("no File key specified")
+		}
+		if isURL(relativeToFile) {
+			return "", fmt.Errorf("SetWorkingDirectory=%s can not be used with a remote File", setWorkingDirectory)
 		}
 	case "unit":
-		relativeToFile = kube.Path
+		relativeToFile = quadletUnitFile.Path
 	default:
-		return fmt.Errorf("unsupported value for %s: %s ", ServiceKeyWorkingDirectory, setWorkingDirectory)
+		// A .build file may point directly at the build context
+		if groupName == BuildGroup {
+			if isURL(setWorkingDirectory) || filepath.IsAbs(setWorkingDirectory) {
+				return setWorkingDirectory, nil
+			}
+			return "", fmt.Errorf("SetWorkingDirectory=%s is not an absolute path or URL", setWorkingDirectory)
+		}
+		return "", fmt.Errorf("unsupported value for %s: %s ", ServiceKeyWorkingDirectory, setWorkingDirectory)
 	}
 
-	fileInWorkingDir, err := getAbsolutePath(kube, relativeToFile)
+	fileInWorkingDir, err := getAbsolutePath(quadletUnitFile, relativeToFile)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	serviceUnitFile.Add(ServiceGroup, ServiceKeyWorkingDirectory, filepath.Dir(fileInWorkingDir))
 
-	return nil
+	return "", nil
+}
+
+// isURL reports whether the given build source is remote and must be passed
+// to podman build untouched.
+func isURL(source string) bool {
+	for _, prefix := range []string{"http://", "https://", "git://", "github.com/"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func lookupAndAddString(unit *parser.UnitFile, group, key, flag string, podman *PodmanCmdline) {
@@ -1721,6 +2049,20 @@ func handleImageSource(quadletImageName string, serviceUnitFile *parser.UnitFile
 		serviceUnitFile.Add(UnitGroup, "Requires", imageServiceName)
 		serviceUnitFile.Add(UnitGroup, "After", imageServiceName)
 
+		quadletImageName = imageName
+	} else if strings.HasSuffix(quadletImageName, ".build") {
+		// since there is no default name conversion, the actual image name must exist in the names map
+		imageName, ok := names[quadletImageName]
+		if !ok {
+			return "", fmt.Errorf("requested Quadlet image %s was not found", quadletImageName)
+		}
+
+		// the systemd unit name is $name-build.service
+		buildServiceName := replaceExtension(quadletImageName, ".service", "", "-build")
+
+		serviceUnitFile.Add(UnitGroup, "Requires", buildServiceName)
+		serviceUnitFile.Add(UnitGroup, "After", buildServiceName)
+
 		quadletImageName = imageName
 	}
 
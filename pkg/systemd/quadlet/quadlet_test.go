@@ -43,3 +43,12 @@ func TestQuadlet_SplitPorts(t *testing.T) {
 	assert.Equal(t, parts[0], "foo")
 	assert.Equal(t, parts[1], "abc[foo::barxyz:bar")
 }
+
+func TestQuadlet_ValidMemory(t *testing.T) {
+	for _, size := range []string{"-1", "512", "512m", "512MB", "512 MB", "1GiB", "1.5g", "2t"} {
+		assert.True(t, validMemory(size), size)
+	}
+	for _, size := range []string{"", "-2", "512 apples", "1.5.g", "m"} {
+		assert.False(t, validMemory(size), size)
+	}
+}
@@ -1403,6 +1403,104 @@ USER test1`
 		Expect(pod.Spec.Volumes[0].Secret).To(BeNil())
 	})
 
+	It("with secrets and --configmaps", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("hunter2"), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, name := range []string{"db-pass", "api-key"} {
+			session := podmanTest.Podman([]string{"secret", "create", name, secretFilePath})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+		}
+
+		ctrName := "secret-ctr"
+		session := podmanTest.Podman([]string{"create", "--name", ctrName, "--secret", "db-pass", "--secret", "api-key,type=env,target=API_KEY",
+			"-e", "MODE=prod", CITEST_IMAGE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--configmaps", ctrName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+		Expect(kube.OutputToString()).ToNot(ContainSubstring("kind: Secret"))
+
+		arr := strings.Split(string(kube.Out.Contents()), "---")
+		Expect(arr).To(HaveLen(2))
+
+		configMap := new(v1.ConfigMap)
+		err = yaml.Unmarshal([]byte(arr[0]), configMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap.Name).To(Equal(ctrName + "-env"))
+		Expect(configMap.Data).To(HaveKeyWithValue("MODE", "prod"))
+
+		pod := new(v1.Pod)
+		err = yaml.Unmarshal([]byte(arr[1]), pod)
+		Expect(err).ToNot(HaveOccurred())
+		ctr := pod.Spec.Containers[0]
+		Expect(ctr.EnvFrom).To(HaveLen(1))
+		Expect(ctr.EnvFrom[0].ConfigMapRef.Name).To(Equal(ctrName + "-env"))
+		Expect(ctr.Env).To(HaveLen(1))
+		Expect(ctr.Env[0].Name).To(Equal("API_KEY"))
+		Expect(ctr.Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("api-key"))
+		Expect(ctr.Env[0].ValueFrom.SecretKeyRef.Key).To(Equal("api-key"))
+		Expect(ctr.VolumeMounts).To(ContainElement(v1.VolumeMount{Name: "db-pass-secret", MountPath: "/run/secrets/db-pass", SubPath: "db-pass", ReadOnly: true}))
+		Expect(pod.Spec.Volumes).To(HaveLen(1))
+		Expect(pod.Spec.Volumes[0].Secret.SecretName).To(Equal("db-pass"))
+
+		// The referenced secrets are used when playing the YAML.
+		outputFile := filepath.Join(podmanTest.TempDir, "secret.yaml")
+		err = os.WriteFile(outputFile, kube.Out.Contents(), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		rm := podmanTest.Podman([]string{"rm", ctrName})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm).Should(ExitCleanly())
+
+		play := podmanTest.Podman([]string{"kube", "play", outputFile})
+		play.WaitWithDefaultTimeout()
+		Expect(play).Should(ExitCleanly())
+
+		exec := podmanTest.Podman([]string{"exec", ctrName + "-pod-" + ctrName, "sh", "-c", "echo $MODE $API_KEY; cat /run/secrets/db-pass"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec).Should(ExitCleanly())
+		Expect(exec.OutputToStringArray()).To(Equal([]string{"prod hunter2", "hunter2"}))
+	})
+
+	It("with --secret-data", func() {
+		secretFilePath := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFilePath, []byte("hunter2"), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"secret", "create", "db-pass", secretFilePath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		ctrName := "secret-data-ctr"
+		session = podmanTest.Podman([]string{"create", "--name", ctrName, "--secret", "db-pass,target=/etc/db-pass", CITEST_IMAGE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--secret-data", ctrName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		arr := strings.Split(string(kube.Out.Contents()), "---")
+		Expect(arr).To(HaveLen(2))
+
+		secret := new(v1.Secret)
+		err = yaml.Unmarshal([]byte(arr[0]), secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Kind).To(Equal("Secret"))
+		Expect(secret.Name).To(Equal("db-pass"))
+		Expect(secret.Data).To(HaveKeyWithValue("db-pass", []byte("hunter2")))
+
+		pod := new(v1.Pod)
+		err = yaml.Unmarshal([]byte(arr[1]), pod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(HaveField("MountPath", "/etc/db-pass")))
+	})
+
 	It("with default ulimits", func() {
 		ctrName := "ulimit-ctr"
 		session := podmanTest.Podman([]string{"run", "-d", "--name", ctrName, CITEST_IMAGE, "sleep", "1000"})
@@ -384,6 +384,23 @@ spec:
           initialDelaySeconds: 5
           periodSeconds: 5
 `
+var jobYaml = `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: testjob
+spec:
+  completions: 2
+  backoffLimit: 2
+  template:
+    spec:
+      containers:
+      - command:
+        - top
+        name: testimage
+        image: ` + CITEST_IMAGE + `
+`
+
 var livenessProbeUnhealthyPodYaml = `
 apiVersion: apps/v1
 kind: Deployment
@@ -2308,6 +2325,52 @@ var _ = Describe("Podman kube play", func() {
 		Expect(healthcheckcmd).To(ContainSubstring("[CMD echo hello]"))
 	})
 
+	It("job creates a pod per completion", func() {
+		err := writeYaml(jobYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		for _, ctrName := range []string{"testjob-pod-0-testimage", "testjob-pod-1-testimage"} {
+			inspect := podmanTest.Podman([]string{"inspect", ctrName, "--format", "{{ .HostConfig.RestartPolicy.Name }} {{ .HostConfig.RestartPolicy.MaximumRetryCount }} {{ .Config.Timeout }}"})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect).Should(ExitCleanly())
+			Expect(inspect.OutputToString()).To(Equal("on-failure 2 0"))
+		}
+
+		down := podmanTest.Podman([]string{"kube", "down", kubeYaml})
+		down.WaitWithDefaultTimeout()
+		Expect(down).Should(ExitCleanly())
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "testjob-pod-0"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).Should(Exit(1))
+	})
+
+	It("job activeDeadlineSeconds requires containers not to be restarted", func() {
+		err := writeYaml(strings.Replace(jobYaml, "backoffLimit: 2", "backoffLimit: 2\n  activeDeadlineSeconds: 30", 1), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("activeDeadlineSeconds cannot be enforced across restarts"))
+
+		err = writeYaml(strings.Replace(jobYaml, "backoffLimit: 2", "backoffLimit: 0\n  activeDeadlineSeconds: 30", 1), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube = podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "testjob-pod-0-testimage", "--format", "{{ .HostConfig.RestartPolicy.Name }} {{ .Config.Timeout }}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("no 30"))
+	})
+
 	It("liveness probe should fail", func() {
 		err := writeYaml(livenessProbeUnhealthyPodYaml, kubeYaml)
 		Expect(err).ToNot(HaveOccurred())
@@ -2323,6 +2386,48 @@ var _ = Describe("Podman kube play", func() {
 		Expect(hcoutput).To(ContainSubstring(define.HealthCheckUnhealthy))
 	})
 
+	It("--wait-ready waits for healthy containers", func() {
+		err := writeYaml(livenessProbePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait-ready", "--wait-ready-timeout", "60", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+		Expect(kube.OutputToString()).To(ContainSubstring("Readiness:"))
+		Expect(kube.OutputToString()).To(MatchRegexp(`liveness-probe-pod-testimage\s+running\s+0\s+healthy\s+true`))
+
+		inspect := podmanTest.InspectContainer("liveness-probe-pod-testimage")
+		Expect(inspect[0].State.Health).To(HaveField("Status", define.HealthCheckHealthy))
+	})
+
+	It("--wait-ready fails on unhealthy containers", func() {
+		err := writeYaml(livenessProbeUnhealthyPodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait-ready", "--wait-ready-timeout", "60", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.OutputToString()).To(MatchRegexp(`liveness-unhealthy-probe-pod-testimage\s+running\s+0\s+unhealthy\s+false`))
+		Expect(kube.ErrorToString()).To(ContainSubstring("Probe of container liveness-unhealthy-probe-pod-testimage failed: cat: can't open '/randomfile'"))
+		Expect(kube.ErrorToString()).To(ContainSubstring("not all containers became ready"))
+	})
+
+	It("--wait-ready reports containers that are not ready after the timeout", func() {
+		err := writeYaml(startupProbePodYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--wait-ready", "--wait-ready-timeout", "2", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.OutputToString()).To(MatchRegexp(`startup-healthy-probe-pod-testimage\s+running\s+0\s+starting\s+false`))
+		Expect(kube.ErrorToString()).To(ContainSubstring("not all containers became ready"))
+
+		kube = podmanTest.Podman([]string{"kube", "play", "--wait-ready", "--start=false", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("--wait-ready cannot be used with --start=false"))
+	})
+
 	It("support container startup probe", func() {
 		ctrName := "startup-healthy-probe-pod-testimage"
 		err := writeYaml(startupProbePodYaml, kubeYaml)
@@ -41,6 +41,8 @@ func loadQuadletTestcase(path string) *quadletTestcase {
 		service += "-image"
 	case ".pod":
 		service += "-pod"
+	case ".build":
+		service += "-build"
 	}
 	service += ".service"
 
@@ -720,13 +722,21 @@ BOGUS=foo
 	})
 
 	DescribeTable("Running quadlet test case",
-		func(fileName string, exitCode int, errString string) {
+		func(fileName string, exitCode int, errString string, dependencyFiles ...string) {
 			testcase := loadQuadletTestcase(filepath.Join("quadlet", fileName))
 
 			// Write the tested file to the quadlet dir
 			err = os.WriteFile(filepath.Join(quadletDir, fileName), testcase.data, 0644)
 			Expect(err).ToNot(HaveOccurred())
 
+			// Write the Quadlet files the tested file depends on
+			for _, dependencyFile := range dependencyFiles {
+				data, err := os.ReadFile(filepath.Join("quadlet", dependencyFile))
+				Expect(err).ToNot(HaveOccurred())
+				err = os.WriteFile(filepath.Join(quadletDir, dependencyFile), data, 0644)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
 			// Also copy any extra snippets
 			dotdDir := filepath.Join("quadlet", fileName+".d")
 			if s, err := os.Stat(dotdDir); err == nil && s.IsDir() {
@@ -755,6 +765,8 @@ BOGUS=foo
 		Entry("annotation.container", "annotation.container", 0, ""),
 		Entry("autoupdate.container", "autoupdate.container", 0, ""),
 		Entry("basepodman.container", "basepodman.container", 0, ""),
+		Entry("build.quadlet.container", "build.quadlet.container", 0, "", "basic.build"),
+		Entry("build.not-found.container", "build.not-found.container", 1, "converting \"build.not-found.container\": requested Quadlet image not-found.build was not found"),
 		Entry("capabilities.container", "capabilities.container", 0, ""),
 		Entry("capabilities2.container", "capabilities2.container", 0, ""),
 		Entry("devices.container", "devices.container", 0, ""),
@@ -904,11 +916,30 @@ BOGUS=foo
 		Entry("Image - global args", "globalargs.image", 0, ""),
 		Entry("Image - Containers Conf Modules", "containersconfmodule.image", 0, ""),
 
+		Entry("Build - Basic", "basic.build", 0, ""),
+		Entry("Build - Absolute File", "abs-file.build", 0, ""),
+		Entry("Build - Options", "options.build", 0, ""),
+		Entry("Build - No ImageTag", "no-imagetag.build", 1, "converting \"no-imagetag.build\": no ImageTag key specified"),
+		Entry("Build - No Context", "no-context.build", 1, "converting \"no-context.build\": neither SetWorkingDirectory, nor File key specified"),
+		Entry("Build - Relative File without Context", "relative-file.build", 1, "converting \"relative-file.build\": relative path in File key requires SetWorkingDirectory key to be set"),
+		Entry("Build - SetWorkingDirectory File", "setworkingdirectory-file.build", 0, ""),
+		Entry("Build - SetWorkingDirectory URL", "setworkingdirectory-url.build", 0, ""),
+
 		Entry("basic.pod", "basic.pod", 0, ""),
+		Entry("dns.pod", "dns.pod", 0, ""),
+		Entry("exit-policy-invalid.pod", "exit-policy-invalid.pod", 1, "converting \"exit-policy-invalid.pod\": invalid ExitPolicy 'restart'"),
+		Entry("infra-image.pod", "infra-image.pod", 0, ""),
+		Entry("ip.pod", "ip.pod", 0, ""),
 		Entry("name.pod", "name.pod", 0, ""),
 		Entry("network.pod", "network.pod", 0, ""),
 		Entry("network-quadlet.pod", "network.quadlet.pod", 0, ""),
 		Entry("podmanargs.pod", "podmanargs.pod", 0, ""),
+		Entry("resources.pod", "resources.pod", 0, ""),
+		Entry("resources-units.pod", "resources-units.pod", 0, ""),
+		Entry("resources-invalid.pod", "resources-invalid.pod", 1, "converting \"resources-invalid.pod\": invalid Memory '512 apples'"),
+		Entry("subidmapping.pod", "subidmapping.pod", 0, ""),
+		Entry("userns.pod", "userns.pod", 0, ""),
+		Entry("userns-with-remap.pod", "userns-with-remap.pod", 1, "converting \"userns-with-remap.pod\": unsupported key 'RemapUsers' in group 'Pod'"),
 		Entry("volume.pod", "volume.pod", 0, ""),
 	)
 
@@ -1,6 +1,8 @@
 package integration
 
 import (
+	"time"
+
 	"github.com/containers/common/pkg/cgroupv2"
 	. "github.com/containers/podman/v4/test/utils"
 	. "github.com/onsi/ginkgo/v2"
@@ -218,4 +220,66 @@ var _ = Describe("Podman update", func() {
 		Expect(session).Should(ExitCleanly())
 		Expect(session.OutputToString()).Should(ContainSubstring("500000"))
 	})
+
+	It("podman update restart policy, healthcheck, env and labels", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "upd", "--env", "FOO=1", "--env", "BAR=2", "--label", "stage=dev", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{
+			"update",
+			"--restart", "on-failure:3",
+			"--health-cmd", "true",
+			"--health-interval", "15s",
+			"--env", "FOO=changed",
+			"--unsetenv", "BAR",
+			"--label", "team=core",
+			"--unset-label", "stage",
+			"upd",
+		})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		inspect := podmanTest.InspectContainer("upd")
+		Expect(inspect[0].HostConfig.RestartPolicy.Name).To(Equal("on-failure"))
+		Expect(inspect[0].HostConfig.RestartPolicy.MaximumRetryCount).To(Equal(uint(3)))
+		Expect(inspect[0].Config.Healthcheck).ToNot(BeNil())
+		Expect(inspect[0].Config.Healthcheck.Test).To(Equal([]string{"CMD-SHELL", "true"}))
+		Expect(inspect[0].Config.Healthcheck.Interval).To(Equal(15 * time.Second))
+		Expect(inspect[0].Config.Env).To(ContainElement("FOO=changed"))
+		Expect(inspect[0].Config.Env).ToNot(ContainElement("BAR=2"))
+		Expect(inspect[0].Config.Labels).To(HaveKeyWithValue("team", "core"))
+		Expect(inspect[0].Config.Labels).ToNot(HaveKey("stage"))
+
+		session = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=update", "--format", "{{.Attributes.updated}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("restartPolicy"))
+
+		// changing only the command keeps the other settings of the healthcheck
+		session = podmanTest.Podman([]string{"update", "--health-retries", "7", "--health-timeout", "9s", "upd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"update", "--health-cmd", "false", "upd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		inspect = podmanTest.InspectContainer("upd")
+		Expect(inspect[0].Config.Healthcheck.Test).To(Equal([]string{"CMD-SHELL", "false"}))
+		Expect(inspect[0].Config.Healthcheck.Interval).To(Equal(15 * time.Second))
+		Expect(inspect[0].Config.Healthcheck.Retries).To(Equal(7))
+		Expect(inspect[0].Config.Healthcheck.Timeout).To(Equal(9 * time.Second))
+
+		// modifying the healthcheck without a command requires an existing healthcheck
+		session = podmanTest.Podman([]string{"update", "--health-cmd", "none", "upd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"update", "--health-retries", "5", "upd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("has no healthcheck"))
+
+		session = podmanTest.Podman([]string{"update", "--restart", "always:3", "upd"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125))
+	})
 })
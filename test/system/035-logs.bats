@@ -436,4 +436,93 @@ EOF
     run_podman rm $cname
 }
 
+@test "podman pod logs - --merge and --prefix" {
+    skip_if_remote "--merge and --prefix are not available remotely"
+
+    podname="p-$(random_string 10)"
+    run_podman pod create --name $podname
+    # c1 logs before and after c2
+    run_podman run -d --pod $podname --name c1_$podname --log-driver k8s-file $IMAGE sh -c "echo a;sleep 3;echo c"
+    run_podman run --pod $podname --name c2_$podname --log-driver k8s-file $IMAGE sh -c "sleep 1;echo b"
+    run_podman wait c1_$podname
+
+    run_podman pod logs --merge --prefix '{{.Pod}}/{{.Name}}: ' $podname
+    assert "$output" = "$podname/c1_$podname: a
+$podname/c2_$podname: b
+$podname/c1_$podname: c" "pod logs ordered by timestamp with prefix"
+
+    run_podman 125 logs --prefix '{{.ID' c1_$podname
+    is "$output" ".*parsing log prefix.*"
+
+    run_podman pod rm -f -t0 $podname
+}
+
+@test "podman logs - rate limit and pod quota" {
+    skip_if_remote "log-throttled events are not available remotely"
+
+    cname="c-$(random_string 10)"
+    run_podman run --name $cname --log-driver k8s-file \
+               --log-opt rate=1kb --log-opt burst=2kb \
+               $IMAGE sh -c 'for i in $(seq 1 200); do echo line-$i-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx; done'
+    run_podman container inspect --format '{{.HostConfig.LogConfig.RateLimit}} {{.HostConfig.LogConfig.Burst}}' $cname
+    is "$output" "1kB 2kB" "rate limit shown in inspect"
+
+    run_podman logs $cname
+    assert "${lines[0]}" = "line-1-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx" "first line is logged"
+    assert "${#lines[*]}" -lt 200 "lines above the burst are dropped"
+
+    run_podman events --filter container=$cname --filter event=log-throttled \
+               --stream=false --format '{{.Status}} {{.Attributes.reason}}'
+    is "$output" "log-throttled rate" "log-throttled event"
+    run_podman rm $cname
+
+    run_podman 125 run --rm --log-driver none --log-opt rate=1kb $IMAGE true
+    is "$output" ".*cannot set a log rate limit with the none log driver.*"
+
+    podname="p-$(random_string 10)"
+    run_podman pod create --log-quota 1kb --name $podname
+    run_podman run --pod $podname --name $cname --log-driver k8s-file \
+               $IMAGE sh -c 'for i in $(seq 1 100); do echo line-$i; done'
+    run_podman pod inspect --format '{{.LogQuota}}' $podname
+    is "$output" "1000" "pod inspect shows the log quota"
+    run_podman container inspect --format '{{.HostConfig.LogConfig.Quota}}' $cname
+    is "$output" "1kB" "container inspect shows the quota of the pod"
+
+    run_podman logs $cname
+    assert "${lines[0]}" = "line-1" "first line is logged"
+    assert "${#lines[*]}" -lt 100 "lines above the quota are dropped"
+
+    run_podman events --filter container=$cname --filter event=log-throttled \
+               --stream=false --format '{{.Attributes.reason}}'
+    is "$output" "quota" "log-throttled event for the quota"
+
+    run_podman pod rm -f -t0 $podname
+}
+
+@test "podman logs - rotated log files" {
+    skip_if_remote "log files are not accessible remotely"
+
+    cname="c-$(random_string 10)"
+    run_podman run --name $cname --log-driver k8s-file \
+               --log-opt max-size=1kb --log-opt max-file=3 --log-opt compress=true \
+               $IMAGE sh -c 'for i in $(seq 1 40); do echo line-$i-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx; done'
+
+    run_podman container inspect --format '{{.HostConfig.LogConfig.Path}} {{.HostConfig.LogConfig.MaxFiles}} {{.HostConfig.LogConfig.Compress}}' $cname
+    logpath=${output%% *}
+    is "${output#* }" "3 true" "inspect shows the log rotation"
+    test -e "$logpath.1.gz" || die "$logpath.1.gz does not exist"
+    test ! -e "$logpath.4.gz" || die "$logpath.4.gz should have been removed"
+
+    # The newest lines are kept across the rotated files.
+    run_podman logs $cname
+    assert "${lines[-1]}" = "line-40-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx" "last line"
+    assert "${#lines[*]}" -gt 20 "lines are read from the rotated files"
+
+    run_podman logs --tail 20 $cname
+    assert "${#lines[*]}" = 20 "--tail reads across rotated files"
+    assert "${lines[0]}" = "line-21-xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx" "first line of --tail 20"
+
+    run_podman rm $cname
+}
+
 # vim: filetype=sh
@@ -400,3 +400,74 @@ EOF
     run_podman events --since=1m --stream=false --filter volume=${vname:0:5}
     assert "$output" = "$notrunc_results"
 }
+
+function _events_after_cursor() {
+    local backend=$1
+    local cname=c$(random_string 15)
+
+    t0=$(date --iso-8601=seconds)
+    run_podman $backend run --name=$cname --rm $IMAGE true
+    run_podman $backend events --since=$t0 --stream=false \
+               --filter container=$cname --format '{{.Cursor}} {{.Status}}'
+    assert "${lines[0]}" =~ "^.+ create$" "first event has a cursor"
+    local cursor=${lines[0]% *}
+    local -a expect=("${lines[@]:1}")
+
+    # --after-cursor takes precedence over --since
+    run_podman $backend events --after-cursor "$cursor" --since=1s --stream=false \
+               --filter container=$cname --format '{{.Cursor}} {{.Status}}'
+    assert "${#lines[@]}" = "${#expect[@]}" "events after the cursor"
+    assert "${lines[0]}" = "${expect[0]}" "first event after the cursor"
+
+    run_podman $backend events --stream=false --after-cursor "$cursor" --format json \
+               --filter container=$cname
+    assert "${lines[0]}" =~ "\"cursor\":" "JSON output includes the cursor"
+}
+
+@test "events - after cursor - file" {
+    skip_if_remote "remote does not support --events-backend"
+    _events_after_cursor --events-backend=file
+
+    run_podman 125 --events-backend=file events --stream=false --after-cursor nope
+    is "$output" ".*invalid events cursor \"nope\".*"
+}
+
+@test "events - after cursor - journald" {
+    skip_if_remote "remote does not support --events-backend"
+    skip_if_journald_unavailable "system does not support journald events"
+    _events_after_cursor --events-backend=journald
+}
+
+@test "events - caller and image attributes" {
+    local vname=v$(random_string 10)
+    local sname=s$(random_string 10)
+    local tag=t$(random_string 10 | tr A-Z a-z)
+
+    t0=$(date --iso-8601=seconds)
+    run_podman volume create $vname
+    run_podman volume rm $vname
+    run_podman secret create $sname - <<< "secret"
+    run_podman secret rm $sname
+    run_podman tag $IMAGE $tag
+    run_podman untag $IMAGE $tag
+
+    local uid=$(id -u)
+    run_podman events --since=$t0 --stream=false --filter caller=$uid --filter type=volume \
+               --format '{{.Status}} {{.Name}} {{.Attributes.driver}} {{.Attributes.callerUID}}'
+    assert "$output" = "create $vname local $uid
+remove $vname local $uid" "volume events record the driver and the caller"
+
+    run_podman events --since=$t0 --stream=false --filter secret=$sname \
+               --format '{{.Type}} {{.Status}} {{.Name}} {{.Attributes.driver}} {{.Attributes.callerUID}}'
+    assert "$output" = "secret create $sname file $uid
+secret remove $sname file $uid" "secret events"
+
+    run_podman image inspect --format '{{.Digest}}' $IMAGE
+    local digest=$output
+    run_podman events --since=$t0 --stream=false --filter type=image --filter event=tag \
+               --filter digest=$digest --format '{{.Attributes.callerUID}}'
+    assert "$output" = "$uid" "image events can be filtered by digest"
+
+    run_podman events --since=$t0 --stream=false --filter type=image --filter digest=sha256:0000
+    assert "$output" = "" "no events with another digest"
+}
@@ -222,6 +222,129 @@ Log[-1].Output   | \"Uh-oh on stdout!\\\nUh-oh on stderr!\"
     done
 }
 
+@test "podman healthcheck --health-on-failure-cmd" {
+    run_podman 125 create --health-cmd /bin/false --health-on-failure=command $IMAGE
+    is "$output" "Error: on-failure action command requires an on-failure command: invalid argument"
+
+    ctr="healthcheck_c"
+
+    for where in container host;do
+        in_container=
+        outfile=$PODMAN_TMPDIR/on-failure
+        if [[ $where == "container" ]];then
+            in_container=--health-on-failure-cmd-in-container
+            outfile=/tmp/on-failure
+        fi
+        run_podman run -d --name $ctr          \
+               --health-cmd "echo broken; false" \
+               --health-retries=2              \
+               --health-on-failure=command     \
+               --health-on-failure-cmd "echo \$PODMAN_CONTAINER_NAME \$PODMAN_HEALTH_FAILING_STREAK \$PODMAN_HEALTH_OUTPUT > $outfile; echo ran-$where" \
+               $in_container                   \
+               --health-interval=disable       \
+               $IMAGE top
+
+        run_podman inspect $ctr --format "{{.Config.HealthcheckOnFailureAction}} {{.Config.HealthcheckOnFailureCommandInContainer}}"
+        is "$output" "command $([[ $where == container ]] && echo true || echo false)" "on-failure command config ($where)"
+
+        run_podman 1 healthcheck run $ctr
+        run_podman 1 healthcheck run $ctr
+        is "$output" "unhealthy" "output from 'podman healthcheck run' ($where)"
+
+        if [[ $where == "container" ]];then
+            run_podman exec $ctr cat $outfile
+        else
+            run cat $outfile
+        fi
+        is "$output" "$ctr 2 broken" "on-failure command environment ($where)"
+
+        run_podman inspect $ctr --format "{{len .State.Health.OnFailureLog}} {{(index .State.Health.OnFailureLog 0).ExitCode}} {{(index .State.Health.OnFailureLog 0).Output}}"
+        is "$output" "1 0 ran-$where" "on-failure command is logged ($where)"
+
+        # The command runs once per transition to unhealthy
+        run_podman 1 healthcheck run $ctr
+        run_podman inspect $ctr --format "{{.State.Status}} {{len .State.Health.OnFailureLog}}"
+        is "$output" "running 1" "container keeps running and command ran only once ($where)"
+
+        run_podman rm -f -t0 $ctr
+    done
+}
+
+@test "podman healthcheck --health-on-failure-cmd-in-container timeout" {
+    ctr="healthcheck_c"
+
+    run_podman run -d --name $ctr                      \
+               --health-cmd /bin/false                 \
+               --health-retries=1                      \
+               --health-timeout=2s                     \
+               --health-on-failure=command             \
+               --health-on-failure-cmd "sleep 60"      \
+               --health-on-failure-cmd-in-container    \
+               --health-interval=disable               \
+               $IMAGE top
+
+    # The on-failure command is killed after the health check timeout
+    local start=$SECONDS
+    run_podman 1 healthcheck run $ctr
+    assert $((SECONDS - start)) -lt 30 "healthcheck run returns after the timeout of the on-failure command"
+
+    run_podman inspect $ctr --format "{{(index .State.Health.OnFailureLog 0).ExitCode}} {{(index .State.Health.OnFailureLog 0).Output}}"
+    assert "$output" =~ "^125 .*timed out after 2s" "on-failure command timed out"
+
+    run_podman exec $ctr ps -o args
+    assert "$output" !~ "sleep 60" "on-failure command is not running anymore"
+
+    run_podman rm -f -t0 $ctr
+}
+
+@test "podman healthcheck inspect and logs" {
+    ctr="healthcheck_c"
+
+    run_podman 125 healthcheck logs --status bogus $IMAGE
+    is "$output" "Error: invalid healthcheck status \"bogus\": supported statuses are healthy,unhealthy,starting: invalid argument"
+
+    run_podman run -d --name $ctr           \
+               --health-cmd "cat /ok"       \
+               --health-retries=1           \
+               --health-interval=disable    \
+               $IMAGE top
+
+    run_podman 1 healthcheck run $ctr
+    run_podman exec $ctr touch /ok
+    run_podman healthcheck run $ctr
+    run_podman healthcheck run $ctr
+
+    run_podman healthcheck inspect --format "{{.Name}} {{.Status}} {{.FailingStreak}} {{len .History}}" $ctr
+    is "$output" "$ctr healthy 0 3" "healthcheck inspect"
+
+    run_podman healthcheck logs --format "{{.ExitCode}} {{.Status}}" $ctr
+    assert "${lines[*]}" == "1 unhealthy 0 healthy 0 healthy" "healthcheck logs"
+
+    run_podman healthcheck logs --status unhealthy --format json $ctr
+    assert "${#lines[@]}" == 1 "one probe left the container unhealthy"
+    assert "$(jq -r .Output <<<"$output")" =~ "/ok" "output of failed probe"
+
+    run_podman healthcheck logs --since 1h --until 1s --format "{{.ExitCode}}" $ctr
+    is "${#lines[@]}" 3 "all probes within time range"
+    run_podman healthcheck logs --until 2000-01-01 --format "{{.ExitCode}}" $ctr
+    is "$output" "" "no probes before the given time"
+
+    # The history is kept across restarts, unlike the healthcheck log
+    run_podman restart -t0 $ctr
+    run_podman healthcheck run $ctr
+    run_podman inspect $ctr --format "{{len .State.Health.Log}}"
+    is "$output" "1" "healthcheck log is reset on restart"
+    run_podman healthcheck inspect --format "{{len .History}}" $ctr
+    is "$output" "4" "healthcheck history is kept across restarts"
+
+    # Follow stops once the container is no longer running
+    run_podman stop -t0 $ctr
+    PODMAN_TIMEOUT=10 run_podman healthcheck logs --follow --format "{{.ExitCode}}" $ctr
+    is "${#lines[@]}" 4 "all probes are shown before follow stops"
+
+    run_podman rm -f -t0 $ctr
+}
+
 @test "podman healthcheck --health-on-failure with interval" {
     ctr="healthcheck_c"
 
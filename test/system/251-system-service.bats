@@ -24,6 +24,26 @@ function teardown() {
     is "$output" "Error: API Service endpoint scheme \"\" is not supported. Try tcp://myunix.sock or unix://myunix.sock"
 }
 
+@test "podman system service --enable-metrics" {
+    skip_if_remote "podman system service unavailable over remote"
+    URL=unix://$PODMAN_TMPDIR/metrics.sock
+
+    systemd-run --unit=$SERVICE_NAME $PODMAN system service --enable-metrics $URL --time=0
+    wait_for_file $PODMAN_TMPDIR/metrics.sock
+
+    run_podman --host $URL info --format '{{.Host.RemoteSocket.Path}}'
+    run curl -s --max-time 10 --unix-socket $PODMAN_TMPDIR/metrics.sock http://d/metrics
+    assert "$status" -eq 0 "curl /metrics"
+    assert "$output" =~ "# TYPE podman_api_request_duration_seconds histogram" "request latency histogram"
+    assert "$output" =~ 'podman_api_request_duration_seconds_count\{method="GET",route="[^"]*/libpod/info",code="200"\} 1' \
+           "info request is accounted"
+    assert "$output" =~ "# TYPE podman_containers gauge" "container state counts"
+    assert "$output" =~ "# TYPE podman_image_size_bytes gauge" "image disk usage"
+
+    systemctl stop $SERVICE_NAME
+    rm -f $PODMAN_TMPDIR/metrics.sock
+}
+
 @test "podman system service unix: without two slashes still works" {
     skip_if_remote "podman system service unavailable over remote"
     URL=unix:$PODMAN_TMPDIR/myunix.sock
@@ -102,3 +122,69 @@ function teardown() {
     run_podman --url $URL rm $cname
     systemctl stop $SERVICE_NAME
 }
+
+@test "podman system service --drain-timeout waits for requests in flight" {
+    skip_if_remote "podman system service unavailable over remote"
+    local sock=$PODMAN_TMPDIR/drain.sock
+    URL=unix://$sock
+
+    systemd-run --unit=$SERVICE_NAME $PODMAN system service --drain-timeout=30 $URL --time=0
+    wait_for_file $sock
+
+    cname=c-$(random_string)
+    run_podman --url $URL run -d --name $cname $IMAGE sleep 3
+
+    # Wait for the container in a request which is in flight when the
+    # service is stopped.
+    curl -s --max-time 30 --unix-socket $sock -XPOST \
+         "http://d/v4.0.0/libpod/containers/$cname/wait" > $PODMAN_TMPDIR/wait.out &
+    local curl_pid=$!
+    sleep 1
+
+    systemctl kill --signal=TERM $SERVICE_NAME
+    sleep 0.5
+    run curl -s --max-time 5 --unix-socket $sock http://d/_ping
+    assert "$status" -ne 0 "service does not accept new connections while draining"
+    run systemctl is-active $SERVICE_NAME
+    assert "$output" = "active" "service is still running while draining"
+
+    wait $curl_pid
+    assert "$(< $PODMAN_TMPDIR/wait.out)" = "0" "request in flight finished"
+
+    # The service exits once the request finished.
+    for i in {1..20}; do
+        run systemctl is-active $SERVICE_NAME
+        if [[ "$output" != "active" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$output" != "active" "service exits once drained"
+
+    run_podman rm -f -t 0 $cname
+    rm -f $sock
+}
+
+@test "podman system service drain via the API" {
+    skip_if_remote "podman system service unavailable over remote"
+    local sock=$PODMAN_TMPDIR/drain-api.sock
+    URL=unix://$sock
+
+    systemd-run --unit=$SERVICE_NAME $PODMAN system service $URL --time=0
+    wait_for_file $sock
+
+    run curl -s --max-time 10 --unix-socket $sock -XPOST "http://d/v4.0.0/libpod/system/drain?timeout=10"
+    assert "$status" -eq 0 "drain request"
+    assert "$output" =~ '"Deadline":' "drain report includes the deadline"
+    assert "$output" =~ '"Requests":\[\]' "no other requests in flight"
+
+    for i in {1..20}; do
+        run systemctl is-active $SERVICE_NAME
+        if [[ "$output" != "active" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$output" != "active" "service exits once drained"
+    rm -f $sock
+}
@@ -341,6 +341,108 @@ EOF
     _confirm_update $cname $newID
 }
 
+@test "podman auto-update - rollback of unhealthy container" {
+    dockerfile1=$PODMAN_TMPDIR/Dockerfile.1
+    cat >$dockerfile1 <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s --retries=1 CMD test -e /healthy
+RUN touch /healthy
+EOF
+
+    dockerfile2=$PODMAN_TMPDIR/Dockerfile.2
+    cat >$dockerfile2 <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s --retries=1 CMD test -e /healthy
+EOF
+    image=quay.io/libpod/localtest:latest
+
+    # Health checks are only supported by the docker format.
+    run_podman build --format docker -t $image -f $dockerfile1
+    run_podman image inspect --format "{{.ID}}" $image
+    healthyID="$output"
+
+    generate_service localtest local "" "" noTag
+    _wait_service_ready container-$cname.service
+
+    # The new image lacks the file the health check looks for.
+    run_podman build --format docker -t $image -f $dockerfile2
+    run_podman auto-update --health-timeout 30s --format "{{.Unit}},{{.Image}},{{.Updated}},{{.Policy}},{{.Reason}}"
+    is "$output" ".*container-$cname.service,$image,rolled back,local,.*container .* is unhealthy.*" "Rolled back unhealthy container"
+
+    _wait_service_ready container-$cname.service
+    run_podman container inspect --format "{{.Image}}" $cname
+    is "$output" "$healthyID" "container rolled back to healthy image"
+}
+
+@test "podman auto-update - container without systemd unit" {
+    dockerfile1=$PODMAN_TMPDIR/Dockerfile.1
+    cat >$dockerfile1 <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s --retries=1 CMD test -e /healthy
+RUN touch /healthy
+EOF
+
+    dockerfile2=$PODMAN_TMPDIR/Dockerfile.2
+    cat >$dockerfile2 <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s --retries=1 CMD test -e /healthy
+RUN touch /healthy /updated
+EOF
+
+    dockerfile3=$PODMAN_TMPDIR/Dockerfile.3
+    cat >$dockerfile3 <<EOF
+FROM $IMAGE
+HEALTHCHECK --interval=1s --retries=1 CMD test -e /healthy
+EOF
+    image=quay.io/libpod/localtest:latest
+
+    # Health checks are only supported by the docker format.
+    run_podman build --format docker -t $image -f $dockerfile1
+
+    local cname=c-noservice-$(random_string)
+    local netname=n-noservice-$(random_string)
+    local vname=v-noservice-$(random_string)
+    run_podman network create $netname
+    run_podman run -d --name $cname --network $netname -v $vname:/vol \
+               --label io.containers.autoupdate=local $image top -d 120
+    local old_cid="$output"
+    run_podman exec $cname touch /vol/data
+
+    run_podman build --format docker -t $image -f $dockerfile2
+    run_podman image inspect --format "{{.ID}}" $image
+    local updatedID="$output"
+
+    run_podman auto-update --health-timeout 30s --format "{{.ContainerName}},{{.Unit}},{{.Image}},{{.Updated}},{{.Policy}}"
+    is "$output" "$cname,,$image,true,local" "container recreated"
+
+    # The container has been recreated on the new image with its name,
+    # network and volume.
+    run_podman container inspect --format "{{.ID}} {{.Image}} {{.State.Status}}" $cname
+    assert "$output" =~ "^[0-9a-f]+ $updatedID running$" "container runs the new image"
+    assert "${output%% *}" != "$old_cid" "container has been recreated"
+    run_podman container inspect --format "{{range \$n, \$v := .NetworkSettings.Networks}}{{\$n}}{{end}}" $cname
+    is "$output" "$netname" "network preserved"
+    run_podman exec $cname ls /vol/data /updated
+    run_podman ps -a --format "{{.Names}}"
+    assert "$output" !~ "$cname-autoupdate" "previous container removed"
+    run_podman container inspect --format "{{.ID}}" $cname
+    local updated_cid="$output"
+
+    # An unhealthy update restores the previous container.
+    run_podman build --format docker -t $image -f $dockerfile3
+    run_podman auto-update --health-timeout 30s --format "{{.ContainerName}},{{.Updated}},{{.Reason}}"
+    is "$output" "$cname,rolled back,.*container .* is unhealthy.*" "Rolled back unhealthy container"
+
+    run_podman container inspect --format "{{.ID}} {{.Image}} {{.State.Status}}" $cname
+    is "$output" "$updated_cid $updatedID running" "previous container restored"
+    run_podman ps -a --format "{{.Names}}"
+    is "$output" "$cname" "only the restored container is left"
+
+    run_podman rm -f -t0 $cname
+    run_podman network rm $netname
+    run_podman volume rm $vname
+}
+
 @test "podman auto-update with multiple services" {
     # Preserve original image ID, to confirm that it changes (or not)
     run_podman inspect --format "{{.Id}}" $IMAGE
@@ -688,4 +790,48 @@ EOF
     run_podman rmi $image_on_local_registry
 }
 
+@test "podman auto-update - semver policy" {
+    registry=localhost:${PODMAN_LOGIN_REGISTRY_PORT}
+    repo=$registry/semver$(random_string 6 | tr A-Z a-z)
+    authfile=$PODMAN_TMPDIR/authfile.json
+
+    start_registry
+    run_podman login --authfile=$authfile \
+        --tls-verify=false \
+        --username ${PODMAN_LOGIN_USER} \
+        --password ${PODMAN_LOGIN_PASS} \
+        $registry
+
+    # Push three versions, only one of them is a patch update.
+    dockerfile=$PODMAN_TMPDIR/Dockerfile
+    for version in 1.4.1 1.4.2 1.5.0; do
+        echo -e "FROM $IMAGE\nRUN echo $version > /version" > $dockerfile
+        run_podman build -t $repo:$version -f $dockerfile
+        run_podman push --tls-verify=false --authfile=$authfile $repo:$version
+        run_podman rmi $repo:$version
+    done
+    run_podman pull --tls-verify=false --authfile=$authfile $repo:1.4.1
+
+    generate_service "" "" top "--label io.containers.autoupdate=semver:~1.4" noTag "" $repo:1.4.1
+    ctr=$cname
+    _wait_service_ready container-$ctr.service
+
+    run_podman auto-update --authfile=$authfile --tls-verify=false --dry-run --format "{{.Unit}},{{.Updated}},{{.Policy}},{{.Version}},{{.NewVersion}},{{.NewImage}},{{.NewDigest}}"
+    is "$output" "container-$ctr.service,pending,semver,1.4.1,1.4.2,$repo:1.4.2,sha256:.*" "patch update is pending"
+
+    run_podman auto-update --authfile=$authfile --tls-verify=false --format "{{.Unit}},{{.Updated}},{{.Policy}}"
+    is "$output" ".*container-$ctr.service,true,semver" "container updated to patch release"
+    _confirm_update $ctr $ori_image
+
+    run_podman exec $ctr cat /version
+    is "$output" "1.4.2" "container runs the newest patch release"
+
+    run_podman auto-update --authfile=$authfile --tls-verify=false --dry-run --format "{{.Unit}},{{.Updated}},{{.Version}},{{.NewVersion}}"
+    is "$output" "container-$ctr.service,false,1.4.2," "no update beyond the version range"
+
+    systemctl stop container-$ctr.service
+    run_podman rm -f -t0 --ignore $ctr
+    run_podman rmi -f $repo:1.4.1 $repo:1.4.2
+}
+
 # vim: filetype=sh
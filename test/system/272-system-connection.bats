@@ -143,6 +143,81 @@ $c2[ ]\+tcp://localhost:54321[ ]\+true" \
     run_podman system connection rm myconnect
 }
 
+# Test tcp+tls socket with client certificates; requires starting a local server
+@test "podman system connection - tcp+tls" {
+    _SERVICE_PORT=$(random_free_port 63000-64999)
+
+    # Create a CA, a server certificate for localhost and a client certificate
+    local certs=$PODMAN_TMPDIR/certs
+    mkdir -p $certs
+    openssl req -x509 -newkey rsa:2048 -nodes -days 1 -subj "/CN=podman-test-ca" \
+            -keyout $certs/ca-key.pem -out $certs/ca.pem
+    openssl req -newkey rsa:2048 -nodes -subj "/CN=localhost" \
+            -keyout $certs/server-key.pem -out $certs/server.csr
+    echo "subjectAltName=DNS:localhost,IP:127.0.0.1" > $certs/server.ext
+    openssl x509 -req -days 1 -in $certs/server.csr -extfile $certs/server.ext \
+            -CA $certs/ca.pem -CAkey $certs/ca-key.pem -CAcreateserial -out $certs/server.pem
+    openssl req -newkey rsa:2048 -nodes -subj "/CN=podman-test-client" \
+            -keyout $certs/client-key.pem -out $certs/client.csr
+    openssl x509 -req -days 1 -in $certs/client.csr \
+            -CA $certs/ca.pem -CAkey $certs/ca-key.pem -CAcreateserial -out $certs/client.pem
+
+    ${PODMAN%%-remote*} $(podman_isolation_opts ${PODMAN_TMPDIR}) \
+                        system service -t 99 \
+                        --tls-cert $certs/server.pem --tls-key $certs/server-key.pem \
+                        --tls-client-ca $certs/ca.pem \
+                        tcp://localhost:$_SERVICE_PORT &
+    _SERVICE_PID=$!
+    wait_for_port 127.0.0.1 $_SERVICE_PORT
+
+    # Without a client certificate the server must reject the connection
+    run_podman system connection add --tls-ca $certs/ca.pem nocert tcp+tls://localhost:$_SERVICE_PORT
+    _run_podman_remote 125 --connection nocert info
+    assert "$output" =~ "Cannot connect to Podman" "connection without client certificate is rejected"
+
+    run_podman system connection add --tls-ca $certs/ca.pem \
+               --tls-cert $certs/client.pem --tls-key $certs/client-key.pem \
+               mtls tcp+tls://localhost:$_SERVICE_PORT
+    local timeout=10
+    while [[ $timeout -gt 1 ]]; do
+        _run_podman_remote '?' --connection mtls info --format '{{.Store.GraphRoot}}'
+        if [[ $status == 0 ]]; then
+            break
+        fi
+        sleep 1
+        let timeout=$timeout-1
+    done
+    is "$output" "${PODMAN_TMPDIR}/root" "podman info over mutual TLS"
+
+    run_podman system connection ls --format '{{.Name}} {{.URI}}'
+    assert "$output" =~ "mtls tcp\+tls://localhost:$_SERVICE_PORT\?.*tls_cert=" \
+           "client certificate is stored with the connection"
+
+    # The same settings can be given in the URL
+    local query="tls_ca=$certs/ca.pem&tls_cert=$certs/client.pem&tls_key=$certs/client-key.pem"
+    _run_podman_remote --url "tcp+tls://localhost:$_SERVICE_PORT?$query" info --format '{{.Store.GraphRoot}}'
+    is "$output" "${PODMAN_TMPDIR}/root" "podman info over mutual TLS with --url"
+
+    # TLS options are refused for schemes other than tcp+tls
+    run_podman 125 system connection add --tls-ca $certs/ca.pem plain tcp://localhost:$_SERVICE_PORT
+    is "$output" "Error: --tls-cert, --tls-key and --tls-ca options are not supported for tcp scheme"
+
+    run kill $_SERVICE_PID
+    run wait $_SERVICE_PID
+    _SERVICE_PID=
+
+    run_podman system connection rm mtls
+    run_podman system connection rm nocert
+
+    # TLS without client authentication must be requested explicitly
+    run ${PODMAN%%-remote*} $(podman_isolation_opts ${PODMAN_TMPDIR}) \
+        system service -t 1 --tls-cert $certs/server.pem --tls-key $certs/server-key.pem \
+        tcp://localhost:$_SERVICE_PORT
+    assert "$status" -eq 125 "exit status of system service"
+    assert "$output" =~ "requires --tls-client-ca to authenticate clients" \
+           "TLS without a client CA is refused"
+}
+
 # If we have ssh access to localhost (unlikely in CI), test that.
 @test "podman system connection - ssh" {
     # system connection only really works if we have an agent
@@ -0,0 +1,29 @@
+#!/usr/bin/env bats   -*- bats -*-
+#
+# tests for podman system check
+#
+
+load helpers
+
+@test "podman system check - consistent state" {
+    local volname=v-$(random_string 10)
+    run_podman run -d -v $volname:/vol $IMAGE top
+    cid="$output"
+    run_podman exec $cid true
+
+    for opts in "" "--quick" "--repair" "--quick --repair"; do
+        run_podman system check $opts
+        is "$output" "No inconsistencies found" "podman system check $opts"
+    done
+
+    run_podman system check --format json
+    is "$output" "\[\]" "no issues as JSON"
+
+    run_podman system check --format '{{.Kind}}'
+    is "$output" "" "no issues with a Go template"
+
+    run_podman rm -t 0 -f $cid
+    run_podman volume rm $volname
+}
+
+# vim: filetype=sh
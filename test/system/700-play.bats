@@ -940,6 +940,46 @@ spec:
     run_podman rm -a
 }
 
+@test "podman kube play - preStop hook is bounded by the grace period" {
+    fname="$PODMAN_TMPDIR/play_kube_prestop_$(random_string 6).yaml"
+    echo "
+apiVersion: v1
+kind: Pod
+metadata:
+  name: prestop
+spec:
+  terminationGracePeriodSeconds: 3
+  containers:
+  - name: hang
+    image: $IMAGE
+    command:
+    - sleep
+    - \"600\"
+    lifecycle:
+      preStop:
+        exec:
+          command:
+          - sleep
+          - \"600\"
+" > $fname
+
+    run_podman kube play $fname
+    ctrName="prestop-hang"
+
+    # The hook hangs, so it is killed when the grace period expires and
+    # the container is stopped right away.
+    t0=$SECONDS
+    run_podman stop $ctrName
+    local duration=$((SECONDS - t0))
+    assert "$duration" -lt 10 "podman stop took $duration seconds"
+    assert "$output" =~ "PreStop hook failed: .*timed out after 3s" "hook killed on timeout"
+
+    run_podman ps -a --filter name=$ctrName --format "{{.State}}"
+    is "$output" "exited" "container stopped"
+
+    run_podman kube down $fname
+}
+
 @test "podman play --build private registry" {
     skip_if_remote "--build is not supported in context remote"
 